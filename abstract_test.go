@@ -395,6 +395,75 @@ func TestIsTypeOfUsedToResolveRuntimeTypeForUnion(t *testing.T) {
 	}
 }
 
+func TestTypedValuesResolveTheirRuntimeType(t *testing.T) {
+	fields := graphql.Fields{
+		"name": &graphql.Field{
+			Type: graphql.String,
+		},
+	}
+	dogType := graphql.NewObject(graphql.ObjectConfig{Name: "Dog", Fields: fields})
+	catType := graphql.NewObject(graphql.ObjectConfig{Name: "Cat", Fields: fields})
+	petType := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "Pet",
+		Types: []*graphql.Object{dogType, catType},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.NewList(petType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						// the values are equal, only their type names differ
+						return []interface{}{
+							graphql.TypedValue{TypeName: "Dog", Value: map[string]interface{}{"name": "Odie"}},
+							graphql.TypedValue{TypeName: "Cat", Value: map[string]interface{}{"name": "Odie"}},
+							graphql.TypedValue{TypeName: "Cat", Value: nil},
+						}, nil
+					},
+				},
+				"invalid": &graphql.Field{
+					Type: petType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return graphql.TypedValue{TypeName: "Query", Value: map[string]interface{}{}}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ pets { __typename ... on Dog { name } ... on Cat { name } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"pets": []interface{}{
+				map[string]interface{}{"__typename": "Dog", "name": "Odie"},
+				map[string]interface{}{"__typename": "Cat", "name": "Odie"},
+				nil,
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ invalid { __typename } }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Runtime Object type "Query" is not a possible type for "Pet".` {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+}
+
 func TestResolveTypeOnInterfaceYieldsUsefulError(t *testing.T) {
 
 	var dogType *graphql.Object
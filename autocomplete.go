@@ -0,0 +1,920 @@
+package graphql
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/lexer"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Position is a zero-based line and character offset in a document, the
+// characters being counted in runes.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// SuggestionKind is the kind of the element suggested by
+// GetAutocompleteSuggestions.
+type SuggestionKind string
+
+const (
+	SuggestionKeyword    SuggestionKind = "Keyword"
+	SuggestionField      SuggestionKind = "Field"
+	SuggestionArgument   SuggestionKind = "Argument"
+	SuggestionInputField SuggestionKind = "InputField"
+	SuggestionEnumValue  SuggestionKind = "EnumValue"
+	SuggestionValue      SuggestionKind = "Value"
+	SuggestionVariable   SuggestionKind = "Variable"
+	SuggestionDirective  SuggestionKind = "Directive"
+	SuggestionType       SuggestionKind = "Type"
+	SuggestionFragment   SuggestionKind = "Fragment"
+)
+
+// Suggestion is a candidate for the name or value typed at the cursor.
+type Suggestion struct {
+	Label string         `json:"label"`
+	Kind  SuggestionKind `json:"kind"`
+	// Type is the signature of the suggested element: the type of a field,
+	// argument, input field, variable or enum value, the definition of a
+	// directive, type or fragment.
+	Type              string `json:"type,omitempty"`
+	Description       string `json:"description,omitempty"`
+	IsDeprecated      bool   `json:"isDeprecated,omitempty"`
+	DeprecationReason string `json:"deprecationReason,omitempty"`
+}
+
+// GetAutocompleteSuggestions returns the candidates for the name or value
+// typed at the cursor of query: the fields of the selection set at the
+// cursor, the arguments of a field or directive, the values of an argument
+// or variable, directives, types, fragments and keywords.
+//
+// The context of the cursor is found by walking the tokens before it rather
+// than by parsing, since the query being edited is rarely valid: the walk
+// feeds the enclosing operation, selection sets, fields, arguments and
+// directives to a TypeInfo, which then knows the type at the cursor.
+//
+// Candidates matching the part of the name typed before the cursor are
+// ranked first, by case-sensitive then case-insensitive prefix, followed by
+// the candidates containing it. Deprecated candidates come last among
+// equally matching ones.
+func GetAutocompleteSuggestions(schema *Schema, query string, cursor Position) []*Suggestion {
+	c := &completer{
+		schema:   schema,
+		typeInfo: NewTypeInfo(&TypeInfoConfig{Schema: schema}),
+	}
+	if schema == nil || !c.tokenize(query, cursorOffset(query, cursor)) {
+		return []*Suggestion{}
+	}
+	c.collectFragments()
+	c.document()
+
+	suggestions := []*Suggestion{}
+	ranks := map[*Suggestion]int{}
+	for _, suggestion := range c.suggestions {
+		rank := suggestionRank(suggestion.Label, c.prefix)
+		if rank < 0 {
+			continue
+		}
+		if suggestion.IsDeprecated {
+			rank++
+		}
+		ranks[suggestion] = rank
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return ranks[suggestions[i]] < ranks[suggestions[j]]
+	})
+	return suggestions
+}
+
+// suggestionRank ranks how label matches prefix, leaving room for a
+// deprecation penalty, or returns -1 if it does not.
+func suggestionRank(label, prefix string) int {
+	switch {
+	case strings.HasPrefix(label, prefix):
+		return 0
+	case strings.HasPrefix(strings.ToLower(label), strings.ToLower(prefix)):
+		return 2
+	case strings.Contains(strings.ToLower(label), strings.ToLower(prefix)):
+		return 4
+	}
+	return -1
+}
+
+// cursorOffset returns the byte offset of cursor in query, clamped to the
+// line and to the query.
+func cursorOffset(query string, cursor Position) int {
+	offset := 0
+	for line := 0; line < cursor.Line; line++ {
+		next := strings.IndexByte(query[offset:], '\n')
+		if next < 0 {
+			return len(query)
+		}
+		offset += next + 1
+	}
+	for character := 0; character < cursor.Character && offset < len(query) && query[offset] != '\n'; character++ {
+		_, size := utf8.DecodeRuneInString(query[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// completer walks the tokens before the cursor. Each of its grammar methods
+// consumes the tokens of a construct and returns true when the cursor is
+// reached, after recording the candidates valid there.
+type completer struct {
+	schema   *Schema
+	typeInfo *TypeInfo
+
+	tokens []lexer.Token
+	text   string
+	pos    int
+	// prefix is the part of the name being typed at the cursor
+	prefix string
+
+	variables   []*ast.VariableDefinition
+	fragments   map[string]string
+	suggestions []*Suggestion
+}
+
+// tokenize lexes text up to offset, skipping the characters that cannot be
+// lexed. It reports false when the cursor is within a string or a comment.
+func (c *completer) tokenize(text string, offset int) bool {
+	c.text = text
+	s := source.NewSource(&source.Source{Body: []byte(text)})
+	lex := lexer.Lex(s)
+	position := 0
+	end := 0
+	for {
+		token, err := lex(position)
+		if err != nil {
+			// the lexer resumes from the given position, 0 meaning the
+			// end of the previous token
+			position++
+			if position >= len(text) || position >= offset {
+				break
+			}
+			continue
+		}
+		position = token.End
+		if token.Kind == lexer.EOF || token.Start >= offset {
+			break
+		}
+		if token.End >= offset {
+			switch token.Kind {
+			case lexer.NAME:
+				c.prefix = text[token.Start:offset]
+				return true
+			case lexer.STRING, lexer.BLOCK_STRING:
+				if token.End > offset {
+					return false
+				}
+			case lexer.INT, lexer.FLOAT:
+				return false
+			}
+		}
+		c.tokens = append(c.tokens, token)
+		end = token.End
+	}
+	return !inComment(text, end, offset)
+}
+
+// collectFragments records the names and type conditions of the fragments
+// defined in the text, before or after the cursor.
+func (c *completer) collectFragments() {
+	c.fragments = map[string]string{}
+	tokens := []lexer.Token{}
+	lex := lexer.Lex(source.NewSource(&source.Source{Body: []byte(c.text)}))
+	for position := 0; position < len(c.text); {
+		token, err := lex(position)
+		if err != nil {
+			position++
+			continue
+		}
+		if token.Kind == lexer.EOF {
+			break
+		}
+		tokens = append(tokens, token)
+		position = token.End
+	}
+	for i := 0; i+3 < len(tokens); i++ {
+		if tokens[i].Kind == lexer.NAME && tokens[i].Value == lexer.FRAGMENT && tokens[i+1].Kind == lexer.NAME &&
+			tokens[i+2].Kind == lexer.NAME && tokens[i+2].Value == "on" && tokens[i+3].Kind == lexer.NAME {
+			c.fragments[tokens[i+1].Value] = tokens[i+3].Value
+		}
+	}
+}
+
+// inComment reports whether a comment starts between start and end on the
+// line of end.
+func inComment(text string, start, end int) bool {
+	if end > len(text) {
+		end = len(text)
+	}
+	lineStart := strings.LastIndexAny(text[:end], "\r\n") + 1
+	if lineStart > start {
+		start = lineStart
+	}
+	return start < end && strings.Contains(text[start:end], "#")
+}
+
+func (c *completer) atCursor() bool {
+	return c.pos >= len(c.tokens)
+}
+
+func (c *completer) peek(kind lexer.TokenKind) bool {
+	return !c.atCursor() && c.tokens[c.pos].Kind == kind
+}
+
+func (c *completer) peekKeyword(value string) bool {
+	return c.peek(lexer.NAME) && c.tokens[c.pos].Value == value
+}
+
+func (c *completer) advance() lexer.Token {
+	token := c.tokens[c.pos]
+	c.pos++
+	return token
+}
+
+func (c *completer) document() {
+	for !c.atCursor() {
+		switch {
+		case c.peek(lexer.BRACE_L):
+			// query shorthand
+			op := ast.NewOperationDefinition(&ast.OperationDefinition{Operation: ast.OperationTypeQuery})
+			c.typeInfo.Enter(op)
+			c.variables = nil
+			if c.selectionSet() {
+				return
+			}
+			c.typeInfo.Leave(op)
+		case c.peekKeyword(ast.OperationTypeQuery), c.peekKeyword(ast.OperationTypeMutation), c.peekKeyword(ast.OperationTypeSubscription):
+			if c.operation() {
+				return
+			}
+		case c.peekKeyword(lexer.FRAGMENT):
+			if c.fragment() {
+				return
+			}
+		default:
+			if !c.skipDefinition() {
+				return
+			}
+		}
+	}
+	for _, keyword := range []string{ast.OperationTypeQuery, ast.OperationTypeMutation, ast.OperationTypeSubscription, lexer.FRAGMENT} {
+		c.suggestions = append(c.suggestions, &Suggestion{Label: keyword, Kind: SuggestionKeyword})
+	}
+}
+
+// skipDefinition skips the tokens of a type system definition, or of tokens
+// not making a definition. It reports false when the cursor is within braces.
+func (c *completer) skipDefinition() bool {
+	depth := 0
+	for !c.atCursor() {
+		if depth == 0 && c.pos > 0 && (c.peek(lexer.BRACE_L) && c.tokens[c.pos-1].Kind == lexer.BRACE_R ||
+			c.peekKeyword(ast.OperationTypeQuery) || c.peekKeyword(ast.OperationTypeMutation) ||
+			c.peekKeyword(ast.OperationTypeSubscription) || c.peekKeyword(lexer.FRAGMENT)) {
+			return true
+		}
+		switch c.advance().Kind {
+		case lexer.BRACE_L, lexer.PAREN_L, lexer.BRACKET_L:
+			depth++
+		case lexer.BRACE_R, lexer.PAREN_R, lexer.BRACKET_R:
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	return depth == 0
+}
+
+func (c *completer) operation() bool {
+	operation := c.advance().Value
+	op := ast.NewOperationDefinition(&ast.OperationDefinition{Operation: operation})
+	c.typeInfo.Enter(op)
+	c.variables = nil
+	if c.atCursor() {
+		return true
+	}
+	if c.peek(lexer.NAME) {
+		c.advance()
+		if c.atCursor() {
+			return true
+		}
+	}
+	if c.peek(lexer.PAREN_L) && c.variableDefinitions() {
+		return true
+	}
+	location := map[string]string{
+		ast.OperationTypeQuery:        DirectiveLocationQuery,
+		ast.OperationTypeMutation:     DirectiveLocationMutation,
+		ast.OperationTypeSubscription: DirectiveLocationSubscription,
+	}[operation]
+	if c.directives(location) {
+		return true
+	}
+	if c.peek(lexer.BRACE_L) && c.selectionSet() {
+		return true
+	}
+	c.typeInfo.Leave(op)
+	return false
+}
+
+func (c *completer) fragment() bool {
+	c.advance()
+	if c.atCursor() {
+		return true
+	}
+	if c.peek(lexer.NAME) {
+		c.advance()
+	}
+	if c.atCursor() {
+		return true
+	}
+	if !c.peekKeyword("on") {
+		return false
+	}
+	c.advance()
+	if c.atCursor() {
+		c.typeCandidates(false)
+		return true
+	}
+	if !c.peek(lexer.NAME) {
+		return false
+	}
+	def := ast.NewFragmentDefinition(&ast.FragmentDefinition{TypeCondition: named(c.advance().Value)})
+	c.typeInfo.Enter(def)
+	if c.directives(DirectiveLocationFragmentDefinition) {
+		return true
+	}
+	if c.peek(lexer.BRACE_L) && c.selectionSet() {
+		return true
+	}
+	c.typeInfo.Leave(def)
+	return false
+}
+
+func (c *completer) variableDefinitions() bool {
+	c.advance()
+	for {
+		switch {
+		case c.atCursor():
+			return true
+		case c.peek(lexer.PAREN_R):
+			c.advance()
+			return false
+		case c.peek(lexer.DOLLAR):
+			c.advance()
+			if c.atCursor() || !c.peek(lexer.NAME) {
+				continue
+			}
+			def := ast.NewVariableDefinition(&ast.VariableDefinition{
+				Variable: ast.NewVariable(&ast.Variable{Name: ast.NewName(&ast.Name{Value: c.advance().Value})}),
+			})
+			if c.atCursor() || !c.peek(lexer.COLON) {
+				continue
+			}
+			c.advance()
+			ttype, done := c.typeRef()
+			if done {
+				return true
+			}
+			def.Type = ttype
+			c.variables = append(c.variables, def)
+			if c.peek(lexer.EQUALS) && ttype != nil {
+				c.advance()
+				c.typeInfo.Enter(def)
+				if c.value() {
+					return true
+				}
+				c.typeInfo.Leave(def)
+			}
+			// no directive is defined on variables by the specified ones
+			if c.directives("") {
+				return true
+			}
+		default:
+			c.advance()
+		}
+	}
+}
+
+func (c *completer) typeRef() (ast.Type, bool) {
+	var ttype ast.Type
+	switch {
+	case c.atCursor():
+		c.typeCandidates(true)
+		return nil, true
+	case c.peek(lexer.BRACKET_L):
+		c.advance()
+		ofType, done := c.typeRef()
+		if done {
+			return nil, true
+		}
+		if c.peek(lexer.BRACKET_R) {
+			c.advance()
+		}
+		ttype = ast.NewList(&ast.List{Type: ofType})
+	case c.peek(lexer.NAME):
+		ttype = named(c.advance().Value)
+	default:
+		return nil, false
+	}
+	if c.peek(lexer.BANG) {
+		c.advance()
+		ttype = ast.NewNonNull(&ast.NonNull{Type: ttype})
+	}
+	return ttype, false
+}
+
+func (c *completer) selectionSet() bool {
+	c.advance()
+	selectionSet := ast.NewSelectionSet(nil)
+	c.typeInfo.Enter(selectionSet)
+	for {
+		switch {
+		case c.atCursor():
+			c.fieldCandidates()
+			return true
+		case c.peek(lexer.BRACE_R):
+			c.advance()
+			c.typeInfo.Leave(selectionSet)
+			return false
+		case c.peek(lexer.SPREAD):
+			if c.fragmentSelection() {
+				return true
+			}
+		case c.peek(lexer.NAME):
+			if c.field() {
+				return true
+			}
+		default:
+			c.advance()
+		}
+	}
+}
+
+func (c *completer) field() bool {
+	name := c.advance().Value
+	if c.atCursor() {
+		// the name is complete, another selection follows
+		c.fieldCandidates()
+		return true
+	}
+	if c.peek(lexer.COLON) {
+		c.advance()
+		if c.atCursor() {
+			c.fieldCandidates()
+			return true
+		}
+		if c.peek(lexer.NAME) {
+			name = c.advance().Value
+		}
+	}
+	field := ast.NewField(&ast.Field{Name: ast.NewName(&ast.Name{Value: name})})
+	c.typeInfo.Enter(field)
+	if c.peek(lexer.PAREN_L) && c.arguments() {
+		return true
+	}
+	if c.directives(DirectiveLocationField) {
+		return true
+	}
+	if c.peek(lexer.BRACE_L) && c.selectionSet() {
+		return true
+	}
+	c.typeInfo.Leave(field)
+	return false
+}
+
+func (c *completer) fragmentSelection() bool {
+	c.advance()
+	switch {
+	case c.atCursor():
+		c.spreadCandidates()
+		return true
+	case c.peekKeyword("on"):
+		c.advance()
+		if c.atCursor() {
+			c.typeCandidates(false)
+			return true
+		}
+		var condition *ast.Named
+		if c.peek(lexer.NAME) {
+			condition = named(c.advance().Value)
+		}
+		return c.inlineFragment(condition)
+	case c.peek(lexer.AT), c.peek(lexer.BRACE_L):
+		return c.inlineFragment(nil)
+	case c.peek(lexer.NAME):
+		c.advance()
+		return c.directives(DirectiveLocationFragmentSpread)
+	}
+	return false
+}
+
+func (c *completer) inlineFragment(condition *ast.Named) bool {
+	fragment := ast.NewInlineFragment(&ast.InlineFragment{TypeCondition: condition})
+	c.typeInfo.Enter(fragment)
+	if c.directives(DirectiveLocationInlineFragment) {
+		return true
+	}
+	if c.peek(lexer.BRACE_L) && c.selectionSet() {
+		return true
+	}
+	c.typeInfo.Leave(fragment)
+	return false
+}
+
+func (c *completer) arguments() bool {
+	c.advance()
+	given := map[string]bool{}
+	for {
+		switch {
+		case c.atCursor():
+			c.argumentCandidates(given)
+			return true
+		case c.peek(lexer.PAREN_R):
+			c.advance()
+			return false
+		case c.peek(lexer.NAME):
+			name := c.advance().Value
+			given[name] = true
+			if c.atCursor() || !c.peek(lexer.COLON) {
+				continue
+			}
+			c.advance()
+			argument := ast.NewArgument(&ast.Argument{Name: ast.NewName(&ast.Name{Value: name})})
+			c.typeInfo.Enter(argument)
+			if c.value() {
+				return true
+			}
+			c.typeInfo.Leave(argument)
+		default:
+			c.advance()
+		}
+	}
+}
+
+func (c *completer) directives(location string) bool {
+	for c.peek(lexer.AT) {
+		c.advance()
+		if c.atCursor() {
+			c.directiveCandidates(location)
+			return true
+		}
+		if !c.peek(lexer.NAME) {
+			continue
+		}
+		directive := ast.NewDirective(&ast.Directive{Name: ast.NewName(&ast.Name{Value: c.advance().Value})})
+		c.typeInfo.Enter(directive)
+		if c.peek(lexer.PAREN_L) && c.arguments() {
+			return true
+		}
+		c.typeInfo.Leave(directive)
+	}
+	return false
+}
+
+func (c *completer) value() bool {
+	switch {
+	case c.atCursor():
+		c.valueCandidates()
+		return true
+	case c.peek(lexer.DOLLAR):
+		c.advance()
+		if c.atCursor() {
+			c.variableCandidates()
+			return true
+		}
+		if c.peek(lexer.NAME) {
+			c.advance()
+		}
+	case c.peek(lexer.BRACKET_L):
+		c.advance()
+		list := ast.NewListValue(nil)
+		c.typeInfo.Enter(list)
+		for !c.peek(lexer.BRACKET_R) {
+			if c.value() {
+				return true
+			}
+		}
+		c.advance()
+		c.typeInfo.Leave(list)
+	case c.peek(lexer.BRACE_L):
+		c.advance()
+		given := map[string]bool{}
+		for {
+			switch {
+			case c.atCursor():
+				c.objectFieldCandidates(given)
+				return true
+			case c.peek(lexer.BRACE_R):
+				c.advance()
+				return false
+			case c.peek(lexer.NAME):
+				name := c.advance().Value
+				given[name] = true
+				if c.atCursor() || !c.peek(lexer.COLON) {
+					continue
+				}
+				c.advance()
+				field := ast.NewObjectField(&ast.ObjectField{Name: ast.NewName(&ast.Name{Value: name})})
+				c.typeInfo.Enter(field)
+				if c.value() {
+					return true
+				}
+				c.typeInfo.Leave(field)
+			default:
+				c.advance()
+			}
+		}
+	case c.peek(lexer.NAME), c.peek(lexer.INT), c.peek(lexer.FLOAT), c.peek(lexer.STRING), c.peek(lexer.BLOCK_STRING):
+		c.advance()
+	}
+	return false
+}
+
+func named(name string) *ast.Named {
+	return ast.NewNamed(&ast.Named{Name: ast.NewName(&ast.Name{Value: name})})
+}
+
+func (c *completer) suggest(suggestion *Suggestion) {
+	c.suggestions = append(c.suggestions, suggestion)
+}
+
+// parentType returns the parent type of the cursor, or nil if it is unknown
+// or is a root type missing from the schema.
+func (c *completer) parentType() Composite {
+	// the missing root types are typed nil pointers
+	if parentType, ok := c.typeInfo.ParentType().(*Object); ok && parentType == nil {
+		return nil
+	}
+	return c.typeInfo.ParentType()
+}
+
+func (c *completer) fieldCandidates() {
+	parentType := c.parentType()
+	if parentType == nil {
+		return
+	}
+	var fields FieldDefinitionMap
+	switch parentType := parentType.(type) {
+	case *Object:
+		fields = parentType.Fields()
+	case *Interface:
+		fields = parentType.Fields()
+	}
+	for _, name := range sortedFieldNames(fields) {
+		field := fields[name]
+		c.suggest(&Suggestion{
+			Label:             name,
+			Kind:              SuggestionField,
+			Type:              field.Type.String(),
+			Description:       field.Description,
+			IsDeprecated:      field.DeprecationReason != "",
+			DeprecationReason: field.DeprecationReason,
+		})
+	}
+	meta := []*FieldDefinition{TypeNameMetaFieldDef}
+	if queryType := c.schema.QueryType(); queryType != nil && parentType.Name() == queryType.Name() {
+		meta = append(meta, SchemaMetaFieldDef, TypeMetaFieldDef)
+	}
+	for _, field := range meta {
+		c.suggest(&Suggestion{
+			Label:       field.Name,
+			Kind:        SuggestionField,
+			Type:        field.Type.String(),
+			Description: field.Description,
+		})
+	}
+}
+
+func (c *completer) spreadCandidates() {
+	parentType := c.parentType()
+	names := []string{}
+	for name := range c.fragments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		condition, _ := c.schema.Type(c.fragments[name]).(Composite)
+		if parentType != nil && condition != nil && !c.overlap(parentType, condition) {
+			continue
+		}
+		c.suggest(&Suggestion{
+			Label: name,
+			Kind:  SuggestionFragment,
+			Type:  "fragment " + name + " on " + c.fragments[name],
+		})
+	}
+	c.suggest(&Suggestion{Label: "on", Kind: SuggestionKeyword})
+}
+
+// overlap reports whether an object may be of both types.
+func (c *completer) overlap(a, b Composite) bool {
+	if a.Name() == b.Name() {
+		return true
+	}
+	for _, object := range c.possibleTypes(a) {
+		for _, other := range c.possibleTypes(b) {
+			if object.Name() == other.Name() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *completer) possibleTypes(ttype Composite) []*Object {
+	switch ttype := ttype.(type) {
+	case *Object:
+		return []*Object{ttype}
+	case Abstract:
+		return c.schema.PossibleTypes(ttype)
+	}
+	return nil
+}
+
+// typeCandidates suggests the input types of the schema, for variable
+// definitions, or the composite types possibly overlapping the parent type,
+// for type conditions.
+func (c *completer) typeCandidates(input bool) {
+	parentType := c.parentType()
+	typeMap := c.schema.TypeMap()
+	names := []string{}
+	for name := range typeMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ttype := typeMap[name]
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		if input {
+			if !IsInputType(ttype) {
+				continue
+			}
+		} else {
+			composite, ok := ttype.(Composite)
+			if !ok || parentType != nil && !c.overlap(parentType, composite) {
+				continue
+			}
+		}
+		c.suggest(&Suggestion{
+			Label:       name,
+			Kind:        SuggestionType,
+			Type:        typeKeyword(ttype) + " " + name,
+			Description: ttype.Description(),
+		})
+	}
+}
+
+func (c *completer) argumentCandidates(given map[string]bool) {
+	var args []*Argument
+	if directive := c.typeInfo.Directive(); directive != nil {
+		args = directive.Args
+	} else if fieldDef := c.typeInfo.FieldDef(); fieldDef != nil {
+		args = fieldDef.Args
+	}
+	for _, arg := range args {
+		if given[arg.Name()] {
+			continue
+		}
+		c.suggest(&Suggestion{
+			Label:       arg.Name(),
+			Kind:        SuggestionArgument,
+			Type:        arg.Type.String(),
+			Description: arg.Description(),
+		})
+	}
+}
+
+func (c *completer) objectFieldCandidates(given map[string]bool) {
+	object, ok := GetNamed(c.typeInfo.InputType()).(*InputObject)
+	if !ok {
+		return
+	}
+	fields := object.Fields()
+	names := []string{}
+	for name := range fields {
+		if !given[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.suggest(&Suggestion{
+			Label:       name,
+			Kind:        SuggestionInputField,
+			Type:        fields[name].Type.String(),
+			Description: fields[name].Description(),
+		})
+	}
+}
+
+func (c *completer) valueCandidates() {
+	switch ttype := GetNamed(c.typeInfo.InputType()).(type) {
+	case *Enum:
+		values := append([]*EnumValueDefinition{}, ttype.Values()...)
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].Name < values[j].Name
+		})
+		for _, value := range values {
+			c.suggest(&Suggestion{
+				Label:             value.Name,
+				Kind:              SuggestionEnumValue,
+				Type:              ttype.Name(),
+				Description:       value.Description,
+				IsDeprecated:      value.DeprecationReason != "",
+				DeprecationReason: value.DeprecationReason,
+			})
+		}
+	case *Scalar:
+		if ttype.Name() == Boolean.Name() {
+			for _, value := range []string{"true", "false"} {
+				c.suggest(&Suggestion{Label: value, Kind: SuggestionValue, Type: ttype.Name()})
+			}
+		}
+	}
+}
+
+func (c *completer) variableCandidates() {
+	for _, def := range c.variables {
+		c.suggest(&Suggestion{
+			Label: def.Variable.Name.Value,
+			Kind:  SuggestionVariable,
+			Type:  astTypeString(def.Type),
+		})
+	}
+}
+
+func (c *completer) directiveCandidates(location string) {
+	for _, directive := range c.schema.Directives() {
+		for _, directiveLocation := range directive.Locations {
+			if directiveLocation != location {
+				continue
+			}
+			c.suggest(&Suggestion{
+				Label:       directive.Name,
+				Kind:        SuggestionDirective,
+				Type:        directiveSignature(directive),
+				Description: directive.Description,
+			})
+			break
+		}
+	}
+}
+
+func sortedFieldNames(fields FieldDefinitionMap) []string {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func typeKeyword(ttype Type) string {
+	switch ttype.(type) {
+	case *Object:
+		return "type"
+	case *Interface:
+		return "interface"
+	case *Union:
+		return "union"
+	case *Enum:
+		return "enum"
+	case *InputObject:
+		return "input"
+	}
+	return "scalar"
+}
+
+func astTypeString(ttype ast.Type) string {
+	switch ttype := ttype.(type) {
+	case *ast.Named:
+		return ttype.Name.Value
+	case *ast.List:
+		return "[" + astTypeString(ttype.Type) + "]"
+	case *ast.NonNull:
+		return astTypeString(ttype.Type) + "!"
+	}
+	return ""
+}
+
+func directiveSignature(directive *Directive) string {
+	args := []string{}
+	for _, arg := range directive.Args {
+		args = append(args, arg.Name()+": "+arg.Type.String())
+	}
+	if len(args) == 0 {
+		return "@" + directive.Name
+	}
+	return "@" + directive.Name + "(" + strings.Join(args, ", ") + ")"
+}
@@ -0,0 +1,194 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+)
+
+func autocompleteTestSchema(t *testing.T) *graphql.Schema {
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: 0, Description: "The red color."},
+			"GREEN": &graphql.EnumValueConfig{Value: 1},
+			"BLUE":  &graphql.EnumValueConfig{Value: 2, DeprecationReason: "Too cold."},
+		},
+	})
+	petType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Pet",
+		Description: "A pet.",
+		Fields: graphql.Fields{
+			"name":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "The name of the pet."},
+			"nickname": &graphql.Field{Type: graphql.String, DeprecationReason: "Use name."},
+			"lastName": &graphql.Field{Type: graphql.String},
+			"color":    &graphql.Field{Type: colorType},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pets": &graphql.Field{
+					Type: graphql.NewList(petType),
+					Args: graphql.FieldConfigArgument{
+						"color": &graphql.ArgumentConfig{Type: colorType, Description: "Filters on the color."},
+						"first": &graphql.ArgumentConfig{Type: graphql.Int},
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &schema
+}
+
+// suggestionsAt returns the suggestions at the position marked by | in query.
+func suggestionsAt(t *testing.T, query string) []*graphql.Suggestion {
+	offset := strings.Index(query, "|")
+	query = query[:offset] + query[offset+1:]
+	lineStart := strings.LastIndex(query[:offset], "\n") + 1
+	cursor := graphql.Position{
+		Line:      strings.Count(query[:offset], "\n"),
+		Character: len([]rune(query[lineStart:offset])),
+	}
+	return graphql.GetAutocompleteSuggestions(autocompleteTestSchema(t), query, cursor)
+}
+
+func suggestionLabels(suggestions []*graphql.Suggestion) []string {
+	labels := []string{}
+	for _, suggestion := range suggestions {
+		labels = append(labels, suggestion.Label)
+	}
+	return labels
+}
+
+func TestGetAutocompleteSuggestions_SuggestsFieldsOfTheSelectionSet(t *testing.T) {
+	suggestions := suggestionsAt(t, "query {\n  pets {\n    |\n  }\n}")
+	expected := []*graphql.Suggestion{
+		{Label: "color", Kind: graphql.SuggestionField, Type: "Color"},
+		{Label: "lastName", Kind: graphql.SuggestionField, Type: "String"},
+		{Label: "name", Kind: graphql.SuggestionField, Type: "String!", Description: "The name of the pet."},
+		{Label: "__typename", Kind: graphql.SuggestionField, Type: "String!", Description: "The name of the current Object type at runtime."},
+		{Label: "nickname", Kind: graphql.SuggestionField, Type: "String", IsDeprecated: true, DeprecationReason: "Use name."},
+	}
+	if !reflect.DeepEqual(expected, suggestions) {
+		t.Fatalf("unexpected suggestions: %v", suggestionLabels(suggestions))
+	}
+}
+
+func TestGetAutocompleteSuggestions_RanksByPrefixThenSubstring(t *testing.T) {
+	tests := map[string][]string{
+		"{ pets { na| } }": {"name", "lastName", "__typename", "nickname"},
+		"{ pets { Na| } }": {"name", "lastName", "__typename", "nickname"},
+		"{ pets { nA| } }": {"name", "lastName", "__typename", "nickname"},
+		"{ pets { la| } }": {"lastName"},
+		"{ pets { xy| } }": {},
+		"{ pe|":            {"pets", "__typename", "__type"},
+	}
+	for query, expected := range tests {
+		if labels := suggestionLabels(suggestionsAt(t, query)); !reflect.DeepEqual(expected, labels) {
+			t.Fatalf("%v: expected %v, got %v", query, expected, labels)
+		}
+	}
+}
+
+func TestGetAutocompleteSuggestions_SuggestsArgumentsAndValues(t *testing.T) {
+	suggestions := suggestionsAt(t, "{ pets(first: 1, |) { name } }")
+	expected := []*graphql.Suggestion{
+		{Label: "color", Kind: graphql.SuggestionArgument, Type: "Color", Description: "Filters on the color."},
+	}
+	if !reflect.DeepEqual(expected, suggestions) {
+		t.Fatalf("unexpected suggestions: %v", suggestionLabels(suggestions))
+	}
+
+	suggestions = suggestionsAt(t, "{ pets(color: |) { name } }")
+	expected = []*graphql.Suggestion{
+		{Label: "GREEN", Kind: graphql.SuggestionEnumValue, Type: "Color"},
+		{Label: "RED", Kind: graphql.SuggestionEnumValue, Type: "Color", Description: "The red color."},
+		{Label: "BLUE", Kind: graphql.SuggestionEnumValue, Type: "Color", IsDeprecated: true, DeprecationReason: "Too cold."},
+	}
+	if !reflect.DeepEqual(expected, suggestions) {
+		t.Fatalf("unexpected suggestions: %v", suggestionLabels(suggestions))
+	}
+}
+
+func TestGetAutocompleteSuggestions_SuggestsVariablesAndTheirTypes(t *testing.T) {
+	suggestions := suggestionsAt(t, "query Q($color: Co|")
+	expected := []*graphql.Suggestion{
+		{Label: "Color", Kind: graphql.SuggestionType, Type: "enum Color"},
+	}
+	if !reflect.DeepEqual(expected, suggestions) {
+		t.Fatalf("unexpected suggestions: %v", suggestionLabels(suggestions))
+	}
+
+	suggestions = suggestionsAt(t, "query Q($color: Color, $first: [Int!]!) {\n  pets(color: $|")
+	expected = []*graphql.Suggestion{
+		{Label: "color", Kind: graphql.SuggestionVariable, Type: "Color"},
+		{Label: "first", Kind: graphql.SuggestionVariable, Type: "[Int!]!"},
+	}
+	if !reflect.DeepEqual(expected, suggestions) {
+		t.Fatalf("unexpected suggestions: %v", suggestionLabels(suggestions))
+	}
+}
+
+func TestGetAutocompleteSuggestions_SuggestsDirectivesOfTheLocation(t *testing.T) {
+	tests := map[string][]string{
+		"{ pets @|":                          {"include", "skip"},
+		"query Q @|":                         {},
+		"{ pets { ... on Pet @|":             {"include", "skip"},
+		"{ pets @include(|":                  {"if"},
+		"{ pets @include(if: |":              {"true", "false"},
+		"{ pets @include(if: true) { n| } }": {"name", "nickname", "lastName", "__typename"},
+	}
+	for query, expected := range tests {
+		if labels := suggestionLabels(suggestionsAt(t, query)); !reflect.DeepEqual(expected, labels) {
+			t.Fatalf("%v: expected %v, got %v", query, expected, labels)
+		}
+	}
+	suggestions := suggestionsAt(t, "{ pets @sk|")
+	if len(suggestions) != 1 || suggestions[0].Kind != graphql.SuggestionDirective || suggestions[0].Type != "@skip(if: Boolean!)" {
+		t.Fatalf("unexpected suggestions: %v", suggestions)
+	}
+}
+
+func TestGetAutocompleteSuggestions_CountsCharactersInRunes(t *testing.T) {
+	labels := suggestionLabels(suggestionsAt(t, "# é☃\n{ pets(first: 1) { # ☃\n  co| } }"))
+	if expected := []string{"color"}; !reflect.DeepEqual(expected, labels) {
+		t.Fatalf("expected %v, got %v", expected, labels)
+	}
+}
+
+func TestGetAutocompleteSuggestions_SuggestsNothingInStringsAndComments(t *testing.T) {
+	for _, query := range []string{
+		`{ pets(first: "a|") }`,
+		"{ pets # na|",
+		"{ pets(first: 1|",
+	} {
+		if labels := suggestionLabels(suggestionsAt(t, query)); len(labels) != 0 {
+			t.Fatalf("%v: expected no suggestions, got %v", query, labels)
+		}
+	}
+}
+
+func TestGetAutocompleteSuggestions_SuggestsNothingForMissingRootTypes(t *testing.T) {
+	for _, query := range []string{"mutation { b }", "subscription { b }"} {
+		for offset := 0; offset <= len(query); offset++ {
+			suggestions := suggestionsAt(t, query[:offset]+"|"+query[offset:])
+			inSelectionSet := offset > strings.Index(query, "{") && offset <= strings.Index(query, "}")
+			if inSelectionSet && len(suggestions) != 0 {
+				t.Fatalf("%v at %v: expected no suggestions, got %v", query, offset, suggestions)
+			}
+		}
+	}
+	// fragments and type conditions are still suggested
+	for _, query := range []string{"mutation { ...| } fragment F on Query { pets { name } }", "mutation { ... on |"} {
+		if suggestions := suggestionsAt(t, query); len(suggestions) == 0 {
+			t.Fatalf("%v: expected suggestions", query)
+		}
+	}
+}
@@ -0,0 +1,675 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// BuildASTSchema takes a document of type definitions (SDL) and builds an
+// executable Schema from it. Fields are resolved with DefaultResolveFn and
+// abstract types are resolved from the `__typename` key of map sources, so the
+// resulting schema is mostly useful for tooling, validation, mocking and
+// delegation; resolvers can be attached afterwards through Fields().
+//
+// If the document contains no `schema` definition, the types named `Query`,
+// `Mutation` and `Subscription` are used as root operation types.
+func BuildASTSchema(doc *ast.Document) (Schema, error) {
+	if doc == nil {
+		return Schema{}, gqlerrors.NewFormattedError("Must provide a document.")
+	}
+
+	builder := newASTTypeBuilder(nil)
+	var schemaDef *ast.SchemaDefinition
+	operationTypeDefs := []*ast.OperationTypeDefinition{}
+	directiveDefs := []*ast.DirectiveDefinition{}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaDefinition:
+			if schemaDef != nil {
+				return Schema{}, gqlerrors.NewError("Must provide only one schema definition.", []ast.Node{def}, "", nil, []int{}, nil)
+			}
+			schemaDef = def
+			operationTypeDefs = append(operationTypeDefs, def.OperationTypes...)
+		case *ast.SchemaExtensionDefinition:
+			if def.Definition != nil {
+				operationTypeDefs = append(operationTypeDefs, def.Definition.OperationTypes...)
+			}
+		case *ast.DirectiveDefinition:
+			directiveDefs = append(directiveDefs, def)
+		case *ast.ScalarExtensionDefinition, *ast.TypeExtensionDefinition, *ast.InterfaceExtensionDefinition,
+			*ast.UnionExtensionDefinition, *ast.EnumExtensionDefinition, *ast.InputObjectExtensionDefinition:
+			builder.addExtension(def)
+		case ast.TypeDefinition:
+			name := typeDefinitionName(def)
+			if _, ok := builder.defs[name]; ok {
+				return Schema{}, gqlerrors.NewError(fmt.Sprintf(`Type "%v" was defined more than once.`, name), []ast.Node{def}, "", nil, []int{}, nil)
+			}
+			builder.defs[name] = def
+		default:
+			return Schema{}, gqlerrors.NewError(
+				fmt.Sprintf("Cannot build a schema from a document containing a %v.", def.GetKind()),
+				[]ast.Node{def}, "", nil, []int{}, nil,
+			)
+		}
+	}
+
+	operationTypeNames := map[string]string{}
+	if len(operationTypeDefs) > 0 {
+		for _, opType := range operationTypeDefs {
+			if opType.Type != nil && opType.Type.Name != nil {
+				operationTypeNames[opType.Operation] = opType.Type.Name.Value
+			}
+		}
+	} else {
+		for operation, name := range map[string]string{
+			ast.OperationTypeQuery:        "Query",
+			ast.OperationTypeMutation:     "Mutation",
+			ast.OperationTypeSubscription: "Subscription",
+		} {
+			if _, ok := builder.defs[name]; ok {
+				operationTypeNames[operation] = name
+			}
+		}
+	}
+
+	config := SchemaConfig{}
+	for operation, name := range operationTypeNames {
+		ttype := builder.namedType(name)
+		if ttype == nil {
+			return Schema{}, gqlerrors.NewFormattedError(fmt.Sprintf(`Unknown root %v type "%v".`, operation, name))
+		}
+		object, ok := ttype.(*Object)
+		if !ok {
+			return Schema{}, gqlerrors.NewFormattedError(fmt.Sprintf(`Root %v type "%v" must be an Object type.`, operation, name))
+		}
+		switch operation {
+		case ast.OperationTypeQuery:
+			config.Query = object
+		case ast.OperationTypeMutation:
+			config.Mutation = object
+		case ast.OperationTypeSubscription:
+			config.Subscription = object
+		}
+	}
+	if config.Query == nil {
+		return Schema{}, gqlerrors.NewFormattedError("Must provide schema definition with query type or a type named Query.")
+	}
+
+	for _, name := range builder.sortedDefNames() {
+		config.Types = append(config.Types, builder.namedType(name))
+	}
+	if len(directiveDefs) > 0 {
+		config.Directives = append(config.Directives, SpecifiedDirectives...)
+		for _, def := range directiveDefs {
+			config.Directives = append(config.Directives, builder.buildDirective(def))
+		}
+	}
+	if builder.err != nil {
+		return Schema{}, builder.err
+	}
+
+	schema, err := NewSchema(config)
+	if err != nil {
+		return schema, err
+	}
+	// thunks are evaluated while the type map is built
+	if builder.err != nil {
+		return Schema{}, builder.err
+	}
+	return schema, nil
+}
+
+// astTypeBuilder lazily converts type definition nodes into Types. Types that
+// are not defined in the document are looked up through `existing`, which
+// allows extending a schema that was built in Go.
+type astTypeBuilder struct {
+	defs       map[string]ast.TypeDefinition
+	extensions map[string][]ast.TypeDefinition
+	types      map[string]Type
+	existing   func(name string) Type
+	err        error
+}
+
+func newASTTypeBuilder(existing func(name string) Type) *astTypeBuilder {
+	return &astTypeBuilder{
+		defs:       map[string]ast.TypeDefinition{},
+		extensions: map[string][]ast.TypeDefinition{},
+		types:      map[string]Type{},
+		existing:   existing,
+	}
+}
+
+// addExtension records the definition carried by a type extension. Its
+// directives, fields, values and members are added to the extended type when
+// the type is built.
+func (b *astTypeBuilder) addExtension(extension ast.Node) {
+	extended := extendedTypeDefinition(extension)
+	if name := typeDefinitionName(extended); name != "" {
+		b.extensions[name] = append(b.extensions[name], extended)
+	}
+}
+
+// extensionDirectives returns the directives added by the extensions of the
+// scalar named typeName.
+func (b *astTypeBuilder) extensionDirectives(typeName string) []*ast.Directive {
+	directives := []*ast.Directive{}
+	for _, ext := range b.extensions[typeName] {
+		if ext, ok := ext.(*ast.ScalarDefinition); ok {
+			directives = append(directives, ext.Directives...)
+		}
+	}
+	return directives
+}
+
+// extensionInterfaces returns the interfaces added by the extensions of the
+// object named typeName.
+func (b *astTypeBuilder) extensionInterfaces(typeName string) []*ast.Named {
+	interfaces := []*ast.Named{}
+	for _, ext := range b.extensions[typeName] {
+		if ext, ok := ext.(*ast.ObjectDefinition); ok {
+			interfaces = append(interfaces, ext.Interfaces...)
+		}
+	}
+	return interfaces
+}
+
+// extensionFields returns the fields added by the extensions of the object or
+// interface named typeName.
+func (b *astTypeBuilder) extensionFields(typeName string) []*ast.FieldDefinition {
+	fieldDefs := []*ast.FieldDefinition{}
+	for _, ext := range b.extensions[typeName] {
+		switch ext := ext.(type) {
+		case *ast.ObjectDefinition:
+			fieldDefs = append(fieldDefs, ext.Fields...)
+		case *ast.InterfaceDefinition:
+			fieldDefs = append(fieldDefs, ext.Fields...)
+		}
+	}
+	return fieldDefs
+}
+
+// extensionMembers returns the types added by the extensions of the union
+// named typeName.
+func (b *astTypeBuilder) extensionMembers(typeName string) []*ast.Named {
+	members := []*ast.Named{}
+	for _, ext := range b.extensions[typeName] {
+		if ext, ok := ext.(*ast.UnionDefinition); ok {
+			members = append(members, ext.Types...)
+		}
+	}
+	return members
+}
+
+// extensionValues returns the values added by the extensions of the enum named
+// typeName.
+func (b *astTypeBuilder) extensionValues(typeName string) []*ast.EnumValueDefinition {
+	values := []*ast.EnumValueDefinition{}
+	for _, ext := range b.extensions[typeName] {
+		if ext, ok := ext.(*ast.EnumDefinition); ok {
+			values = append(values, ext.Values...)
+		}
+	}
+	return values
+}
+
+// extensionInputFields returns the fields added by the extensions of the input
+// object named typeName.
+func (b *astTypeBuilder) extensionInputFields(typeName string) []*ast.InputValueDefinition {
+	fieldDefs := []*ast.InputValueDefinition{}
+	for _, ext := range b.extensions[typeName] {
+		if ext, ok := ext.(*ast.InputObjectDefinition); ok {
+			fieldDefs = append(fieldDefs, ext.Fields...)
+		}
+	}
+	return fieldDefs
+}
+
+func (b *astTypeBuilder) sortedDefNames() []string {
+	names := []string{}
+	for name := range b.defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (b *astTypeBuilder) reportError(message string, node ast.Node) {
+	if b.err != nil {
+		return
+	}
+	nodes := []ast.Node{}
+	if node != nil {
+		nodes = append(nodes, node)
+	}
+	b.err = gqlerrors.NewError(message, nodes, "", nil, []int{}, nil)
+}
+
+var specifiedScalarTypes = map[string]*Scalar{
+	"Int":     Int,
+	"Float":   Float,
+	"String":  String,
+	"Boolean": Boolean,
+	"ID":      ID,
+}
+
+func (b *astTypeBuilder) namedType(name string) Type {
+	if ttype, ok := b.types[name]; ok {
+		return ttype
+	}
+	def, ok := b.defs[name]
+	if !ok {
+		if b.existing != nil {
+			if ttype := b.existing(name); ttype != nil {
+				return ttype
+			}
+		}
+		if scalar, ok := specifiedScalarTypes[name]; ok {
+			return scalar
+		}
+		return nil
+	}
+
+	var ttype Type
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		ttype = b.buildScalar(def)
+	case *ast.ObjectDefinition:
+		ttype = b.buildObject(def)
+	case *ast.InterfaceDefinition:
+		ttype = b.buildInterface(def)
+	case *ast.UnionDefinition:
+		ttype = b.buildUnion(def)
+	case *ast.EnumDefinition:
+		ttype = b.buildEnum(def)
+	case *ast.InputObjectDefinition:
+		ttype = b.buildInputObject(def)
+	}
+	b.types[name] = ttype
+	return ttype
+}
+
+func (b *astTypeBuilder) typeRef(typeAST ast.Type) Type {
+	switch typeAST := typeAST.(type) {
+	case *ast.List:
+		inner := b.typeRef(typeAST.Type)
+		if inner == nil {
+			return nil
+		}
+		return NewList(inner)
+	case *ast.NonNull:
+		inner := b.typeRef(typeAST.Type)
+		if inner == nil {
+			return nil
+		}
+		return NewNonNull(inner)
+	case *ast.Named:
+		if typeAST.Name == nil {
+			return nil
+		}
+		ttype := b.namedType(typeAST.Name.Value)
+		if ttype == nil {
+			b.reportError(fmt.Sprintf(`Unknown type "%v".`, typeAST.Name.Value), typeAST)
+		}
+		return ttype
+	}
+	return nil
+}
+
+func (b *astTypeBuilder) outputTypeRef(typeAST ast.Type) Output {
+	ttype := b.typeRef(typeAST)
+	if ttype == nil {
+		return nil
+	}
+	if !IsOutputType(ttype) {
+		b.reportError(fmt.Sprintf(`Expected output type but got "%v".`, ttype), typeAST)
+		return nil
+	}
+	return ttype
+}
+
+func (b *astTypeBuilder) inputTypeRef(typeAST ast.Type) Input {
+	ttype := b.typeRef(typeAST)
+	if ttype == nil {
+		return nil
+	}
+	if !IsInputType(ttype) {
+		b.reportError(fmt.Sprintf(`Expected input type but got "%v".`, ttype), typeAST)
+		return nil
+	}
+	return ttype
+}
+
+func (b *astTypeBuilder) buildScalar(def *ast.ScalarDefinition) *Scalar {
+	directives := append(append([]*ast.Directive{}, def.Directives...), b.extensionDirectives(def.Name.Value)...)
+	return NewScalar(ScalarConfig{
+		Name:           def.Name.Value,
+		Description:    descriptionValue(def.Description),
+		Serialize:      identityCoercion,
+		ParseValue:     identityCoercion,
+		ParseLiteral:   parseLiteralUntyped,
+		SpecifiedByURL: specifiedByURL(directives),
+	})
+}
+
+func (b *astTypeBuilder) buildObject(def *ast.ObjectDefinition) *Object {
+	return NewObject(ObjectConfig{
+		Name:        def.Name.Value,
+		Description: descriptionValue(def.Description),
+		Interfaces: InterfacesThunk(func() []*Interface {
+			named := append(append([]*ast.Named{}, def.Interfaces...), b.extensionInterfaces(def.Name.Value)...)
+			return b.buildInterfaces(def.Name.Value, named)
+		}),
+		Fields: FieldsThunk(func() Fields {
+			fieldDefs := append(append([]*ast.FieldDefinition{}, def.Fields...), b.extensionFields(def.Name.Value)...)
+			return b.buildFields(fieldDefs)
+		}),
+	})
+}
+
+func (b *astTypeBuilder) buildInterface(def *ast.InterfaceDefinition) *Interface {
+	return NewInterface(InterfaceConfig{
+		Name:        def.Name.Value,
+		Description: descriptionValue(def.Description),
+		ResolveType: resolveTypeFromTypename,
+		Fields: FieldsThunk(func() Fields {
+			fieldDefs := append(append([]*ast.FieldDefinition{}, def.Fields...), b.extensionFields(def.Name.Value)...)
+			return b.buildFields(fieldDefs)
+		}),
+	})
+}
+
+func (b *astTypeBuilder) buildUnion(def *ast.UnionDefinition) *Union {
+	return NewUnion(UnionConfig{
+		Name:        def.Name.Value,
+		Description: descriptionValue(def.Description),
+		ResolveType: resolveTypeFromTypename,
+		Types: UnionTypesThunk(func() []*Object {
+			named := append(append([]*ast.Named{}, def.Types...), b.extensionMembers(def.Name.Value)...)
+			return b.buildMembers(def.Name.Value, named)
+		}),
+	})
+}
+
+func (b *astTypeBuilder) buildEnum(def *ast.EnumDefinition) *Enum {
+	values := EnumValueConfigMap{}
+	b.buildEnumValues(values, def.Values)
+	b.buildEnumValues(values, b.extensionValues(def.Name.Value))
+	return NewEnum(EnumConfig{
+		Name:        def.Name.Value,
+		Description: descriptionValue(def.Description),
+		Values:      values,
+	})
+}
+
+func (b *astTypeBuilder) buildInputObject(def *ast.InputObjectDefinition) *InputObject {
+	return NewInputObject(InputObjectConfig{
+		Name:        def.Name.Value,
+		Description: descriptionValue(def.Description),
+		Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+			fields := InputObjectConfigFieldMap{}
+			b.buildInputFields(fields, def.Fields)
+			b.buildInputFields(fields, b.extensionInputFields(def.Name.Value))
+			return fields
+		}),
+	})
+}
+
+func (b *astTypeBuilder) buildInterfaces(typeName string, named []*ast.Named) []*Interface {
+	interfaces := []*Interface{}
+	for _, namedAST := range named {
+		iface, ok := b.typeRef(namedAST).(*Interface)
+		if !ok {
+			b.reportError(fmt.Sprintf(`Type "%v" must only implement Interface types.`, typeName), namedAST)
+			continue
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
+
+func (b *astTypeBuilder) buildMembers(typeName string, named []*ast.Named) []*Object {
+	types := []*Object{}
+	for _, namedAST := range named {
+		object, ok := b.typeRef(namedAST).(*Object)
+		if !ok {
+			b.reportError(fmt.Sprintf(`Union "%v" may only contain Object types.`, typeName), namedAST)
+			continue
+		}
+		types = append(types, object)
+	}
+	return types
+}
+
+func (b *astTypeBuilder) buildEnumValues(values EnumValueConfigMap, valueDefs []*ast.EnumValueDefinition) {
+	for _, value := range valueDefs {
+		values[value.Name.Value] = &EnumValueConfig{
+			Value:             value.Name.Value,
+			Description:       descriptionValue(value.Description),
+			DeprecationReason: deprecationReason(value.Directives),
+		}
+	}
+}
+
+func (b *astTypeBuilder) buildInputFields(fields InputObjectConfigFieldMap, fieldDefs []*ast.InputValueDefinition) {
+	for _, fieldDef := range fieldDefs {
+		ttype := b.inputTypeRef(fieldDef.Type)
+		if ttype == nil {
+			continue
+		}
+		fields[fieldDef.Name.Value] = &InputObjectFieldConfig{
+			Type:         ttype,
+			Description:  descriptionValue(fieldDef.Description),
+			DefaultValue: valueFromAST(fieldDef.DefaultValue, ttype, nil),
+		}
+	}
+}
+
+func (b *astTypeBuilder) buildFields(fieldDefs []*ast.FieldDefinition) Fields {
+	fields := Fields{}
+	for _, fieldDef := range fieldDefs {
+		ttype := b.outputTypeRef(fieldDef.Type)
+		if ttype == nil {
+			continue
+		}
+		fields[fieldDef.Name.Value] = &Field{
+			Type:              ttype,
+			Description:       descriptionValue(fieldDef.Description),
+			DeprecationReason: deprecationReason(fieldDef.Directives),
+			Args:              b.buildArgs(fieldDef.Arguments),
+		}
+	}
+	return fields
+}
+
+func (b *astTypeBuilder) buildArgs(argDefs []*ast.InputValueDefinition) FieldConfigArgument {
+	args := FieldConfigArgument{}
+	for _, argDef := range argDefs {
+		ttype := b.inputTypeRef(argDef.Type)
+		if ttype == nil {
+			continue
+		}
+		args[argDef.Name.Value] = &ArgumentConfig{
+			Type:         ttype,
+			Description:  descriptionValue(argDef.Description),
+			DefaultValue: valueFromAST(argDef.DefaultValue, ttype, nil),
+		}
+	}
+	return args
+}
+
+func (b *astTypeBuilder) buildDirective(def *ast.DirectiveDefinition) *Directive {
+	locations := []string{}
+	for _, location := range def.Locations {
+		locations = append(locations, location.Value)
+	}
+	return NewDirective(DirectiveConfig{
+		Name:        def.Name.Value,
+		Description: descriptionValue(def.Description),
+		Locations:   locations,
+		Args:        b.buildArgs(def.Arguments),
+	})
+}
+
+func typeDefinitionName(def ast.TypeDefinition) string {
+	if name := typeDefinitionNameNode(def); name != nil {
+		return name.Value
+	}
+	return ""
+}
+
+func typeDefinitionNameNode(def ast.TypeDefinition) *ast.Name {
+	switch def := def.(type) {
+	case *ast.ScalarDefinition:
+		return def.Name
+	case *ast.ObjectDefinition:
+		return def.Name
+	case *ast.InterfaceDefinition:
+		return def.Name
+	case *ast.UnionDefinition:
+		return def.Name
+	case *ast.EnumDefinition:
+		return def.Name
+	case *ast.InputObjectDefinition:
+		return def.Name
+	}
+	return nil
+}
+
+// extendedTypeDefinition returns the definition carried by a type extension,
+// or nil if node does not extend a type.
+func extendedTypeDefinition(node ast.Node) ast.TypeDefinition {
+	switch node := node.(type) {
+	case *ast.ScalarExtensionDefinition:
+		if node.Definition != nil {
+			return node.Definition
+		}
+	case *ast.TypeExtensionDefinition:
+		if node.Definition != nil {
+			return node.Definition
+		}
+	case *ast.InterfaceExtensionDefinition:
+		if node.Definition != nil {
+			return node.Definition
+		}
+	case *ast.UnionExtensionDefinition:
+		if node.Definition != nil {
+			return node.Definition
+		}
+	case *ast.EnumExtensionDefinition:
+		if node.Definition != nil {
+			return node.Definition
+		}
+	case *ast.InputObjectExtensionDefinition:
+		if node.Definition != nil {
+			return node.Definition
+		}
+	}
+	return nil
+}
+
+func descriptionValue(description *ast.StringValue) string {
+	if description == nil {
+		return ""
+	}
+	return description.Value
+}
+
+// deprecationReason returns the reason given to a @deprecated directive, or
+// an empty string if the directive is not present.
+func deprecationReason(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != DeprecatedDirective.Name {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg.Name != nil && arg.Name.Value == "reason" {
+				if value, ok := arg.Value.(*ast.StringValue); ok {
+					return value.Value
+				}
+			}
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+func specifiedByURL(directives []*ast.Directive) string {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != SpecifiedByDirective.Name {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			if arg.Name != nil && arg.Name.Value == "url" {
+				if value, ok := arg.Value.(*ast.StringValue); ok {
+					return value.Value
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// resolveTypeFromTypename resolves the runtime type of an abstract value by
+// reading its `__typename` key, which is how results of remote or mocked
+// executions describe themselves.
+func resolveTypeFromTypename(p ResolveTypeParams) *Object {
+	var typename string
+	switch value := p.Value.(type) {
+	case map[string]interface{}:
+		typename, _ = value["__typename"].(string)
+	case interface{ Typename() string }:
+		typename = value.Typename()
+	}
+	if typename == "" {
+		return nil
+	}
+	object, _ := p.Info.Schema.Type(typename).(*Object)
+	return object
+}
+
+func identityCoercion(value interface{}) interface{} {
+	return value
+}
+
+func parseLiteralUntyped(valueAST ast.Value) interface{} {
+	return valueFromASTUntyped(valueAST, nil)
+}
+
+// valueFromASTUntyped produces a Go value from a value AST without the
+// knowledge of a type, the way a JSON decoder would.
+func valueFromASTUntyped(valueAST ast.Value, variables map[string]interface{}) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		obj := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			if field == nil || field.Name == nil {
+				continue
+			}
+			obj[field.Name.Value] = valueFromASTUntyped(field.Value, variables)
+		}
+		return obj
+	case *ast.ListValue:
+		values := []interface{}{}
+		for _, value := range valueAST.Values {
+			values = append(values, valueFromASTUntyped(value, variables))
+		}
+		return values
+	case *ast.Variable:
+		if valueAST.Name == nil || variables == nil {
+			return nil
+		}
+		return variables[valueAST.Name.Value]
+	case *ast.IntValue:
+		return Int.ParseLiteral(valueAST)
+	case *ast.FloatValue:
+		return Float.ParseLiteral(valueAST)
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.StringValue, *ast.EnumValue:
+		return valueAST.GetValue()
+	}
+	return nil
+}
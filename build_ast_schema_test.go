@@ -0,0 +1,243 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+const buildASTSchemaTestSDL = `
+schema {
+  query: Query
+  mutation: Mutation
+}
+
+"""A character in the Star Wars Trilogy"""
+interface Character {
+  id: ID!
+  name: String
+  friends: [Character]
+}
+
+type Human implements Character {
+  id: ID!
+  name: String
+  friends: [Character]
+  homePlanet: String
+}
+
+type Droid implements Character {
+  id: ID!
+  name: String
+  friends: [Character]
+  primaryFunction: String @deprecated(reason: "Use function")
+}
+
+union SearchResult = Human | Droid
+
+enum Episode {
+  NEWHOPE
+  EMPIRE
+  JEDI @deprecated
+}
+
+scalar Cursor
+
+input ReviewInput {
+  stars: Int!
+  commentary: String = "none"
+}
+
+directive @cost(weight: Int = 1) on FIELD_DEFINITION
+
+type Query {
+  hero(episode: Episode = JEDI): Character
+  search(text: String!, after: Cursor): [SearchResult!]!
+}
+
+type Mutation {
+  createReview(episode: Episode, review: ReviewInput!): Int
+}
+
+extend type Query {
+  version: String
+}
+`
+
+func TestBuildASTSchema_BuildsAllTypeKinds(t *testing.T) {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, buildASTSchemaTestSDL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.QueryType().Name() != "Query" || schema.MutationType().Name() != "Mutation" {
+		t.Fatalf("unexpected root types: %v, %v", schema.QueryType(), schema.MutationType())
+	}
+	for name, expected := range map[string]interface{}{
+		"Character":    &graphql.Interface{},
+		"Human":        &graphql.Object{},
+		"SearchResult": &graphql.Union{},
+		"Episode":      &graphql.Enum{},
+		"Cursor":       &graphql.Scalar{},
+		"ReviewInput":  &graphql.InputObject{},
+	} {
+		if reflect.TypeOf(schema.Type(name)) != reflect.TypeOf(expected) {
+			t.Fatalf("expected %v to be a %T, got %T", name, expected, schema.Type(name))
+		}
+	}
+	if !schema.IsPossibleType(schema.Type("Character").(*graphql.Interface), schema.Type("Droid").(*graphql.Object)) {
+		t.Fatalf("expected Droid to implement Character")
+	}
+	if schema.Type("Character").Description() != "A character in the Star Wars Trilogy" {
+		t.Fatalf("unexpected description: %q", schema.Type("Character").Description())
+	}
+
+	queryFields := schema.QueryType().Fields()
+	if _, ok := queryFields["version"]; !ok {
+		t.Fatalf("expected extended field version on Query")
+	}
+	heroArg := queryFields["hero"].Args[0]
+	if heroArg.DefaultValue != "JEDI" {
+		t.Fatalf("unexpected default value: %v", heroArg.DefaultValue)
+	}
+	droidFields := schema.Type("Droid").(*graphql.Object).Fields()
+	if droidFields["primaryFunction"].DeprecationReason != "Use function" {
+		t.Fatalf("unexpected deprecation reason: %q", droidFields["primaryFunction"].DeprecationReason)
+	}
+	if schema.Directive("cost") == nil || schema.Directive("skip") == nil {
+		t.Fatalf("expected custom and specified directives")
+	}
+}
+
+func TestBuildASTSchema_ExecutesWithDefaultResolvers(t *testing.T) {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, buildASTSchemaTestSDL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hero { name ... on Droid { primaryFunction } } search(text: "R2") { __typename } }`,
+		RootObject: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"__typename":      "Droid",
+				"name":            "R2-D2",
+				"primaryFunction": "Astromech",
+			},
+			"search": []interface{}{
+				map[string]interface{}{"__typename": "Human"},
+			},
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name":            "R2-D2",
+				"primaryFunction": "Astromech",
+			},
+			"search": []interface{}{
+				map[string]interface{}{"__typename": "Human"},
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestBuildASTSchema_UsesConventionalRootTypeNames(t *testing.T) {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, `
+		type Query { a: String }
+		type Subscription { b: String }
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.SubscriptionType() == nil || schema.SubscriptionType().Name() != "Subscription" {
+		t.Fatalf("expected Subscription root type")
+	}
+	if schema.MutationType() != nil {
+		t.Fatalf("expected no mutation type")
+	}
+}
+
+func TestBuildASTSchema_ReadsSpecifiedByURL(t *testing.T) {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, `
+		type Query { id: UUID }
+		scalar UUID @specifiedBy(url: "https://www.rfc-editor.org/rfc/rfc4122")
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scalar, ok := schema.Type("UUID").(*graphql.Scalar)
+	if !ok || scalar.SpecifiedByURL() != "https://www.rfc-editor.org/rfc/rfc4122" {
+		t.Fatalf("expected specifiedByURL to be read from @specifiedBy")
+	}
+
+	client, err := graphql.BuildClientSchema(introspect(t, schema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.Type("UUID").(*graphql.Scalar).SpecifiedByURL() != scalar.SpecifiedByURL() {
+		t.Fatalf("expected specifiedByURL to survive introspection")
+	}
+}
+
+func TestBuildASTSchema_RejectsInvalidDocuments(t *testing.T) {
+	tests := map[string]string{
+		"missing query":  `type Foo { a: String }`,
+		"unknown type":   `type Query { a: Bar }`,
+		"duplicate type": `type Query { a: String } type Query { b: String }`,
+		"operation":      `type Query { a: String } query { a }`,
+		"input as output": `
+			input In { a: String }
+			type Query { a: In }
+		`,
+	}
+	for name, sdl := range tests {
+		if _, err := graphql.BuildASTSchema(testutil.TestParse(t, sdl)); err == nil {
+			t.Fatalf("%v: expected an error", name)
+		}
+	}
+}
+
+func TestBuildASTSchema_AppliesAllTypeExtensions(t *testing.T) {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, `
+		type Query { a: String }
+		type Foo { a: String }
+		type Bar { a: String }
+		interface Node { id: ID }
+		union Result = Foo
+		enum Color { RED }
+		input Filter { a: String }
+		scalar UUID
+
+		extend interface Node { version: Int }
+		extend union Result = Bar
+		extend enum Color { BLUE }
+		extend input Filter { b: Int }
+		extend scalar UUID @specifiedBy(url: "https://www.rfc-editor.org/rfc/rfc4122")
+		extend schema { query: Query mutation: Bar }
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := schema.Type("Node").(*graphql.Interface).Fields()["version"]; !ok {
+		t.Fatalf("expected Node.version")
+	}
+	if len(schema.Type("Result").(*graphql.Union).Types()) != 2 {
+		t.Fatalf("expected Result to contain Foo and Bar")
+	}
+	if len(schema.Type("Color").(*graphql.Enum).Values()) != 2 {
+		t.Fatalf("expected Color to contain RED and BLUE")
+	}
+	if _, ok := schema.Type("Filter").(*graphql.InputObject).Fields()["b"]; !ok {
+		t.Fatalf("expected Filter.b")
+	}
+	if schema.Type("UUID").(*graphql.Scalar).SpecifiedByURL() == "" {
+		t.Fatalf("expected specifiedByURL to be read from the extension")
+	}
+	if schema.QueryType().Name() != "Query" || schema.MutationType().Name() != "Bar" {
+		t.Fatalf("unexpected root types: %v, %v", schema.QueryType(), schema.MutationType())
+	}
+}
@@ -0,0 +1,341 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// BuildClientSchema builds a Schema from the result of an introspection query,
+// such as testutil.IntrospectionQuery. Both the bare `{"__schema": ...}` object
+// and a full `{"data": {"__schema": ...}}` response are accepted.
+//
+// The resulting schema has no resolvers attached: fields use DefaultResolveFn
+// and abstract types are resolved from the `__typename` key of map sources, so
+// it is suited for validation, tooling or delegation to the remote server.
+func BuildClientSchema(introspection map[string]interface{}) (Schema, error) {
+	if data, ok := introspection["data"].(map[string]interface{}); ok {
+		introspection = data
+	}
+	schemaIntrospection, ok := introspection["__schema"].(map[string]interface{})
+	if !ok {
+		return Schema{}, gqlerrors.NewFormattedError("Invalid or incomplete introspection result: missing __schema.")
+	}
+
+	builder := &clientTypeBuilder{
+		defs:  map[string]map[string]interface{}{},
+		types: map[string]Type{},
+	}
+	typeIntrospections, _ := schemaIntrospection["types"].([]interface{})
+	for _, t := range typeIntrospections {
+		typeIntrospection, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := typeIntrospection["name"].(string)
+		builder.defs[name] = typeIntrospection
+	}
+
+	config := SchemaConfig{}
+	rootType := func(key string) (*Object, error) {
+		ref, ok := schemaIntrospection[key].(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		name, _ := ref["name"].(string)
+		object, ok := builder.namedType(name).(*Object)
+		if !ok {
+			return nil, gqlerrors.NewFormattedError(fmt.Sprintf(`Invalid or incomplete introspection result: %v "%v" is not an Object type.`, key, name))
+		}
+		return object, nil
+	}
+	var err error
+	if config.Query, err = rootType("queryType"); err != nil {
+		return Schema{}, err
+	}
+	if config.Query == nil {
+		return Schema{}, gqlerrors.NewFormattedError("Invalid or incomplete introspection result: missing queryType.")
+	}
+	if config.Mutation, err = rootType("mutationType"); err != nil {
+		return Schema{}, err
+	}
+	if config.Subscription, err = rootType("subscriptionType"); err != nil {
+		return Schema{}, err
+	}
+
+	names := []string{}
+	for name := range builder.defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ttype := builder.namedType(name); ttype != nil {
+			config.Types = append(config.Types, ttype)
+		}
+	}
+
+	if directiveIntrospections, ok := schemaIntrospection["directives"].([]interface{}); ok {
+		for _, d := range directiveIntrospections {
+			directiveIntrospection, ok := d.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			config.Directives = append(config.Directives, builder.buildDirective(directiveIntrospection))
+		}
+	}
+	if builder.err != nil {
+		return Schema{}, builder.err
+	}
+
+	schema, err := NewSchema(config)
+	if err != nil {
+		return schema, err
+	}
+	if builder.err != nil {
+		return Schema{}, builder.err
+	}
+	return schema, nil
+}
+
+// clientTypeBuilder lazily converts introspected types into Types.
+type clientTypeBuilder struct {
+	defs  map[string]map[string]interface{}
+	types map[string]Type
+	err   error
+}
+
+func (b *clientTypeBuilder) reportError(message string) {
+	if b.err == nil {
+		b.err = gqlerrors.NewFormattedError(message)
+	}
+}
+
+func (b *clientTypeBuilder) namedType(name string) Type {
+	if ttype, ok := b.types[name]; ok {
+		return ttype
+	}
+	// introspection types are provided by the schema itself
+	switch name {
+	case "__Schema", "__Type", "__TypeKind", "__Field", "__InputValue",
+		"__EnumValue", "__Directive", "__DirectiveLocation":
+		return nil
+	}
+	if scalar, ok := specifiedScalarTypes[name]; ok {
+		return scalar
+	}
+	def, ok := b.defs[name]
+	if !ok {
+		b.reportError(fmt.Sprintf(`Invalid or incomplete schema, unknown type: %v.`, name))
+		return nil
+	}
+
+	kind, _ := def["kind"].(string)
+	description, _ := def["description"].(string)
+	var ttype Type
+	switch kind {
+	case TypeKindScalar:
+		specifiedByURL, _ := def["specifiedByURL"].(string)
+		ttype = NewScalar(ScalarConfig{
+			Name:           name,
+			Description:    description,
+			Serialize:      identityCoercion,
+			ParseValue:     identityCoercion,
+			ParseLiteral:   parseLiteralUntyped,
+			SpecifiedByURL: specifiedByURL,
+		})
+	case TypeKindObject:
+		ttype = NewObject(ObjectConfig{
+			Name:        name,
+			Description: description,
+			Interfaces: InterfacesThunk(func() []*Interface {
+				interfaces := []*Interface{}
+				for _, ref := range listOfMaps(def["interfaces"]) {
+					iface, ok := b.typeRef(ref).(*Interface)
+					if !ok {
+						b.reportError(fmt.Sprintf(`Type "%v" must only implement Interface types.`, name))
+						continue
+					}
+					interfaces = append(interfaces, iface)
+				}
+				return interfaces
+			}),
+			Fields: FieldsThunk(func() Fields {
+				return b.buildFields(def)
+			}),
+		})
+	case TypeKindInterface:
+		ttype = NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: description,
+			ResolveType: resolveTypeFromTypename,
+			Fields: FieldsThunk(func() Fields {
+				return b.buildFields(def)
+			}),
+		})
+	case TypeKindUnion:
+		ttype = NewUnion(UnionConfig{
+			Name:        name,
+			Description: description,
+			ResolveType: resolveTypeFromTypename,
+			Types: UnionTypesThunk(func() []*Object {
+				types := []*Object{}
+				for _, ref := range listOfMaps(def["possibleTypes"]) {
+					object, ok := b.typeRef(ref).(*Object)
+					if !ok {
+						b.reportError(fmt.Sprintf(`Union "%v" may only contain Object types.`, name))
+						continue
+					}
+					types = append(types, object)
+				}
+				return types
+			}),
+		})
+	case TypeKindEnum:
+		values := EnumValueConfigMap{}
+		for _, value := range listOfMaps(def["enumValues"]) {
+			valueName, _ := value["name"].(string)
+			valueDescription, _ := value["description"].(string)
+			values[valueName] = &EnumValueConfig{
+				Value:             valueName,
+				Description:       valueDescription,
+				DeprecationReason: introspectedDeprecationReason(value),
+			}
+		}
+		ttype = NewEnum(EnumConfig{
+			Name:        name,
+			Description: description,
+			Values:      values,
+		})
+	case TypeKindInputObject:
+		ttype = NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: description,
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for fieldName, arg := range b.buildArgs(def["inputFields"]) {
+					fields[fieldName] = &InputObjectFieldConfig{
+						Type:         arg.Type,
+						Description:  arg.Description,
+						DefaultValue: arg.DefaultValue,
+					}
+				}
+				return fields
+			}),
+		})
+	default:
+		b.reportError(fmt.Sprintf(`Invalid or incomplete introspection result: unknown kind "%v" for type %v.`, kind, name))
+	}
+	b.types[name] = ttype
+	return ttype
+}
+
+func (b *clientTypeBuilder) typeRef(ref map[string]interface{}) Type {
+	kind, _ := ref["kind"].(string)
+	switch kind {
+	case TypeKindList, TypeKindNonNull:
+		ofType, ok := ref["ofType"].(map[string]interface{})
+		if !ok {
+			b.reportError("Decorated type deeper than introspection query.")
+			return nil
+		}
+		inner := b.typeRef(ofType)
+		if inner == nil {
+			return nil
+		}
+		if kind == TypeKindList {
+			return NewList(inner)
+		}
+		return NewNonNull(inner)
+	}
+	name, _ := ref["name"].(string)
+	return b.namedType(name)
+}
+
+func (b *clientTypeBuilder) buildFields(def map[string]interface{}) Fields {
+	fields := Fields{}
+	for _, field := range listOfMaps(def["fields"]) {
+		name, _ := field["name"].(string)
+		description, _ := field["description"].(string)
+		ref, _ := field["type"].(map[string]interface{})
+		ttype, ok := b.typeRef(ref).(Output)
+		if !ok {
+			continue
+		}
+		fields[name] = &Field{
+			Type:              ttype,
+			Description:       description,
+			DeprecationReason: introspectedDeprecationReason(field),
+			Args:              b.buildArgs(field["args"]),
+		}
+	}
+	return fields
+}
+
+func (b *clientTypeBuilder) buildArgs(args interface{}) FieldConfigArgument {
+	config := FieldConfigArgument{}
+	for _, arg := range listOfMaps(args) {
+		name, _ := arg["name"].(string)
+		description, _ := arg["description"].(string)
+		ref, _ := arg["type"].(map[string]interface{})
+		ttype, ok := b.typeRef(ref).(Input)
+		if !ok {
+			continue
+		}
+		argConfig := &ArgumentConfig{
+			Type:        ttype,
+			Description: description,
+		}
+		if defaultValue, ok := arg["defaultValue"].(string); ok {
+			valueAST, err := parser.ParseValue(parser.ParseParams{Source: defaultValue})
+			if err != nil {
+				b.reportError(fmt.Sprintf(`Invalid default value for argument "%v": %v`, name, err))
+				continue
+			}
+			argConfig.DefaultValue = valueFromAST(valueAST, ttype, nil)
+		}
+		config[name] = argConfig
+	}
+	return config
+}
+
+func (b *clientTypeBuilder) buildDirective(def map[string]interface{}) *Directive {
+	name, _ := def["name"].(string)
+	description, _ := def["description"].(string)
+	locations := []string{}
+	if locs, ok := def["locations"].([]interface{}); ok {
+		for _, loc := range locs {
+			if loc, ok := loc.(string); ok {
+				locations = append(locations, loc)
+			}
+		}
+	}
+	return NewDirective(DirectiveConfig{
+		Name:        name,
+		Description: description,
+		Locations:   locations,
+		Args:        b.buildArgs(def["args"]),
+	})
+}
+
+func introspectedDeprecationReason(def map[string]interface{}) string {
+	if deprecated, _ := def["isDeprecated"].(bool); !deprecated {
+		return ""
+	}
+	if reason, ok := def["deprecationReason"].(string); ok && reason != "" {
+		return reason
+	}
+	return DefaultDeprecationReason
+}
+
+func listOfMaps(value interface{}) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		if item, ok := item.(map[string]interface{}); ok {
+			result = append(result, item)
+		}
+	}
+	return result
+}
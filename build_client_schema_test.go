@@ -0,0 +1,96 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func introspect(t *testing.T, schema graphql.Schema) map[string]interface{} {
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: testutil.IntrospectionQuery,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("introspection failed: %v", result.Errors)
+	}
+	// round-trip through JSON, the way a client would receive it
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	sortByName(data)
+	return data
+}
+
+// sortByName orders named introspection entries, since fields, arguments and
+// enum values are not guaranteed to be returned in a stable order.
+func sortByName(value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for _, v := range value {
+			sortByName(v)
+		}
+	case []interface{}:
+		for _, v := range value {
+			sortByName(v)
+		}
+		sort.SliceStable(value, func(i, j int) bool {
+			a, _ := value[i].(map[string]interface{})
+			b, _ := value[j].(map[string]interface{})
+			nameA, _ := a["name"].(string)
+			nameB, _ := b["name"].(string)
+			return nameA < nameB
+		})
+	}
+}
+
+func TestBuildClientSchema_RoundTripsIntrospection(t *testing.T) {
+	original, err := graphql.BuildASTSchema(testutil.TestParse(t, buildASTSchemaTestSDL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	introspection := introspect(t, original)
+
+	client, err := graphql.BuildClientSchema(introspection)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := introspect(t, client); !reflect.DeepEqual(introspection, got) {
+		t.Fatalf("introspection differs after round-trip: %v", testutil.Diff(introspection, got))
+	}
+}
+
+func TestBuildClientSchema_RoundTripsStarWarsSchema(t *testing.T) {
+	introspection := introspect(t, testutil.StarWarsSchema)
+	client, err := graphql.BuildClientSchema(map[string]interface{}{"data": introspection})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := introspect(t, client); !reflect.DeepEqual(introspection, got) {
+		t.Fatalf("introspection differs after round-trip: %v", testutil.Diff(introspection, got))
+	}
+}
+
+func TestBuildClientSchema_RejectsIncompleteIntrospection(t *testing.T) {
+	if _, err := graphql.BuildClientSchema(map[string]interface{}{}); err == nil {
+		t.Fatalf("expected an error for missing __schema")
+	}
+	_, err := graphql.BuildClientSchema(map[string]interface{}{
+		"__schema": map[string]interface{}{
+			"queryType": map[string]interface{}{"name": "Query"},
+			"types":     []interface{}{},
+		},
+	})
+	if err == nil {
+		t.Fatalf("expected an error for unknown query type")
+	}
+}
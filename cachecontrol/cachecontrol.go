@@ -0,0 +1,440 @@
+// Package cachecontrol computes the HTTP cache policy of GraphQL responses
+// from cache hints on types and fields.
+//
+// Hints are declared with the @cacheControl directive in SDL, read by
+// ConfigFromSDL, or in Config for schemas built in Go, which carry no
+// directives. Resolvers may also set hints dynamically with SetHint. The
+// Extension combines the hints of the fields resolved by a query into a
+// Policy: the minimum maxAge and the most restrictive scope. The policy is
+// added to the "cacheControl" entry of Result.Extensions, from which
+// PolicyOf reads it back, e.g. to set the Cache-Control header of an HTTP
+// response with SetHeader.
+package cachecontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Name is the name of the extension and of its entry in Result.Extensions.
+const Name = "cacheControl"
+
+// Scope tells whether a response may be stored by shared caches.
+type Scope string
+
+const (
+	// Public responses may be stored by any cache.
+	Public Scope = "PUBLIC"
+	// Private responses are specific to a user, and may only be stored by
+	// their own caches.
+	Private Scope = "PRIVATE"
+)
+
+// Hint is the cache hint of a type or field. A nil MaxAge leaves the maxAge
+// unset, and an empty Scope the scope, so that a hint may restrict the scope
+// only.
+type Hint struct {
+	MaxAge *int
+	Scope  Scope
+}
+
+// Seconds returns a pointer to n, for setting Hint.MaxAge.
+func Seconds(n int) *int {
+	return &n
+}
+
+// ScopeEnum is the type of the scope argument of Directive. It must be added
+// to SchemaConfig.Types along with Directive, since the types of directive
+// arguments are not collected in the schema.
+var ScopeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "CacheControlScope",
+	Values: graphql.EnumValueConfigMap{
+		string(Public): &graphql.EnumValueConfig{
+			Value:       Public,
+			Description: "The response may be stored by shared caches.",
+		},
+		string(Private): &graphql.EnumValueConfig{
+			Value:       Private,
+			Description: "The response may only be stored by the caches of a single user.",
+		},
+	},
+})
+
+// Directive is the @cacheControl directive, to be added to
+// SchemaConfig.Directives along with graphql.SpecifiedDirectives.
+var Directive = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:        "cacheControl",
+	Description: "Sets the maximum age and the scope of the cached responses holding the type or field.",
+	Locations: []string{
+		graphql.DirectiveLocationObject,
+		graphql.DirectiveLocationFieldDefinition,
+		graphql.DirectiveLocationInterface,
+		graphql.DirectiveLocationUnion,
+	},
+	Args: graphql.FieldConfigArgument{
+		"maxAge": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "The maximum age of the responses, in seconds.",
+		},
+		"scope": &graphql.ArgumentConfig{
+			Type:        ScopeEnum,
+			Description: "Whether the responses may be stored by shared caches.",
+		},
+	},
+})
+
+// Config configures NewExtension.
+type Config struct {
+	// Types by type name. The hint of an object, interface or union type
+	// applies to the fields returning it.
+	Types map[string]Hint
+
+	// Fields by coordinate, e.g. "Product.price". The hints of the fields of
+	// interfaces apply to the fields of the objects implementing them, unless
+	// these have their own hints. Field hints override the values of type
+	// hints they set.
+	Fields map[string]Hint
+
+	// DefaultMaxAge is the maxAge of root fields and of fields returning
+	// objects, interfaces or unions which have none. Other fields have the
+	// maxAge of their parents by default. A zero DefaultMaxAge makes the
+	// responses uncacheable unless hints allow it.
+	DefaultMaxAge int
+}
+
+// ConfigFromSDL returns the hints of the @cacheControl directives of the
+// object, interface and union types of doc, their extensions and their
+// fields.
+func ConfigFromSDL(doc *ast.Document) (Config, error) {
+	config := Config{Types: map[string]Hint{}, Fields: map[string]Hint{}}
+	addType := func(name *ast.Name, directives []*ast.Directive, fields []*ast.FieldDefinition) error {
+		if name == nil {
+			return nil
+		}
+		if hint, ok, err := hintOf(directives); err != nil {
+			return fmt.Errorf("cachecontrol: type %q: %v", name.Value, err)
+		} else if ok {
+			config.Types[name.Value] = hint
+		}
+		for _, field := range fields {
+			if field.Name == nil {
+				continue
+			}
+			coordinate := name.Value + "." + field.Name.Value
+			if hint, ok, err := hintOf(field.Directives); err != nil {
+				return fmt.Errorf("cachecontrol: field %q: %v", coordinate, err)
+			} else if ok {
+				config.Fields[coordinate] = hint
+			}
+		}
+		return nil
+	}
+
+	for _, def := range doc.Definitions {
+		var err error
+		switch def := def.(type) {
+		case *ast.ObjectDefinition:
+			err = addType(def.Name, def.Directives, def.Fields)
+		case *ast.InterfaceDefinition:
+			err = addType(def.Name, def.Directives, def.Fields)
+		case *ast.UnionDefinition:
+			err = addType(def.Name, def.Directives, nil)
+		case *ast.TypeExtensionDefinition:
+			if def.Definition != nil {
+				err = addType(def.Definition.Name, def.Definition.Directives, def.Definition.Fields)
+			}
+		case *ast.InterfaceExtensionDefinition:
+			if def.Definition != nil {
+				err = addType(def.Definition.Name, def.Definition.Directives, def.Definition.Fields)
+			}
+		case *ast.UnionExtensionDefinition:
+			if def.Definition != nil {
+				err = addType(def.Definition.Name, def.Definition.Directives, nil)
+			}
+		}
+		if err != nil {
+			return Config{}, err
+		}
+	}
+	return config, nil
+}
+
+// hintOf returns the hint of the @cacheControl directive of directives, if
+// any.
+func hintOf(directives []*ast.Directive) (Hint, bool, error) {
+	for _, directive := range directives {
+		if directive.Name == nil || directive.Name.Value != Directive.Name {
+			continue
+		}
+		hint := Hint{}
+		for _, arg := range directive.Arguments {
+			if arg.Name == nil {
+				continue
+			}
+			switch arg.Name.Value {
+			case "maxAge":
+				value, ok := arg.Value.(*ast.IntValue)
+				if !ok {
+					return Hint{}, false, fmt.Errorf("maxAge must be an integer")
+				}
+				maxAge, err := strconv.Atoi(value.Value)
+				if err != nil {
+					return Hint{}, false, fmt.Errorf("invalid maxAge: %v", err)
+				}
+				hint.MaxAge = &maxAge
+			case "scope":
+				value, ok := arg.Value.(*ast.EnumValue)
+				if !ok || Scope(value.Value) != Public && Scope(value.Value) != Private {
+					return Hint{}, false, fmt.Errorf("scope must be PUBLIC or PRIVATE")
+				}
+				hint.Scope = Scope(value.Value)
+			}
+		}
+		return hint, true, nil
+	}
+	return Hint{}, false, nil
+}
+
+// Policy is the cache policy of a response.
+type Policy struct {
+	// MaxAge is the number of seconds the response may be cached, the
+	// response must not be cached if it is not positive.
+	MaxAge int   `json:"maxAge"`
+	Scope  Scope `json:"scope"`
+}
+
+// Cacheable tells whether the response may be cached.
+func (p Policy) Cacheable() bool {
+	return p.MaxAge > 0
+}
+
+// HeaderValue returns the value of the Cache-Control header of the response.
+func (p Policy) HeaderValue() string {
+	if !p.Cacheable() {
+		return "no-store"
+	}
+	scope := "public"
+	if p.Scope == Private {
+		scope = "private"
+	}
+	return "max-age=" + strconv.Itoa(p.MaxAge) + ", " + scope
+}
+
+// SetHeader sets the Cache-Control header of header to the policy of result,
+// or to "no-store" if result has none.
+func SetHeader(header http.Header, result *graphql.Result) {
+	policy, _ := PolicyOf(result)
+	header.Set("Cache-Control", policy.HeaderValue())
+}
+
+// PolicyOf returns the policy added to result by the Extension.
+func PolicyOf(result *graphql.Result) (Policy, bool) {
+	if result == nil {
+		return Policy{}, false
+	}
+	policy, ok := result.Extensions[Name].(Policy)
+	return policy, ok
+}
+
+// SetHint sets the hint of the field resolved by p, overriding the maxAge
+// of its static hint if hint has one, and restricting its scope. Hints set
+// once the resolver has returned, e.g. from the thunk it returned, can only
+// restrict the policy: they apply as they would to another field. It does
+// nothing if the schema has no Extension.
+func SetHint(p graphql.ResolveParams, hint Hint) {
+	state, ok := stateOf(p.Context)
+	if !ok || p.Info.Path == nil {
+		return
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	key := pathKey(p.Info.Path)
+	field, pending := state.fields[key]
+	if !pending {
+		state.restrict(hint)
+		return
+	}
+	if hint.MaxAge != nil {
+		field.MaxAge = Seconds(*hint.MaxAge)
+	}
+	if hint.Scope == Private {
+		field.Scope = Private
+	}
+	state.fields[key] = field
+}
+
+// Extension is a graphql.Extension computing the cache policy of query
+// results. Mutations, subscriptions and results with errors are uncacheable.
+type Extension struct {
+	config Config
+}
+
+var _ graphql.Extension = (*Extension)(nil)
+
+// NewExtension returns an Extension applying the hints of config, to be added
+// to a schema with Schema.AddExtensions.
+func NewExtension(config Config) *Extension {
+	return &Extension{config: config}
+}
+
+type stateKey struct{}
+
+// state is the cache policy of a request being executed.
+type state struct {
+	mu sync.Mutex
+	// fields holds the hints of the fields being resolved by path
+	fields map[string]Hint
+	policy Policy
+	// restricted tells whether a field has set the maxAge of the policy
+	restricted  bool
+	uncacheable bool
+}
+
+func stateOf(ctx context.Context) (*state, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	state, ok := ctx.Value(stateKey{}).(*state)
+	return state, ok
+}
+
+// restrict lowers the maxAge of the policy to the one of hint, and makes it
+// private if hint is. It must be called with s.mu held.
+func (s *state) restrict(hint Hint) {
+	if hint.MaxAge != nil && (!s.restricted || *hint.MaxAge < s.policy.MaxAge) {
+		s.policy.MaxAge = *hint.MaxAge
+		s.restricted = true
+	}
+	if hint.Scope == Private {
+		s.policy.Scope = Private
+	}
+}
+
+func pathKey(path *graphql.ResponsePath) string {
+	return fmt.Sprint(path.AsArray())
+}
+
+// Init implements graphql.Extension.
+func (e *Extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return ctx
+}
+
+// Name implements graphql.Extension.
+func (e *Extension) Name() string {
+	return Name
+}
+
+// ParseDidStart implements graphql.Extension.
+func (e *Extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(err error) {}
+}
+
+// ValidationDidStart implements graphql.Extension.
+func (e *Extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+// ExecutionDidStart implements graphql.Extension.
+func (e *Extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	state := &state{fields: map[string]Hint{}, policy: Policy{Scope: Public}}
+	return context.WithValue(ctx, stateKey{}, state), func(result *graphql.Result) {
+		if result != nil && len(result.Errors) != 0 {
+			state.mu.Lock()
+			state.uncacheable = true
+			state.mu.Unlock()
+		}
+	}
+}
+
+// ResolveFieldDidStart implements graphql.Extension.
+func (e *Extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	state, ok := stateOf(ctx)
+	if !ok || info == nil || info.Path == nil {
+		return ctx, func(interface{}, error) {}
+	}
+	if operation, ok := info.Operation.(*ast.OperationDefinition); ok && operation.Operation != ast.OperationTypeQuery {
+		state.mu.Lock()
+		state.uncacheable = true
+		state.mu.Unlock()
+	}
+
+	key := pathKey(info.Path)
+	state.mu.Lock()
+	state.fields[key] = e.staticHint(info)
+	state.mu.Unlock()
+	return ctx, func(interface{}, error) {
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		hint := state.fields[key]
+		delete(state.fields, key)
+		state.restrict(hint)
+	}
+}
+
+// staticHint returns the hint of the field resolved with info: the hint of
+// its return type overridden by its own, the maxAge defaulting to
+// Config.DefaultMaxAge for root fields and fields returning composite types.
+func (e *Extension) staticHint(info *graphql.ResolveInfo) Hint {
+	hint := Hint{}
+	// scalars and enums have the methods of graphql.Composite too
+	composite := false
+	switch named := graphql.GetNamed(info.ReturnType).(type) {
+	case *graphql.Object, *graphql.Interface, *graphql.Union:
+		composite = true
+		hint = e.config.Types[named.(graphql.Composite).Name()]
+	}
+	if info.ParentType != nil {
+		fieldHint, ok := e.config.Fields[info.ParentType.Name()+"."+info.FieldName]
+		if object, isObject := info.ParentType.(*graphql.Object); !ok && isObject {
+			for _, iface := range object.Interfaces() {
+				if fieldHint, ok = e.config.Fields[iface.Name()+"."+info.FieldName]; ok {
+					break
+				}
+			}
+		}
+		if fieldHint.MaxAge != nil {
+			hint.MaxAge = fieldHint.MaxAge
+		}
+		if fieldHint.Scope != "" {
+			hint.Scope = fieldHint.Scope
+		}
+	}
+	if hint.MaxAge == nil && (composite || info.Path.Prev == nil) {
+		hint.MaxAge = Seconds(e.config.DefaultMaxAge)
+	}
+	return hint
+}
+
+// HasResult implements graphql.Extension.
+func (e *Extension) HasResult() bool {
+	return true
+}
+
+// GetResult implements graphql.Extension, returning the Policy of the
+// request.
+func (e *Extension) GetResult(ctx context.Context) interface{} {
+	state, ok := stateOf(ctx)
+	if !ok {
+		return Policy{Scope: Public}
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	policy := state.policy
+	if !state.restricted {
+		policy.MaxAge = e.config.DefaultMaxAge
+	}
+	if state.uncacheable || policy.MaxAge < 0 {
+		policy.MaxAge = 0
+	}
+	return policy
+}
@@ -0,0 +1,252 @@
+package cachecontrol_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/cachecontrol"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+func testSchema(t *testing.T, config cachecontrol.Config) graphql.Schema {
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"title": &graphql.Field{Type: graphql.String},
+			"price": &graphql.Field{Type: graphql.Float},
+			"rating": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					cachecontrol.SetHint(p, cachecontrol.Hint{MaxAge: cachecontrol.Seconds(5)})
+					return 4, nil
+				},
+			},
+		},
+	})
+	books := []interface{}{
+		map[string]interface{}{"title": "Dune", "price": 9.5},
+		map[string]interface{}{"title": "Emma", "price": 7.0},
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"books": &graphql.Field{
+					Type: graphql.NewList(bookType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return books, nil
+					},
+				},
+				"me": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						cachecontrol.SetHint(p, cachecontrol.Hint{Scope: cachecontrol.Private})
+						return "reader", nil
+					},
+				},
+				"later": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return func() (interface{}, error) {
+							cachecontrol.SetHint(p, cachecontrol.Hint{MaxAge: cachecontrol.Seconds(5), Scope: cachecontrol.Private})
+							return "later", nil
+						}, nil
+					},
+				},
+				"fail": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("failed")
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"buy": &graphql.Field{
+					Type: graphql.Boolean,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return true, nil
+					},
+				},
+			},
+		}),
+		Directives: append(graphql.SpecifiedDirectives, cachecontrol.Directive),
+		Types:      []graphql.Type{cachecontrol.ScopeEnum},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema.AddExtensions(cachecontrol.NewExtension(config))
+	return schema
+}
+
+func policyOf(t *testing.T, schema graphql.Schema, query string) cachecontrol.Policy {
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	policy, ok := cachecontrol.PolicyOf(result)
+	if !ok {
+		t.Fatalf("%v: expected a policy in the extensions, got %v", query, result.Extensions)
+	}
+	return policy
+}
+
+func TestExtension_ComputesTheMinimumMaxAgeAndMostRestrictiveScope(t *testing.T) {
+	schema := testSchema(t, cachecontrol.Config{
+		Types: map[string]cachecontrol.Hint{
+			"Book": {MaxAge: cachecontrol.Seconds(60)},
+		},
+		Fields: map[string]cachecontrol.Hint{
+			"Query.books": {MaxAge: cachecontrol.Seconds(30)},
+			"Query.me":    {MaxAge: cachecontrol.Seconds(120)},
+			"Book.price":  {MaxAge: cachecontrol.Seconds(10), Scope: cachecontrol.Private},
+		},
+	})
+	tests := map[string]cachecontrol.Policy{
+		`{ books { title } }`:        {MaxAge: 30, Scope: cachecontrol.Public},
+		`{ books { title price } }`:  {MaxAge: 10, Scope: cachecontrol.Private},
+		`{ books { title rating } }`: {MaxAge: 5, Scope: cachecontrol.Public},
+		`{ me }`:                     {MaxAge: 120, Scope: cachecontrol.Private},
+		`{ books { title } me }`:     {MaxAge: 30, Scope: cachecontrol.Private},
+	}
+	for query, expected := range tests {
+		if policy := policyOf(t, schema, query); !reflect.DeepEqual(expected, policy) {
+			t.Fatalf("%v: expected %+v, got %+v", query, expected, policy)
+		}
+	}
+}
+
+func TestExtension_AppliesTheDefaultMaxAge(t *testing.T) {
+	schema := testSchema(t, cachecontrol.Config{})
+	if policy := policyOf(t, schema, `{ books { title } }`); policy.MaxAge != 0 || policy.Cacheable() {
+		t.Fatalf("expected an uncacheable policy, got %+v", policy)
+	}
+
+	schema = testSchema(t, cachecontrol.Config{
+		DefaultMaxAge: 20,
+		Fields: map[string]cachecontrol.Hint{
+			"Query.books": {MaxAge: cachecontrol.Seconds(300)},
+		},
+	})
+	// the scalar fields of the books have no maxAge, unlike the root fields
+	if policy := policyOf(t, schema, `{ books { title } }`); policy.MaxAge != 300 {
+		t.Fatalf("expected a maxAge of 300, got %+v", policy)
+	}
+	if policy := policyOf(t, schema, `{ books { title } me }`); policy.MaxAge != 20 {
+		t.Fatalf("expected a maxAge of 20, got %+v", policy)
+	}
+}
+
+func TestExtension_MakesMutationsAndErrorsUncacheable(t *testing.T) {
+	schema := testSchema(t, cachecontrol.Config{DefaultMaxAge: 60})
+	if policy := policyOf(t, schema, `{ me }`); policy.MaxAge != 60 {
+		t.Fatalf("expected a maxAge of 60, got %+v", policy)
+	}
+	for _, query := range []string{`mutation { buy }`, `{ me fail }`} {
+		if policy := policyOf(t, schema, query); policy.Cacheable() {
+			t.Fatalf("%v: expected an uncacheable policy, got %+v", query, policy)
+		}
+	}
+}
+
+func TestSetHint_RestrictsThePolicyFromThunks(t *testing.T) {
+	schema := testSchema(t, cachecontrol.Config{DefaultMaxAge: 60})
+	expected := cachecontrol.Policy{MaxAge: 5, Scope: cachecontrol.Private}
+	if policy := policyOf(t, schema, `{ later }`); !reflect.DeepEqual(expected, policy) {
+		t.Fatalf("expected %+v, got %+v", expected, policy)
+	}
+}
+
+func TestSetHint_IsIgnoredWithoutTheExtension(t *testing.T) {
+	schema := testSchema(t, cachecontrol.Config{})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: schema.QueryType()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ me books { rating } }`})
+	if len(result.Errors) != 0 || result.Extensions != nil {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
+
+func TestPolicy_HeaderValue(t *testing.T) {
+	tests := map[string]cachecontrol.Policy{
+		"no-store":            {MaxAge: 0, Scope: cachecontrol.Public},
+		"max-age=60, public":  {MaxAge: 60, Scope: cachecontrol.Public},
+		"max-age=30, private": {MaxAge: 30, Scope: cachecontrol.Private},
+		"max-age=5, public":   {MaxAge: 5},
+	}
+	for expected, policy := range tests {
+		if value := policy.HeaderValue(); value != expected {
+			t.Fatalf("%+v: expected %q, got %q", policy, expected, value)
+		}
+	}
+
+	schema := testSchema(t, cachecontrol.Config{DefaultMaxAge: 60})
+	header := http.Header{}
+	cachecontrol.SetHeader(header, graphql.Do(graphql.Params{Schema: schema, RequestString: `{ me }`}))
+	if value := header.Get("Cache-Control"); value != "max-age=60, private" {
+		t.Fatalf("unexpected Cache-Control header: %q", value)
+	}
+	cachecontrol.SetHeader(header, &graphql.Result{})
+	if value := header.Get("Cache-Control"); value != "no-store" {
+		t.Fatalf("unexpected Cache-Control header: %q", value)
+	}
+}
+
+func TestConfigFromSDL(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: `
+		type Query {
+			books: [Book] @cacheControl(maxAge: 30)
+			me: User @cacheControl(scope: PRIVATE)
+		}
+
+		type Book @cacheControl(maxAge: 60) {
+			title: String
+		}
+
+		interface User @cacheControl(maxAge: 10, scope: PRIVATE) {
+			name: String @cacheControl(maxAge: 0)
+		}
+
+		union Result @cacheControl(maxAge: 5) = Book
+
+		extend type Book {
+			price: Float @cacheControl(maxAge: 1)
+		}
+	`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config, err := cachecontrol.ConfigFromSDL(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := cachecontrol.Config{
+		Types: map[string]cachecontrol.Hint{
+			"Book":   {MaxAge: cachecontrol.Seconds(60)},
+			"User":   {MaxAge: cachecontrol.Seconds(10), Scope: cachecontrol.Private},
+			"Result": {MaxAge: cachecontrol.Seconds(5)},
+		},
+		Fields: map[string]cachecontrol.Hint{
+			"Query.books": {MaxAge: cachecontrol.Seconds(30)},
+			"Query.me":    {Scope: cachecontrol.Private},
+			"User.name":   {MaxAge: cachecontrol.Seconds(0)},
+			"Book.price":  {MaxAge: cachecontrol.Seconds(1)},
+		},
+	}
+	if !reflect.DeepEqual(expected, config) {
+		t.Fatalf("unexpected config: %+v", config)
+	}
+
+	doc, err = parser.Parse(parser.ParseParams{Source: `type Query { a: String @cacheControl(scope: SHARED) }`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := cachecontrol.ConfigFromSDL(doc); err == nil || err.Error() != `cachecontrol: field "Query.a": scope must be PUBLIC or PRIVATE` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
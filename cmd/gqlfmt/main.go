@@ -0,0 +1,132 @@
+// Command gqlfmt formats GraphQL documents, operations as well as schemas,
+// keeping their comments.
+//
+// Usage:
+//
+//	gqlfmt [-w | -d] [-indent 2] [-width 80] [-descriptions block] [path ...]
+//
+// Directories are walked for .graphql and .gql files and the standard input is
+// formatted when no path is given. The formatted documents are printed unless
+// -w rewrites the files in place or -d prints the differences between the
+// files and their formatted version, in which case gqlfmt exits with status 1
+// when a file is not formatted.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/graphql-go/graphql/internal/diff"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+var descriptionStyles = map[string]printer.DescriptionStyle{
+	"block":    printer.BlockDescriptions,
+	"string":   printer.StringDescriptions,
+	"preserve": printer.PreserveDescriptions,
+}
+
+func main() {
+	write := flag.Bool("w", false, "write the formatted documents to their files")
+	diff := flag.Bool("d", false, "print the differences with the formatted documents and fail if there are any")
+	indent := flag.Int("indent", 2, "number of spaces per indentation level")
+	width := flag.Int("width", 80, "line width past which arguments are wrapped, 0 for no limit")
+	descriptions := flag.String("descriptions", "block", "style of descriptions: block, string or preserve")
+	flag.Parse()
+
+	style, ok := descriptionStyles[*descriptions]
+	if !ok || *write && *diff {
+		fmt.Fprintln(os.Stderr, "usage: gqlfmt [-w | -d] [-indent n] [-width n] [-descriptions block|string|preserve] [path ...]")
+		os.Exit(2)
+	}
+	config := printer.Config{
+		Indent:       *indent,
+		MaxLineWidth: *width,
+		Descriptions: style,
+	}
+
+	if flag.NArg() == 0 {
+		body, err := ioutil.ReadAll(os.Stdin)
+		if err == nil {
+			body, err = format(config, "<stdin>", body)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Stdout.Write(body)
+		return
+	}
+
+	status := 0
+	for _, root := range flag.Args() {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			// files found in directories are only formatted when named like documents
+			if err != nil || info.IsDir() || path != root && !isGraphQLFile(path) {
+				return err
+			}
+			changed, err := formatFile(config, path, *write, *diff)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				status = 1
+			} else if changed && *diff {
+				status = 1
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			status = 1
+		}
+	}
+	os.Exit(status)
+}
+
+func isGraphQLFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".graphql" || ext == ".gql"
+}
+
+// formatFile formats the file at path and reports whether its content
+// differs from the formatted document.
+func formatFile(config printer.Config, path string, write, showDiff bool) (bool, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	formatted, err := format(config, path, body)
+	if err != nil {
+		return false, err
+	}
+	changed := !bytes.Equal(body, formatted)
+	switch {
+	case write:
+		if changed {
+			return true, ioutil.WriteFile(path, formatted, 0644)
+		}
+	case showDiff:
+		if changed {
+			fmt.Print(diff.Unified(path, path+" (formatted)", string(body), string(formatted)))
+		}
+	default:
+		os.Stdout.Write(formatted)
+	}
+	return changed, nil
+}
+
+func format(config printer.Config, name string, body []byte) ([]byte, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.NewSource(&source.Source{Body: body, Name: name}),
+		Options: parser.ParseOptions{Comments: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(config.Print(doc)), nil
+}
@@ -0,0 +1,112 @@
+// Command graphql-codegen generates a typed Go client from GraphQL operation
+// documents.
+//
+// Usage:
+//
+//	graphql-codegen -schema schema.graphql -package api -out api/client.go queries/*.graphql
+//
+// The schema is read as SDL unless its file name ends in .json, in which case
+// it is read as the result of an introspection query.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/codegen"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+type scalarFlags map[string]string
+
+func (s scalarFlags) String() string {
+	pairs := []string{}
+	for name, goType := range s {
+		pairs = append(pairs, name+"="+goType)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (s scalarFlags) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("expected Scalar=GoType, got %q", value)
+	}
+	s[parts[0]] = parts[1]
+	return nil
+}
+
+func main() {
+	scalars := scalarFlags{}
+	schemaPath := flag.String("schema", "", "schema file, SDL or introspection JSON (.json)")
+	out := flag.String("out", "", "output file, defaults to stdout")
+	packageName := flag.String("package", "client", "package name of the generated file")
+	flag.Var(scalars, "scalar", "Go type of a custom scalar as Scalar=GoType, may be repeated")
+	flag.Parse()
+
+	if *schemaPath == "" || flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: graphql-codegen -schema <file> [-out <file>] [-package <name>] <operations.graphql>...")
+		os.Exit(2)
+	}
+	if err := run(*schemaPath, *out, *packageName, scalars, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, out, packageName string, scalars map[string]string, operationPaths []string) error {
+	schema, err := loadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	sources := []*source.Source{}
+	for _, path := range operationPaths {
+		body, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sources = append(sources, source.NewSource(&source.Source{Body: body, Name: path}))
+	}
+	code, err := codegen.Generate(codegen.Config{
+		Schema:      &schema,
+		Sources:     sources,
+		PackageName: packageName,
+		Scalars:     scalars,
+	})
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return ioutil.WriteFile(out, code, 0644)
+}
+
+func loadSchema(path string) (graphql.Schema, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	if filepath.Ext(path) == ".json" {
+		var introspection map[string]interface{}
+		if err := json.Unmarshal(body, &introspection); err != nil {
+			return graphql.Schema{}, err
+		}
+		return graphql.BuildClientSchema(introspection)
+	}
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: body, Name: path}),
+	})
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	return graphql.BuildASTSchema(doc)
+}
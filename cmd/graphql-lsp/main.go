@@ -0,0 +1,64 @@
+// Command graphql-lsp is a language server for GraphQL documents, speaking
+// the Language Server Protocol over the standard input and output.
+//
+// Usage:
+//
+//	graphql-lsp -schema schema.graphql
+//
+// The schema is read as SDL unless its file name ends in .json, in which case
+// it is read as the result of an introspection query.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/graphql-go/graphql/lsp"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "", "schema file, SDL or introspection JSON (.json)")
+	flag.Parse()
+
+	if *schemaPath == "" {
+		fmt.Fprintln(os.Stderr, "usage: graphql-lsp -schema <file>")
+		os.Exit(2)
+	}
+	schema, err := loadSchema(*schemaPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := lsp.NewServer(&schema).Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func loadSchema(path string) (graphql.Schema, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	if filepath.Ext(path) == ".json" {
+		var introspection map[string]interface{}
+		if err := json.Unmarshal(body, &introspection); err != nil {
+			return graphql.Schema{}, err
+		}
+		return graphql.BuildClientSchema(introspection)
+	}
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: body, Name: path}),
+	})
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	return graphql.BuildASTSchema(doc)
+}
@@ -0,0 +1,79 @@
+package codegen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/internal/transport"
+)
+
+// HTTPClient sends operations to a GraphQL server as JSON POST requests. It
+// satisfies the Client interface of generated code.
+type HTTPClient struct {
+	URL        string
+	HTTPClient *http.Client
+
+	// Header is added to every request, e.g. for authorization.
+	Header http.Header
+}
+
+// NewHTTPClient returns a client for the server at url. If httpClient is nil,
+// http.DefaultClient is used.
+func NewHTTPClient(url string, httpClient *http.Client) *HTTPClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPClient{
+		URL:        url,
+		HTTPClient: httpClient,
+		Header:     http.Header{},
+	}
+}
+
+// Errors is returned when the response contains GraphQL errors. The data that
+// could be resolved is still decoded into the response.
+type Errors []gqlerrors.FormattedError
+
+func (errs Errors) Error() string {
+	messages := []string{}
+	for _, err := range errs {
+		messages = append(messages, err.Message)
+	}
+	return "graphql: " + strings.Join(messages, "; ")
+}
+
+// Do executes the operation and decodes the `data` of the response into
+// response.
+func (c *HTTPClient) Do(ctx context.Context, query, operationName string, variables, response interface{}) error {
+	request := map[string]interface{}{
+		"query":         query,
+		"operationName": operationName,
+		"variables":     variables,
+	}
+	var result struct {
+		Data   json.RawMessage            `json:"data"`
+		Errors []gqlerrors.FormattedError `json:"errors"`
+	}
+	if err := transport.Post(ctx, c.HTTPClient, c.URL, c.Header, request, &result); err != nil {
+		switch err := err.(type) {
+		case *transport.StatusError:
+			return fmt.Errorf("graphql: unexpected status %v", err.Status)
+		case *transport.DecodeError:
+			return err.Err
+		}
+		return err
+	}
+	if len(result.Data) > 0 && string(result.Data) != "null" && response != nil {
+		if err := json.Unmarshal(result.Data, response); err != nil {
+			return err
+		}
+	}
+	if len(result.Errors) > 0 {
+		return Errors(result.Errors)
+	}
+	return nil
+}
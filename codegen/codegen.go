@@ -0,0 +1,799 @@
+// Package codegen generates typed Go clients from GraphQL operation documents.
+//
+// Every named operation becomes a function returning a response struct that
+// mirrors its selection set. Variables become a struct, enums become string
+// types with constants and input objects become structs. Fragments are
+// flattened into the selecting struct; selections on interfaces and unions get
+// an `On<Type>` pointer per concrete type that is populated from `__typename`.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Config describes what to generate.
+type Config struct {
+	// Schema the operations are validated and typed against.
+	Schema *graphql.Schema
+
+	// Sources holds the operation documents. Fragments may be defined in any
+	// of them.
+	Sources []*source.Source
+
+	// PackageName of the generated file, defaults to "client".
+	PackageName string
+
+	// Scalars maps custom scalar names to Go types, e.g. "Time": "string".
+	// Unmapped custom scalars are generated as interface{}.
+	Scalars map[string]string
+}
+
+// Generate validates the operations against the schema and returns the
+// formatted Go source of the client.
+func Generate(config Config) ([]byte, error) {
+	if config.Schema == nil {
+		return nil, fmt.Errorf("codegen: must provide a schema")
+	}
+	doc := &ast.Document{Kind: kinds.Document}
+	for _, src := range config.Sources {
+		d, err := parser.Parse(parser.ParseParams{Source: src})
+		if err != nil {
+			return nil, err
+		}
+		doc.Definitions = append(doc.Definitions, d.Definitions...)
+	}
+	validation := graphql.ValidateDocument(config.Schema, doc, nil)
+	if !validation.IsValid {
+		messages := []string{}
+		for _, err := range validation.Errors {
+			message := err.Message
+			if len(err.Locations) > 0 {
+				message = fmt.Sprintf("%v (%v:%v)", message, err.Locations[0].Line, err.Locations[0].Column)
+			}
+			messages = append(messages, message)
+		}
+		return nil, fmt.Errorf("codegen: invalid operations: %v", strings.Join(messages, "; "))
+	}
+
+	g := &generator{
+		config:    config,
+		schema:    config.Schema,
+		fragments: map[string]*ast.FragmentDefinition{},
+		names:     map[string]bool{"Client": true},
+		enums:     map[string]string{},
+		inputs:    map[string]string{},
+		typenames: map[*ast.SelectionSet]bool{},
+	}
+	if g.config.PackageName == "" {
+		g.config.PackageName = "client"
+	}
+	operations := []*ast.OperationDefinition{}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			g.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if def.Name == nil || def.Name.Value == "" {
+				return nil, fmt.Errorf("codegen: operations must be named")
+			}
+			operations = append(operations, def)
+		}
+	}
+	for _, operation := range operations {
+		if err := g.operation(operation); err != nil {
+			return nil, err
+		}
+	}
+	return g.output()
+}
+
+type generator struct {
+	config    Config
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	names     map[string]bool
+
+	// generated enum and input object type names by schema type name
+	enums  map[string]string
+	inputs map[string]string
+
+	// typenames holds the selection sets of abstract types lacking the
+	// __typename field, which document adds to copies of them
+	typenames map[*ast.SelectionSet]bool
+
+	operations bytes.Buffer
+	decls      []string
+	usesJSON   bool
+}
+
+func (g *generator) output() ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by graphql-codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %v\n\n", g.config.PackageName)
+	if g.usesJSON {
+		fmt.Fprintf(&buf, "import (\n\t\"context\"\n\t\"encoding/json\"\n)\n\n")
+	} else {
+		fmt.Fprintf(&buf, "import \"context\"\n\n")
+	}
+	fmt.Fprintf(&buf, "// Client executes a GraphQL operation and decodes the `data` of the\n")
+	fmt.Fprintf(&buf, "// response into response.\n")
+	fmt.Fprintf(&buf, "type Client interface {\n")
+	fmt.Fprintf(&buf, "\tDo(ctx context.Context, query, operationName string, variables, response interface{}) error\n")
+	fmt.Fprintf(&buf, "}\n\n")
+	buf.Write(g.operations.Bytes())
+	for _, decl := range g.decls {
+		buf.WriteString(decl)
+		buf.WriteString("\n")
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return buf.Bytes(), fmt.Errorf("codegen: formatting generated code: %v", err)
+	}
+	return formatted, nil
+}
+
+// typeName reserves a unique Go type name.
+func (g *generator) typeName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%v%v", name, i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+func (g *generator) operation(operation *ast.OperationDefinition) error {
+	name := g.typeName(exportedName(operation.Name.Value))
+	var root *graphql.Object
+	switch operation.Operation {
+	case ast.OperationTypeMutation:
+		root = g.schema.MutationType()
+	case ast.OperationTypeSubscription:
+		root = g.schema.SubscriptionType()
+	default:
+		root = g.schema.QueryType()
+	}
+	if root == nil {
+		return fmt.Errorf("codegen: schema does not support %v operations", operation.Operation)
+	}
+
+	responseType, err := g.selectionStruct(g.typeName(name+"Response"), name, root, []*ast.SelectionSet{operation.SelectionSet})
+	if err != nil {
+		return err
+	}
+
+	variablesType := ""
+	if len(operation.VariableDefinitions) > 0 {
+		variablesType = g.typeName(name + "Variables")
+		var decl bytes.Buffer
+		fmt.Fprintf(&decl, "// %v holds the variables of the %v %v.\n", variablesType, operation.Operation, operation.Name.Value)
+		fmt.Fprintf(&decl, "type %v struct {\n", variablesType)
+		for _, def := range operation.VariableDefinitions {
+			ttype, err := g.inputTypeFromAST(def.Type)
+			if err != nil {
+				return err
+			}
+			goType, err := g.inputGoType(ttype)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&decl, "\t%v %v `json:\"%v%v\"`\n", exportedName(def.Variable.Name.Value), goType, def.Variable.Name.Value, omitEmpty(ttype))
+		}
+		fmt.Fprintf(&decl, "}\n")
+		g.decls = append(g.decls, decl.String())
+	}
+
+	documentName := g.typeName(name + "Document")
+	fmt.Fprintf(&g.operations, "// %v is the document sent for the %v %v.\n", documentName, operation.Operation, operation.Name.Value)
+	fmt.Fprintf(&g.operations, "const %v = %v\n\n", documentName, goStringLiteral(g.document(operation)))
+
+	fmt.Fprintf(&g.operations, "// %v executes the %v %v.\n", name, operation.Operation, operation.Name.Value)
+	if variablesType != "" {
+		fmt.Fprintf(&g.operations, "func %v(ctx context.Context, client Client, variables *%v) (*%v, error) {\n", name, variablesType, responseType)
+	} else {
+		fmt.Fprintf(&g.operations, "func %v(ctx context.Context, client Client) (*%v, error) {\n", name, responseType)
+	}
+	fmt.Fprintf(&g.operations, "\tvar response %v\n", responseType)
+	if variablesType != "" {
+		fmt.Fprintf(&g.operations, "\tif err := client.Do(ctx, %v, %q, variables, &response); err != nil {\n", documentName, operation.Name.Value)
+	} else {
+		fmt.Fprintf(&g.operations, "\tif err := client.Do(ctx, %v, %q, nil, &response); err != nil {\n", documentName, operation.Name.Value)
+	}
+	fmt.Fprintf(&g.operations, "\t\treturn nil, err\n\t}\n\treturn &response, nil\n}\n\n")
+	return nil
+}
+
+// document prints the operation together with the fragments it uses.
+func (g *generator) document(operation *ast.OperationDefinition) string {
+	used := map[string]bool{}
+	order := []string{}
+	var visit func(set *ast.SelectionSet)
+	visit = func(set *ast.SelectionSet) {
+		if set == nil {
+			return
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				visit(selection.SelectionSet)
+			case *ast.InlineFragment:
+				visit(selection.SelectionSet)
+			case *ast.FragmentSpread:
+				name := selection.Name.Value
+				if used[name] {
+					continue
+				}
+				used[name] = true
+				order = append(order, name)
+				if fragment, ok := g.fragments[name]; ok {
+					visit(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	visit(operation.SelectionSet)
+	sort.Strings(order)
+
+	copied := *operation
+	copied.SelectionSet = g.withTypenames(operation.SelectionSet)
+	doc := ast.NewDocument(&ast.Document{Definitions: []ast.Node{&copied}})
+	for _, name := range order {
+		fragment := *g.fragments[name]
+		fragment.SelectionSet = g.withTypenames(fragment.SelectionSet)
+		doc.Definitions = append(doc.Definitions, &fragment)
+	}
+	printed, _ := printer.Print(doc).(string)
+	return strings.TrimSpace(printed)
+}
+
+// withTypenames returns a copy of set with the __typename field added to the
+// selection sets recorded in typenames.
+func (g *generator) withTypenames(set *ast.SelectionSet) *ast.SelectionSet {
+	if set == nil {
+		return nil
+	}
+	selections := []ast.Selection{}
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			field := *selection
+			field.SelectionSet = g.withTypenames(selection.SelectionSet)
+			selections = append(selections, &field)
+		case *ast.InlineFragment:
+			fragment := *selection
+			fragment.SelectionSet = g.withTypenames(selection.SelectionSet)
+			selections = append(selections, &fragment)
+		default:
+			selections = append(selections, selection)
+		}
+	}
+	if g.typenames[set] {
+		selections = append(selections, typenameField())
+	}
+	copied := *set
+	copied.Selections = selections
+	return &copied
+}
+
+func typenameField() *ast.Field {
+	return ast.NewField(&ast.Field{Name: ast.NewName(&ast.Name{Value: "__typename"})})
+}
+
+// selectedField is a response key together with all the field nodes that
+// contribute to it.
+type selectedField struct {
+	responseName string
+	def          *graphql.FieldDefinition
+	asts         []*ast.Field
+}
+
+func (f *selectedField) selectionSets() []*ast.SelectionSet {
+	sets := []*ast.SelectionSet{}
+	for _, field := range f.asts {
+		if field.SelectionSet != nil {
+			sets = append(sets, field.SelectionSet)
+		}
+	}
+	return sets
+}
+
+// collectFields flattens the fields selected on parentType. Fragments are
+// included when their type condition is parentType itself or, if runtime is
+// set, when runtime satisfies the condition.
+func (g *generator) collectFields(parentType graphql.Composite, runtime *graphql.Object, sets []*ast.SelectionSet) ([]*selectedField, error) {
+	fields := []*selectedField{}
+	byName := map[string]*selectedField{}
+	visited := map[string]bool{}
+	var collect func(set *ast.SelectionSet) error
+	collect = func(set *ast.SelectionSet) error {
+		if set == nil {
+			return nil
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				responseName := selection.Name.Value
+				if selection.Alias != nil {
+					responseName = selection.Alias.Value
+				}
+				if field, ok := byName[responseName]; ok {
+					field.asts = append(field.asts, selection)
+					continue
+				}
+				var def *graphql.FieldDefinition
+				if runtime != nil {
+					def = fieldDef(g.schema, runtime, selection.Name.Value)
+				} else {
+					def = fieldDef(g.schema, parentType, selection.Name.Value)
+				}
+				if def == nil {
+					return fmt.Errorf(`codegen: unknown field "%v" on type "%v"`, selection.Name.Value, parentType.Name())
+				}
+				field := &selectedField{responseName: responseName, def: def, asts: []*ast.Field{selection}}
+				byName[responseName] = field
+				fields = append(fields, field)
+			case *ast.InlineFragment:
+				if g.fragmentApplies(parentType, runtime, selection.TypeCondition) {
+					if err := collect(selection.SelectionSet); err != nil {
+						return err
+					}
+				}
+			case *ast.FragmentSpread:
+				name := selection.Name.Value
+				if visited[name] {
+					continue
+				}
+				fragment, ok := g.fragments[name]
+				if !ok {
+					return fmt.Errorf(`codegen: unknown fragment "%v"`, name)
+				}
+				if g.fragmentApplies(parentType, runtime, fragment.TypeCondition) {
+					visited[name] = true
+					if err := collect(fragment.SelectionSet); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+	for _, set := range sets {
+		if err := collect(set); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+func (g *generator) fragmentApplies(parentType graphql.Composite, runtime *graphql.Object, condition *ast.Named) bool {
+	if condition == nil || condition.Name.Value == parentType.Name() {
+		return true
+	}
+	if runtime == nil {
+		return false
+	}
+	return typeConditionMatches(g.schema, condition.Name.Value, runtime)
+}
+
+// typeSpecificTypes returns the possible types of an abstract parent that are
+// targeted by at least one fragment with a narrower type condition.
+func (g *generator) typeSpecificTypes(parentType graphql.Abstract, sets []*ast.SelectionSet) []*graphql.Object {
+	conditions := map[string]bool{}
+	visited := map[string]bool{}
+	var collect func(set *ast.SelectionSet)
+	collect = func(set *ast.SelectionSet) {
+		if set == nil {
+			return
+		}
+		for _, selection := range set.Selections {
+			switch selection := selection.(type) {
+			case *ast.InlineFragment:
+				if selection.TypeCondition != nil {
+					conditions[selection.TypeCondition.Name.Value] = true
+				}
+				collect(selection.SelectionSet)
+			case *ast.FragmentSpread:
+				fragment, ok := g.fragments[selection.Name.Value]
+				if !ok || visited[fragment.Name.Value] {
+					continue
+				}
+				visited[fragment.Name.Value] = true
+				conditions[fragment.TypeCondition.Name.Value] = true
+				collect(fragment.SelectionSet)
+			}
+		}
+	}
+	for _, set := range sets {
+		collect(set)
+	}
+	delete(conditions, parentType.Name())
+
+	types := []*graphql.Object{}
+	for _, possible := range g.schema.PossibleTypes(parentType) {
+		for condition := range conditions {
+			if typeConditionMatches(g.schema, condition, possible) {
+				types = append(types, possible)
+				break
+			}
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name() < types[j].Name() })
+	return types
+}
+
+// selectionStruct generates the struct for a selection on parentType and
+// returns its Go type name. prefix is used to name nested types.
+func (g *generator) selectionStruct(name, prefix string, parentType graphql.Composite, sets []*ast.SelectionSet) (string, error) {
+	var abstractType graphql.Abstract
+	var runtime *graphql.Object
+	switch parentType := parentType.(type) {
+	case *graphql.Object:
+		runtime = parentType
+	case *graphql.Interface, *graphql.Union:
+		abstractType = parentType.(graphql.Abstract)
+	}
+	isAbstract := abstractType != nil
+	fields, err := g.collectFields(parentType, runtime, sets)
+	if err != nil {
+		return "", err
+	}
+
+	var specific []*graphql.Object
+	if isAbstract {
+		specific = g.typeSpecificTypes(abstractType, sets)
+		hasTypename := false
+		for _, field := range fields {
+			if field.responseName == "__typename" {
+				hasTypename = true
+			}
+		}
+		if !hasTypename {
+			// the concrete type is needed to decode the response, so request it
+			typename := typenameField()
+			g.typenames[sets[0]] = true
+			fields = append(fields, &selectedField{
+				responseName: "__typename",
+				def:          fieldDef(g.schema, parentType, "__typename"),
+				asts:         []*ast.Field{typename},
+			})
+		}
+	}
+
+	var decl bytes.Buffer
+	fmt.Fprintf(&decl, "type %v struct {\n", name)
+	if err := g.structFields(&decl, prefix, fields); err != nil {
+		return "", err
+	}
+	specificNames := map[*graphql.Object]string{}
+	for _, object := range specific {
+		typeName := g.typeName(name + "On" + exportedName(object.Name()))
+		specificNames[object] = typeName
+		fmt.Fprintf(&decl, "\tOn%v *%v `json:\"-\"`\n", exportedName(object.Name()), typeName)
+	}
+	fmt.Fprintf(&decl, "}\n")
+
+	if len(specific) > 0 {
+		g.usesJSON = true
+		fmt.Fprintf(&decl, "\n// UnmarshalJSON decodes the fields selected on every type and the\n")
+		fmt.Fprintf(&decl, "// fields of the concrete type named by `__typename`.\n")
+		fmt.Fprintf(&decl, "func (v *%v) UnmarshalJSON(data []byte) error {\n", name)
+		fmt.Fprintf(&decl, "\ttype plain %v\n", name)
+		fmt.Fprintf(&decl, "\tif err := json.Unmarshal(data, (*plain)(v)); err != nil {\n\t\treturn err\n\t}\n")
+		fmt.Fprintf(&decl, "\tswitch v.%v {\n", g.typenameField(fields))
+		for _, object := range specific {
+			fmt.Fprintf(&decl, "\tcase %q:\n", object.Name())
+			fmt.Fprintf(&decl, "\t\tv.On%v = new(%v)\n", exportedName(object.Name()), specificNames[object])
+			fmt.Fprintf(&decl, "\t\treturn json.Unmarshal(data, v.On%v)\n", exportedName(object.Name()))
+		}
+		fmt.Fprintf(&decl, "\t}\n\treturn nil\n}\n")
+	}
+	g.decls = append(g.decls, decl.String())
+
+	for _, object := range specific {
+		objectFields, err := g.collectFields(parentType, object, sets)
+		if err != nil {
+			return "", err
+		}
+		var decl bytes.Buffer
+		fmt.Fprintf(&decl, "type %v struct {\n", specificNames[object])
+		if err := g.structFields(&decl, specificNames[object], objectFields); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&decl, "}\n")
+		g.decls = append(g.decls, decl.String())
+	}
+	return name, nil
+}
+
+func (g *generator) typenameField(fields []*selectedField) string {
+	for _, field := range fields {
+		if len(field.asts) > 0 && field.asts[0].Name.Value == "__typename" {
+			return exportedName(field.responseName)
+		}
+	}
+	return "Typename"
+}
+
+func (g *generator) structFields(decl *bytes.Buffer, prefix string, fields []*selectedField) error {
+	for _, field := range fields {
+		goName := exportedName(field.responseName)
+		goType, err := g.outputGoType(prefix+goName, field, field.def.Type)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(decl, "\t%v %v `json:\"%v\"`\n", goName, goType, field.responseName)
+	}
+	return nil
+}
+
+func (g *generator) outputGoType(name string, field *selectedField, ttype graphql.Type) (string, error) {
+	nullable := true
+	if nonNull, ok := ttype.(*graphql.NonNull); ok {
+		nullable = false
+		ttype = nonNull.OfType
+	}
+	switch ttype := ttype.(type) {
+	case *graphql.List:
+		elem, err := g.outputGoType(name, field, ttype.OfType)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case *graphql.Scalar, *graphql.Enum:
+		return g.leafGoType(ttype, nullable), nil
+	case graphql.Composite:
+		typeName, err := g.selectionStruct(g.typeName(name), name, ttype, field.selectionSets())
+		if err != nil {
+			return "", err
+		}
+		if nullable {
+			return "*" + typeName, nil
+		}
+		return typeName, nil
+	}
+	return "", fmt.Errorf("codegen: unsupported output type %v", ttype)
+}
+
+func (g *generator) inputGoType(ttype graphql.Type) (string, error) {
+	nullable := true
+	if nonNull, ok := ttype.(*graphql.NonNull); ok {
+		nullable = false
+		ttype = nonNull.OfType
+	}
+	switch ttype := ttype.(type) {
+	case *graphql.List:
+		elem, err := g.inputGoType(ttype.OfType)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case *graphql.Scalar, *graphql.Enum:
+		return g.leafGoType(ttype, nullable), nil
+	case *graphql.InputObject:
+		typeName, err := g.inputObject(ttype)
+		if err != nil {
+			return "", err
+		}
+		if nullable {
+			return "*" + typeName, nil
+		}
+		return typeName, nil
+	}
+	return "", fmt.Errorf("codegen: unsupported input type %v", ttype)
+}
+
+func (g *generator) leafGoType(ttype graphql.Type, nullable bool) string {
+	goType := "interface{}"
+	switch ttype := ttype.(type) {
+	case *graphql.Enum:
+		goType = g.enum(ttype)
+	case *graphql.Scalar:
+		switch ttype.Name() {
+		case "Int":
+			goType = "int"
+		case "Float":
+			goType = "float64"
+		case "String", "ID":
+			goType = "string"
+		case "Boolean":
+			goType = "bool"
+		default:
+			if mapped, ok := g.config.Scalars[ttype.Name()]; ok {
+				goType = mapped
+			}
+		}
+	}
+	if nullable && goType != "interface{}" && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") {
+		return "*" + goType
+	}
+	return goType
+}
+
+func (g *generator) enum(ttype *graphql.Enum) string {
+	if name, ok := g.enums[ttype.Name()]; ok {
+		return name
+	}
+	name := g.typeName(exportedName(ttype.Name()))
+	g.enums[ttype.Name()] = name
+
+	var decl bytes.Buffer
+	writeDescription(&decl, name, ttype.Description())
+	fmt.Fprintf(&decl, "type %v string\n\n", name)
+	fmt.Fprintf(&decl, "const (\n")
+	values := ttype.Values()
+	sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+	for _, value := range values {
+		if value.DeprecationReason != "" {
+			fmt.Fprintf(&decl, "\t// Deprecated: %v\n", value.DeprecationReason)
+		}
+		fmt.Fprintf(&decl, "\t%v%v %v = %q\n", name, exportedName(strings.ToLower(value.Name)), name, value.Name)
+	}
+	fmt.Fprintf(&decl, ")\n")
+	g.decls = append(g.decls, decl.String())
+	return name
+}
+
+func (g *generator) inputObject(ttype *graphql.InputObject) (string, error) {
+	if name, ok := g.inputs[ttype.Name()]; ok {
+		return name, nil
+	}
+	name := g.typeName(exportedName(ttype.Name()))
+	g.inputs[ttype.Name()] = name
+
+	fieldMap := ttype.Fields()
+	fieldNames := []string{}
+	for fieldName := range fieldMap {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	var decl bytes.Buffer
+	writeDescription(&decl, name, ttype.Description())
+	fmt.Fprintf(&decl, "type %v struct {\n", name)
+	for _, fieldName := range fieldNames {
+		field := fieldMap[fieldName]
+		goType, err := g.inputGoType(field.Type)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&decl, "\t%v %v `json:\"%v%v\"`\n", exportedName(fieldName), goType, fieldName, omitEmpty(field.Type))
+	}
+	fmt.Fprintf(&decl, "}\n")
+	g.decls = append(g.decls, decl.String())
+	return name, nil
+}
+
+func (g *generator) inputTypeFromAST(typeAST ast.Type) (graphql.Type, error) {
+	switch typeAST := typeAST.(type) {
+	case *ast.NonNull:
+		inner, err := g.inputTypeFromAST(typeAST.Type)
+		if err != nil {
+			return nil, err
+		}
+		return graphql.NewNonNull(inner), nil
+	case *ast.List:
+		inner, err := g.inputTypeFromAST(typeAST.Type)
+		if err != nil {
+			return nil, err
+		}
+		return graphql.NewList(inner), nil
+	case *ast.Named:
+		if ttype := g.schema.Type(typeAST.Name.Value); ttype != nil {
+			return ttype, nil
+		}
+		return nil, fmt.Errorf(`codegen: unknown type "%v"`, typeAST.Name.Value)
+	}
+	return nil, fmt.Errorf("codegen: unsupported type %v", typeAST)
+}
+
+func fieldDef(schema *graphql.Schema, parentType graphql.Composite, name string) *graphql.FieldDefinition {
+	switch name {
+	case graphql.TypeNameMetaFieldDef.Name:
+		return graphql.TypeNameMetaFieldDef
+	case graphql.SchemaMetaFieldDef.Name:
+		if parentType == schema.QueryType() {
+			return graphql.SchemaMetaFieldDef
+		}
+	case graphql.TypeMetaFieldDef.Name:
+		if parentType == schema.QueryType() {
+			return graphql.TypeMetaFieldDef
+		}
+	}
+	switch parentType := parentType.(type) {
+	case *graphql.Object:
+		return parentType.Fields()[name]
+	case *graphql.Interface:
+		return parentType.Fields()[name]
+	}
+	return nil
+}
+
+func typeConditionMatches(schema *graphql.Schema, condition string, object *graphql.Object) bool {
+	if condition == object.Name() {
+		return true
+	}
+	if abstractType, ok := schema.Type(condition).(graphql.Abstract); ok {
+		return schema.IsPossibleType(abstractType, object)
+	}
+	return false
+}
+
+func omitEmpty(ttype graphql.Type) string {
+	if _, ok := ttype.(*graphql.NonNull); ok {
+		return ""
+	}
+	return ",omitempty"
+}
+
+func writeDescription(buf *bytes.Buffer, name, description string) {
+	if description == "" {
+		return
+	}
+	for i, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if i == 0 {
+			fmt.Fprintf(buf, "// %v: %v\n", name, line)
+			continue
+		}
+		fmt.Fprintf(buf, "// %v\n", line)
+	}
+}
+
+func goStringLiteral(s string) string {
+	if !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return fmt.Sprintf("%q", s)
+}
+
+var commonInitialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"JSON": true, "SQL": true, "UI": true, "URI": true, "URL": true, "UUID": true,
+}
+
+// exportedName converts a GraphQL name to an exported Go identifier, e.g.
+// "user_id" and "userId" both become "UserID".
+func exportedName(name string) string {
+	words := []string{}
+	start := 0
+	runes := []rune(name)
+	for i := 0; i <= len(runes); i++ {
+		if i == len(runes) || runes[i] == '_' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i > start && isUpper(runes[i]) && !isUpper(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	var out strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			out.WriteString(upper)
+			continue
+		}
+		out.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	if out.Len() == 0 {
+		return "X"
+	}
+	return out.String()
+}
+
+func isUpper(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
@@ -0,0 +1,219 @@
+package codegen_test
+
+import (
+	"context"
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/codegen"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+const heroOperations = `
+query HeroDetails($episode: Episode) {
+  hero(episode: $episode) {
+    ...CharacterFields
+    ... on Droid {
+      primaryFunction
+    }
+    friends {
+      name
+    }
+  }
+}
+
+fragment CharacterFields on Character {
+  id
+  name
+  appearsIn
+}
+
+query HumanName($id: String!) {
+  human(id: $id) {
+    name
+    homePlanet
+  }
+}
+`
+
+func generate(t *testing.T, config codegen.Config) string {
+	code, err := codegen.Generate(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	typeCheck(t, string(code))
+	return string(code)
+}
+
+// typeCheck makes sure the generated code compiles.
+func typeCheck(t *testing.T, code string) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "client.go", code, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%v", err, code)
+	}
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := config.Check("client", fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("generated code does not type check: %v\n%v", err, code)
+	}
+}
+
+// expectContains checks for snippets regardless of the alignment added by
+// gofmt.
+func expectContains(t *testing.T, code string, snippets ...string) {
+	normalized := strings.Join(strings.Fields(code), " ")
+	for _, snippet := range snippets {
+		if !strings.Contains(normalized, snippet) {
+			t.Fatalf("expected generated code to contain %q:\n%v", snippet, code)
+		}
+	}
+}
+
+func TestGenerate_OperationsWithFragmentsAndAbstractTypes(t *testing.T) {
+	code := generate(t, codegen.Config{
+		Schema:      &testutil.StarWarsSchema,
+		Sources:     []*source.Source{source.NewSource(&source.Source{Body: []byte(heroOperations)})},
+		PackageName: "starwars",
+	})
+	expectContains(t, code,
+		"package starwars",
+		"type Client interface {",
+		"func HeroDetails(ctx context.Context, client Client, variables *HeroDetailsVariables) (*HeroDetailsResponse, error) {",
+		"func HumanName(ctx context.Context, client Client, variables *HumanNameVariables) (*HumanNameResponse, error) {",
+		"Episode *Episode `json:\"episode,omitempty\"`",
+		"ID string `json:\"id\"`",
+		"Hero *HeroDetailsHero `json:\"hero\"`",
+		"AppearsIn []*Episode `json:\"appearsIn\"`",
+		"Friends []*HeroDetailsHeroFriends `json:\"friends\"`",
+		"Typename string `json:\"__typename\"`",
+		"OnDroid *HeroDetailsHeroOnDroid `json:\"-\"`",
+		"PrimaryFunction *string `json:\"primaryFunction\"`",
+		"func (v *HeroDetailsHero) UnmarshalJSON(data []byte) error {",
+		"EpisodeNewhope Episode = \"NEWHOPE\"",
+		"fragment CharacterFields on Character",
+		// the concrete types of abstract types are requested
+		"friends { name __typename } __typename } }",
+		"fragment CharacterFields on Character { id name appearsIn }`",
+	)
+	if strings.Contains(code, "OnHuman") {
+		t.Fatalf("expected no type specific struct for Human:\n%v", code)
+	}
+}
+
+func TestGenerate_RejectsInvalidOperations(t *testing.T) {
+	tests := map[string]string{
+		"invalid field": `query Q { hero { unknownField } }`,
+		"anonymous":     `{ hero { name } }`,
+		"syntax":        `query Q { hero { `,
+	}
+	for name, operations := range tests {
+		_, err := codegen.Generate(codegen.Config{
+			Schema:  &testutil.StarWarsSchema,
+			Sources: []*source.Source{source.NewSource(&source.Source{Body: []byte(operations)})},
+		})
+		if err == nil {
+			t.Fatalf("%v: expected an error", name)
+		}
+	}
+}
+
+func TestGenerate_InputObjectsAndCustomScalars(t *testing.T) {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, `
+		scalar Time
+		scalar Cursor
+		enum Status { OPEN CLOSED }
+		input TaskInput {
+			title: String!
+			due: Time
+			tags: [String!]
+			parent: TaskInput
+		}
+		type Task {
+			id: ID!
+			title: String!
+			status: Status!
+			due: Time
+			cursor: Cursor
+		}
+		type Query { tasks(status: Status): [Task!]! }
+		type Mutation { createTask(input: TaskInput!): Task }
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code := generate(t, codegen.Config{
+		Schema: &schema,
+		Sources: []*source.Source{source.NewSource(&source.Source{Body: []byte(`
+			mutation CreateTask($input: TaskInput!) {
+				task: createTask(input: $input) { id status due cursor }
+			}
+			query OpenTasks { tasks(status: OPEN) { title } }
+		`)})},
+		Scalars: map[string]string{"Time": "string"},
+	})
+	expectContains(t, code,
+		"package client",
+		"type TaskInput struct {",
+		"Parent *TaskInput `json:\"parent,omitempty\"`",
+		"Tags []string `json:\"tags,omitempty\"`",
+		"Due *string `json:\"due,omitempty\"`",
+		"Input TaskInput `json:\"input\"`",
+		"Task *CreateTaskTask `json:\"task\"`",
+		"Status Status `json:\"status\"`",
+		"Cursor interface{} `json:\"cursor\"`",
+		"Tasks []OpenTasksTasks `json:\"tasks\"`",
+		"func OpenTasks(ctx context.Context, client Client) (*OpenTasksResponse, error) {",
+	)
+}
+
+func TestHTTPClient_DecodesDataAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params struct {
+			Query         string                 `json:"query"`
+			OperationName string                 `json:"operationName"`
+			Variables     map[string]interface{} `json:"variables"`
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &params); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		result := graphql.Do(graphql.Params{
+			Schema:         testutil.StarWarsSchema,
+			RequestString:  params.Query,
+			OperationName:  params.OperationName,
+			VariableValues: params.Variables,
+		})
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	client := codegen.NewHTTPClient(server.URL, nil)
+	var response struct {
+		Human *struct {
+			Name string `json:"name"`
+		} `json:"human"`
+	}
+	err := client.Do(context.Background(), `query H($id: String!) { human(id: $id) { name } }`, "H", map[string]interface{}{"id": "1000"}, &response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Human == nil || response.Human.Name != "Luke Skywalker" {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+
+	err = client.Do(context.Background(), `query { unknown }`, "", nil, &response)
+	if _, ok := err.(codegen.Errors); !ok {
+		t.Fatalf("expected GraphQL errors, got %v", err)
+	}
+}
@@ -0,0 +1,401 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// DecodeArgs decodes the arguments of the field into target, a pointer to a
+// struct. Arguments are matched with the struct fields by their `graphql` or
+// `json` tags, or by name, case-insensitively. Input objects are decoded into
+// structs or maps, lists into slices or arrays, and enums and custom scalars
+// are assigned or converted to the type of the struct field.
+//
+// It returns an error naming the argument if an argument has no struct field
+// or if a value cannot be decoded into its struct field.
+func (p ResolveParams) DecodeArgs(target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("DecodeArgs target must be a non-nil pointer to a struct, got %T", target)
+	}
+	return decodeStruct(p.Args, rv.Elem(), "")
+}
+
+// argField is a struct field decoded from an argument or input field.
+type argField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// argFields returns the fields of a struct type, flattening embedded structs
+// as encoding/json does.
+func argFields(t reflect.Type) []argField {
+	fields := []argField{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, tagged := argFieldName(sf)
+		if name == "-" {
+			continue
+		}
+		if sf.Anonymous && !tagged {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, embedded := range argFields(ft) {
+					embedded.index = append([]int{i}, embedded.index...)
+					fields = append(fields, embedded)
+				}
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		fields = append(fields, argField{name: name, index: []int{i}, typ: sf.Type})
+	}
+	return fields
+}
+
+func argFieldName(sf reflect.StructField) (string, bool) {
+	for _, key := range []string{"graphql", "json"} {
+		tag, ok := sf.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name, true
+		}
+	}
+	return sf.Name, false
+}
+
+// lookupArgField returns the field of name, preferring exact matches.
+func lookupArgField(fields []argField, name string) (argField, bool) {
+	for _, field := range fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, name) {
+			return field, true
+		}
+	}
+	return argField{}, false
+}
+
+func joinArgPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func decodeStruct(values map[string]interface{}, rv reflect.Value, path string) error {
+	fields := argFields(rv.Type())
+	for name, value := range values {
+		field, ok := lookupArgField(fields, name)
+		if !ok {
+			return fmt.Errorf(`Argument "%v" has no field in %v.`, joinArgPath(path, name), rv.Type())
+		}
+		if err := decodeArgValue(value, fieldByIndex(rv, field.index), joinArgPath(path, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex allocating nil embedded pointers.
+func fieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv
+}
+
+func decodeArgValue(value interface{}, rv reflect.Value, path string) error {
+	if value == nil {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+	vv := reflect.ValueOf(value)
+	if vv.Type().AssignableTo(rv.Type()) {
+		rv.Set(vv)
+		return nil
+	}
+	if ok := convertArgValue(vv, rv); ok {
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(rv.Type().Elem())
+		if err := decodeArgValue(value, elem.Elem(), path); err != nil {
+			return err
+		}
+		rv.Set(elem)
+		return nil
+	case reflect.Struct:
+		if values, ok := value.(map[string]interface{}); ok {
+			return decodeStruct(values, rv, path)
+		}
+	case reflect.Map:
+		values, ok := value.(map[string]interface{})
+		if !ok || rv.Type().Key().Kind() != reflect.String {
+			break
+		}
+		m := reflect.MakeMapWithSize(rv.Type(), len(values))
+		for key, value := range values {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := decodeArgValue(value, elem, joinArgPath(path, key)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()), elem)
+		}
+		rv.Set(m)
+		return nil
+	case reflect.Slice, reflect.Array:
+		values, ok := value.([]interface{})
+		if !ok {
+			break
+		}
+		list := rv
+		if rv.Kind() == reflect.Slice {
+			list = reflect.MakeSlice(rv.Type(), len(values), len(values))
+		} else if len(values) > rv.Len() {
+			return fmt.Errorf(`Argument "%v" has %v values, more than the length of %v.`, path, len(values), rv.Type())
+		}
+		for i, value := range values {
+			if err := decodeArgValue(value, list.Index(i), fmt.Sprintf("%v[%v]", path, i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(list)
+		return nil
+	}
+	return fmt.Errorf(`Argument "%v" cannot be decoded into %v: got %T %v.`, path, rv.Type(), value, value)
+}
+
+// convertArgValue converts values between kinds of the same family, e.g. an
+// Int into an int32 or an enum value into a named string type. Numbers must be
+// representable in the target type.
+func convertArgValue(vv reflect.Value, rv reflect.Value) bool {
+	if !convertibleArgKind(vv.Type(), rv.Type()) {
+		return false
+	}
+	if (isIntKind(rv.Kind()) || isUintKind(rv.Kind())) && !representableArgValue(vv, rv) {
+		return false
+	}
+	rv.Set(vv.Convert(rv.Type()))
+	return true
+}
+
+// representableArgValue reports whether the number vv fits the integer rv.
+func representableArgValue(vv reflect.Value, rv reflect.Value) bool {
+	signed := isIntKind(rv.Kind())
+	switch {
+	case isIntKind(vv.Kind()):
+		i := vv.Int()
+		if signed {
+			return !rv.OverflowInt(i)
+		}
+		return i >= 0 && !rv.OverflowUint(uint64(i))
+	case isUintKind(vv.Kind()):
+		u := vv.Uint()
+		if signed {
+			return u <= math.MaxInt64 && !rv.OverflowInt(int64(u))
+		}
+		return !rv.OverflowUint(u)
+	}
+	f := vv.Float()
+	if f != math.Trunc(f) {
+		return false
+	}
+	if signed {
+		return f >= math.MinInt64 && f < math.MaxInt64 && !rv.OverflowInt(int64(f))
+	}
+	return f >= 0 && f < math.MaxUint64 && !rv.OverflowUint(uint64(f))
+}
+
+func convertibleArgKind(from, to reflect.Type) bool {
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+	isNumber := func(k reflect.Kind) bool {
+		return isIntKind(k) || isUintKind(k) || k == reflect.Float32 || k == reflect.Float64
+	}
+	switch {
+	case isNumber(from.Kind()):
+		return isNumber(to.Kind())
+	case from.Kind() == reflect.String:
+		return to.Kind() == reflect.String
+	case from.Kind() == reflect.Bool:
+		return to.Kind() == reflect.Bool
+	}
+	return false
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// builtinScalarTypes are the Go types of the values parsed by the built-in
+// scalars.
+var builtinScalarTypes = map[*Scalar]reflect.Type{
+	Int:      reflect.TypeOf(0),
+	Float:    reflect.TypeOf(0.0),
+	String:   reflect.TypeOf(""),
+	ID:       reflect.TypeOf(""),
+	Boolean:  reflect.TypeOf(false),
+	DateTime: reflect.TypeOf(time.Time{}),
+}
+
+// checkArgsStruct reports the mismatches between args and the struct of
+// value, the ArgsStruct of a field.
+func checkArgsStruct(args FieldConfigArgument, value interface{}) error {
+	t := reflect.TypeOf(value)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct, got %T", value)
+	}
+	inputs := map[string]Input{}
+	for name, arg := range args {
+		if arg != nil {
+			inputs[name] = arg.Type
+		}
+	}
+	return (&argsChecker{seen: map[argsCheck]bool{}}).checkStruct(inputs, t, "")
+}
+
+type argsCheck struct {
+	ttype Input
+	t     reflect.Type
+}
+
+type argsChecker struct {
+	seen map[argsCheck]bool
+}
+
+func (c *argsChecker) checkStruct(inputs map[string]Input, t reflect.Type, path string) error {
+	fields := argFields(t)
+	for name, input := range inputs {
+		field, ok := lookupArgField(fields, name)
+		if !ok {
+			return fmt.Errorf(`argument "%v" has no field in %v`, joinArgPath(path, name), t)
+		}
+		if err := c.check(input, field.typ, joinArgPath(path, name)); err != nil {
+			return err
+		}
+	}
+	for _, field := range fields {
+		found := false
+		for name := range inputs {
+			found = found || strings.EqualFold(field.name, name)
+		}
+		if !found {
+			return fmt.Errorf(`field %v.%v matches no argument "%v"`, t, field.name, joinArgPath(path, field.name))
+		}
+	}
+	return nil
+}
+
+func (c *argsChecker) check(ttype Input, t reflect.Type, path string) error {
+	if t.Kind() == reflect.Interface && t.NumMethod() == 0 {
+		return nil
+	}
+	if nonNull, ok := ttype.(*NonNull); ok {
+		return c.check(nonNull.OfType.(Input), t, path)
+	}
+	key := argsCheck{ttype, t}
+	if c.seen[key] {
+		return nil
+	}
+	c.seen[key] = true
+
+	mismatch := fmt.Errorf(`argument "%v" of type %v cannot be decoded into %v`, path, ttype, t)
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		from, ok := builtinScalarTypes[ttype]
+		if !ok {
+			// custom scalars parse to any Go values
+			return nil
+		}
+		if !decodableArgType(from, t) {
+			return mismatch
+		}
+	case *Enum:
+		for _, value := range ttype.Values() {
+			if value.Value != nil && !decodableArgType(reflect.TypeOf(value.Value), t) {
+				return mismatch
+			}
+		}
+	case *List:
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return mismatch
+		}
+		return c.check(ttype.OfType.(Input), t.Elem(), path+"[]")
+	case *InputObject:
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			inputs := map[string]Input{}
+			for name, field := range ttype.Fields() {
+				inputs[name] = field.Type
+			}
+			return c.checkStruct(inputs, t, path)
+		case reflect.Map:
+			if t.Key().Kind() != reflect.String {
+				return mismatch
+			}
+			for _, field := range ttype.Fields() {
+				if err := c.check(field.Type, t.Elem(), joinArgPath(path, field.Name())); err != nil {
+					return err
+				}
+			}
+		default:
+			return mismatch
+		}
+	}
+	return nil
+}
+
+// decodableArgType reports whether values of type from can be decoded into t.
+// Floats are not decodable into integers, although integral values are.
+func decodableArgType(from, t reflect.Type) bool {
+	isFloat := from.Kind() == reflect.Float32 || from.Kind() == reflect.Float64
+	for {
+		if isFloat && (isIntKind(t.Kind()) || isUintKind(t.Kind())) {
+			return false
+		}
+		if from.AssignableTo(t) || convertibleArgKind(from, t) {
+			return true
+		}
+		if t.Kind() != reflect.Ptr {
+			return false
+		}
+		t = t.Elem()
+	}
+}
@@ -0,0 +1,237 @@
+package graphql_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type decodeArgsColor string
+
+type decodeArgsFilter struct {
+	Name   *string           `json:"name"`
+	Colors []decodeArgsColor `json:"colors"`
+	Range  struct {
+		Min int32
+		Max int32
+	} `graphql:"range"`
+}
+
+type decodeArgsPage struct {
+	First int `json:"first"`
+}
+
+type decodeArgs struct {
+	decodeArgsPage
+	Filter *decodeArgsFilter      `json:"filter"`
+	IDs    []string               `graphql:"ids"`
+	Score  float32                `json:"score"`
+	Meta   map[string]interface{} `json:"meta"`
+	Extra  interface{}            `json:"extra"`
+	Ignore string                 `json:"-"`
+	Nested map[string]int         `json:"nested"`
+}
+
+var decodeArgsColorEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Color",
+	Values: graphql.EnumValueConfigMap{
+		"RED":   &graphql.EnumValueConfig{Value: "red"},
+		"GREEN": &graphql.EnumValueConfig{Value: "green"},
+	},
+})
+
+var decodeArgsRangeInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "Range",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"min": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+		"max": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+	},
+})
+
+var decodeArgsFilterInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "Filter",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"colors": &graphql.InputObjectFieldConfig{Type: graphql.NewList(decodeArgsColorEnum)},
+		"range":  &graphql.InputObjectFieldConfig{Type: decodeArgsRangeInput},
+	},
+})
+
+var decodeArgsPageInput = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "Page",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"first": &graphql.InputObjectFieldConfig{Type: graphql.Int},
+	},
+})
+
+var decodeArgsConfig = graphql.FieldConfigArgument{
+	"first":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+	"filter": &graphql.ArgumentConfig{Type: decodeArgsFilterInput},
+	"ids":    &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+	"score":  &graphql.ArgumentConfig{Type: graphql.Float},
+	"meta":   &graphql.ArgumentConfig{Type: decodeArgsFilterInput},
+	"extra":  &graphql.ArgumentConfig{Type: graphql.String},
+	"nested": &graphql.ArgumentConfig{Type: decodeArgsPageInput},
+}
+
+func decodeArgsSchema(t *testing.T, argsStruct interface{}, args graphql.FieldConfigArgument, decoded *decodeArgs) (graphql.Schema, error) {
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"search": &graphql.Field{
+					Type:       graphql.String,
+					Args:       args,
+					ArgsStruct: argsStruct,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "ok", p.DecodeArgs(decoded)
+					},
+				},
+			},
+		}),
+	})
+}
+
+func TestDecodeArgs_DecodesNestedValues(t *testing.T) {
+	decoded := decodeArgs{}
+	schema, err := decodeArgsSchema(t, nil, decodeArgsConfig, &decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `query ($ids: [ID!]) {
+			search(
+				filter: {name: "gopher", colors: [RED, GREEN], range: {min: 1, max: 5}},
+				ids: $ids,
+				score: 2,
+				meta: {colors: RED},
+				extra: "x",
+				nested: {first: 3},
+			)
+		}`,
+		VariableValues: map[string]interface{}{"ids": []interface{}{1, "b"}},
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	name := "gopher"
+	expected := decodeArgs{
+		decodeArgsPage: decodeArgsPage{First: 10},
+		Filter: &decodeArgsFilter{
+			Name:   &name,
+			Colors: []decodeArgsColor{"red", "green"},
+		},
+		IDs:    []string{"1", "b"},
+		Score:  2,
+		Meta:   map[string]interface{}{"colors": []interface{}{"red"}},
+		Extra:  "x",
+		Nested: map[string]int{"first": 3},
+	}
+	expected.Filter.Range.Min = 1
+	expected.Filter.Range.Max = 5
+	if !reflect.DeepEqual(expected, decoded) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, decoded))
+	}
+}
+
+func TestDecodeArgs_ReportsMismatches(t *testing.T) {
+	tests := []struct {
+		args     map[string]interface{}
+		target   interface{}
+		expected string
+	}{
+		{
+			args:     map[string]interface{}{"frist": 1},
+			target:   &decodeArgsPage{},
+			expected: `Argument "frist" has no field in graphql_test.decodeArgsPage.`,
+		},
+		{
+			args:     map[string]interface{}{"first": "ten"},
+			target:   &decodeArgsPage{},
+			expected: `Argument "first" cannot be decoded into int: got string ten.`,
+		},
+		{
+			args: map[string]interface{}{
+				"filter": map[string]interface{}{"range": map[string]interface{}{"min": 1 << 40}},
+			},
+			target:   &decodeArgs{},
+			expected: `Argument "filter.range.min" cannot be decoded into int32: got int 1099511627776.`,
+		},
+		{
+			args:     map[string]interface{}{"ids": []interface{}{"a", 2}},
+			target:   &decodeArgs{},
+			expected: `Argument "ids[1]" cannot be decoded into string: got int 2.`,
+		},
+		{
+			args:     map[string]interface{}{},
+			target:   decodeArgsPage{},
+			expected: `DecodeArgs target must be a non-nil pointer to a struct, got graphql_test.decodeArgsPage`,
+		},
+	}
+	for _, test := range tests {
+		err := graphql.ResolveParams{Args: test.args}.DecodeArgs(test.target)
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("expected error %q, got %v", test.expected, err)
+		}
+	}
+}
+
+func TestDecodeArgs_ChecksArgsStructWhenBuildingSchema(t *testing.T) {
+	if _, err := decodeArgsSchema(t, decodeArgs{}, decodeArgsConfig, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	type typo struct {
+		Frist int `json:"frist"`
+	}
+	type wrongType struct {
+		First string `json:"first"`
+	}
+	type wrongEnum struct {
+		Filter struct {
+			Name   string
+			Colors []int
+			Range  map[string]int
+		}
+	}
+	tests := []struct {
+		argsStruct interface{}
+		args       graphql.FieldConfigArgument
+		expected   string
+	}{
+		{
+			argsStruct: typo{},
+			args:       graphql.FieldConfigArgument{"first": &graphql.ArgumentConfig{Type: graphql.Int}},
+			expected:   `argument "first" has no field in graphql_test.typo`,
+		},
+		{
+			argsStruct: &wrongType{},
+			args:       graphql.FieldConfigArgument{"first": &graphql.ArgumentConfig{Type: graphql.Int}},
+			expected:   `argument "first" of type Int cannot be decoded into string`,
+		},
+		{
+			argsStruct: wrongEnum{},
+			args:       graphql.FieldConfigArgument{"filter": &graphql.ArgumentConfig{Type: graphql.NewNonNull(decodeArgsFilterInput)}},
+			expected:   `argument "filter.colors[]" of type Color cannot be decoded into int`,
+		},
+		{
+			argsStruct: decodeArgsPage{},
+			args:       graphql.FieldConfigArgument{},
+			expected:   `field graphql_test.decodeArgsPage.first matches no argument "first"`,
+		},
+	}
+	for _, test := range tests {
+		_, err := decodeArgsSchema(t, test.argsStruct, test.args, nil)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Fatalf("expected error containing %q, got %v", test.expected, err)
+		}
+		if !strings.HasPrefix(err.Error(), "Query.search args do not match") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -200,8 +200,9 @@ func GetNamed(ttype Type) Named {
 //	  }
 //	});
 type Scalar struct {
-	PrivateName        string `json:"name"`
-	PrivateDescription string `json:"description"`
+	PrivateName           string `json:"name"`
+	PrivateDescription    string `json:"description"`
+	PrivateSpecifiedByURL string `json:"specifiedByURL"`
 
 	scalarConfig ScalarConfig
 	err          error
@@ -217,12 +218,20 @@ type ParseValueFn func(value interface{}) interface{}
 type ParseLiteralFn func(valueAST ast.Value) interface{}
 
 // ScalarConfig options for creating a new GraphQLScalar
+//
+// Serialize, ParseValue and ParseLiteral return nil for values they cannot
+// handle. They may return an error instead, whose message is then reported
+// to explain why the value is invalid.
 type ScalarConfig struct {
 	Name         string `json:"name"`
 	Description  string `json:"description"`
 	Serialize    SerializeFn
 	ParseValue   ParseValueFn
 	ParseLiteral ParseLiteralFn
+
+	// SpecifiedByURL points to a specification of the data format of the
+	// scalar, exposed through introspection and the @specifiedBy directive.
+	SpecifiedByURL string `json:"specifiedByURL"`
 }
 
 // NewScalar creates a new GraphQLScalar
@@ -242,6 +251,7 @@ func NewScalar(config ScalarConfig) *Scalar {
 
 	st.PrivateName = config.Name
 	st.PrivateDescription = config.Description
+	st.PrivateSpecifiedByURL = config.SpecifiedByURL
 
 	err = invariantf(
 		config.Serialize != nil,
@@ -292,6 +302,9 @@ func (st *Scalar) Description() string {
 	return st.PrivateDescription
 
 }
+func (st *Scalar) SpecifiedByURL() string {
+	return st.PrivateSpecifiedByURL
+}
 func (st *Scalar) String() string {
 	return st.PrivateName
 }
@@ -562,6 +575,11 @@ func defineFieldMap(ttype Named, fieldMap Fields) (FieldDefinitionMap, error) {
 			}
 			fieldDef.Args = append(fieldDef.Args, fieldArg)
 		}
+		if field.ArgsStruct != nil {
+			if err = checkArgsStruct(field.Args, field.ArgsStruct); err != nil {
+				return resultFieldMap, fmt.Errorf(`%v.%v args do not match %T: %v.`, ttype, fieldName, field.ArgsStruct, err)
+			}
+		}
 		resultFieldMap[fieldName] = fieldDef
 	}
 	return resultFieldMap, nil
@@ -609,6 +627,11 @@ type Field struct {
 	Subscribe         FieldResolveFn      `json:"-"`
 	DeprecationReason string              `json:"deprecationReason"`
 	Description       string              `json:"description"`
+
+	// ArgsStruct optionally is a value of the struct the resolver decodes its
+	// arguments into with ResolveParams.DecodeArgs. When set, defining the
+	// field fails if the struct does not match Args.
+	ArgsStruct interface{} `json:"-"`
 }
 
 type FieldConfigArgument map[string]*ArgumentConfig
@@ -630,6 +653,28 @@ type FieldDefinition struct {
 	DeprecationReason string         `json:"deprecationReason"`
 }
 
+// ToFieldConfig returns a field configuration equivalent to the definition,
+// which allows building new types from the fields of existing ones.
+func (def *FieldDefinition) ToFieldConfig() *Field {
+	args := FieldConfigArgument{}
+	for _, arg := range def.Args {
+		args[arg.Name()] = &ArgumentConfig{
+			Type:         arg.Type,
+			DefaultValue: arg.DefaultValue,
+			Description:  arg.Description(),
+		}
+	}
+	return &Field{
+		Name:              def.Name,
+		Type:              def.Type,
+		Args:              args,
+		Resolve:           def.Resolve,
+		Subscribe:         def.Subscribe,
+		DeprecationReason: def.DeprecationReason,
+		Description:       def.Description,
+	}
+}
+
 type FieldArgument struct {
 	Name         string      `json:"name"`
 	Type         Type        `json:"type"`
@@ -707,6 +752,15 @@ type ResolveTypeParams struct {
 
 type ResolveTypeFn func(p ResolveTypeParams) *Object
 
+// TypedValue is a value of an abstract type along with the name of its object
+// type, for resolvers knowing the type of the values they return. Its type is
+// resolved from TypeName instead of ResolveType or IsTypeOf, and the fields of
+// the object are resolved with Value as source.
+type TypedValue struct {
+	TypeName string
+	Value    interface{}
+}
+
 func NewInterface(config InterfaceConfig) *Interface {
 	it := &Interface{}
 
@@ -0,0 +1,419 @@
+// Package delegate forwards fields of a local schema to a remote GraphQL
+// server.
+//
+// NewRemoteSchema builds a schema from the introspection of a remote server
+// whose root fields delegate to it. It can be served as is or combined with
+// local schemas using graphql.MergeSchemas. Delegate forwards the field being
+// resolved, which makes it usable as the resolver of any field that matches a
+// root field of the remote schema.
+package delegate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+const introspectionQuery = `
+  query IntrospectionQuery {
+    __schema {
+      queryType { name }
+      mutationType { name }
+      subscriptionType { name }
+      types { ...FullType }
+      directives {
+        name
+        description
+        locations
+        args { ...InputValue }
+      }
+    }
+  }
+
+  fragment FullType on __Type {
+    kind
+    name
+    description
+    fields(includeDeprecated: true) {
+      name
+      description
+      args { ...InputValue }
+      type { ...TypeRef }
+      isDeprecated
+      deprecationReason
+    }
+    inputFields { ...InputValue }
+    interfaces { ...TypeRef }
+    enumValues(includeDeprecated: true) {
+      name
+      description
+      isDeprecated
+      deprecationReason
+    }
+    possibleTypes { ...TypeRef }
+  }
+
+  fragment InputValue on __InputValue {
+    name
+    description
+    type { ...TypeRef }
+    defaultValue
+  }
+
+  fragment TypeRef on __Type {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+                ofType {
+                  kind
+                  name
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+`
+
+// NewRemoteSchema introspects the remote server and returns its schema. The
+// query and mutation fields delegate to the server; the fields of the other
+// object types read the values it returned.
+func NewRemoteSchema(ctx context.Context, executor RemoteExecutor) (graphql.Schema, error) {
+	response, err := executor.Execute(ctx, Request{
+		Query:         introspectionQuery,
+		OperationName: "IntrospectionQuery",
+	})
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+	if len(response.Errors) > 0 {
+		return graphql.Schema{}, fmt.Errorf("delegate: introspection failed: %v", response.Errors[0].Message)
+	}
+	schema, err := graphql.BuildClientSchema(response.Data)
+	if err != nil {
+		return schema, err
+	}
+
+	delegateField := func(p graphql.ResolveParams) (interface{}, error) {
+		return Delegate(p, executor)
+	}
+	for name, ttype := range schema.TypeMap() {
+		object, ok := ttype.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		resolve := resolveRemoteField
+		if object == schema.QueryType() || object == schema.MutationType() {
+			resolve = delegateField
+		}
+		for _, field := range object.Fields() {
+			field.Resolve = resolve
+		}
+	}
+	return schema, nil
+}
+
+// Delegate resolves the current field by sending it, with its sub-selection,
+// the fragments it spreads and the variables it uses, to the remote server as
+// a root field of an operation of the same type. Remote errors are reported
+// on the local fields they belong to.
+func Delegate(p graphql.ResolveParams, executor RemoteExecutor) (interface{}, error) {
+	operation, ok := p.Info.Operation.(*ast.OperationDefinition)
+	if !ok || len(p.Info.FieldASTs) == 0 {
+		return nil, fmt.Errorf("delegate: missing operation")
+	}
+	if operation.Operation == ast.OperationTypeSubscription {
+		return nil, fmt.Errorf("delegate: subscriptions cannot be delegated")
+	}
+
+	request := buildRequest(p, operation)
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	response, err := executor.Execute(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	key := responseKey(p.Info.FieldASTs[0])
+	var unplaced error
+	for _, formatted := range response.Errors {
+		err := &remoteError{formatted}
+		if !placeError(response.Data, formatted.Path, err) && unplaced == nil {
+			unplaced = err
+		}
+	}
+	value := response.Data[key]
+	if err, ok := value.(*remoteError); ok {
+		return nil, err
+	}
+	if value == nil && unplaced != nil {
+		return nil, unplaced
+	}
+	return value, nil
+}
+
+// resolveRemoteField reads the value of a field from the remote result, by
+// response key since the forwarded selection keeps aliases.
+func resolveRemoteField(p graphql.ResolveParams) (interface{}, error) {
+	source, ok := p.Source.(map[string]interface{})
+	if !ok || len(p.Info.FieldASTs) == 0 {
+		return nil, nil
+	}
+	value := source[responseKey(p.Info.FieldASTs[0])]
+	if err, ok := value.(*remoteError); ok {
+		return nil, err
+	}
+	return value, nil
+}
+
+func responseKey(field *ast.Field) string {
+	if field.Alias != nil && field.Alias.Value != "" {
+		return field.Alias.Value
+	}
+	return field.Name.Value
+}
+
+// remoteError is an error reported by the remote server. It keeps the
+// extensions of the original error.
+type remoteError struct {
+	formatted gqlerrors.FormattedError
+}
+
+func (err *remoteError) Error() string {
+	return err.formatted.Message
+}
+
+func (err *remoteError) Extensions() map[string]interface{} {
+	return err.formatted.Extensions
+}
+
+// placeError stores err in the remote result, in place of the value of the
+// field it belongs to, so that resolving this field locally reports err at
+// the local path. When the error nulled a parent, it is stored in place of
+// the closest null parent field instead.
+func placeError(data map[string]interface{}, path []interface{}, err *remoteError) bool {
+	var (
+		container map[string]interface{}
+		key       string
+		current   interface{} = data
+	)
+	for _, segment := range path {
+		if current == nil {
+			break
+		}
+		if _, ok := current.(*remoteError); ok {
+			break
+		}
+		if name, ok := segment.(string); ok {
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				break
+			}
+			container, key = object, name
+			current = object[name]
+			continue
+		}
+		index, ok := pathIndex(segment)
+		list, isList := current.([]interface{})
+		if !ok || !isList || index < 0 || index >= len(list) {
+			break
+		}
+		current = list[index]
+	}
+	if container == nil {
+		return false
+	}
+	if _, ok := container[key].(*remoteError); !ok {
+		container[key] = err
+	}
+	return true
+}
+
+func pathIndex(segment interface{}) (int, bool) {
+	switch segment := segment.(type) {
+	case int:
+		return segment, true
+	case float64:
+		return int(segment), true
+	case json.Number:
+		index, err := segment.Int64()
+		return int(index), err == nil
+	}
+	return 0, false
+}
+
+// requestBuilder copies the forwarded selections, adding `__typename` to
+// every selection set so that abstract types can be resolved locally, and
+// collects the fragments and variables they use.
+type requestBuilder struct {
+	fragments map[string]ast.Definition
+	used      map[string]*ast.FragmentDefinition
+	variables map[string]bool
+}
+
+func buildRequest(p graphql.ResolveParams, operation *ast.OperationDefinition) Request {
+	b := &requestBuilder{
+		fragments: p.Info.Fragments,
+		used:      map[string]*ast.FragmentDefinition{},
+		variables: map[string]bool{},
+	}
+	selections := []ast.Selection{}
+	for _, field := range p.Info.FieldASTs {
+		selections = append(selections, b.field(field))
+	}
+
+	variableDefinitions := []*ast.VariableDefinition{}
+	variables := map[string]interface{}{}
+	for _, def := range operation.VariableDefinitions {
+		name := def.Variable.Name.Value
+		if !b.variables[name] {
+			continue
+		}
+		variableDefinitions = append(variableDefinitions, def)
+		if value, ok := p.Info.VariableValues[name]; ok {
+			variables[name] = value
+		}
+	}
+
+	doc := ast.NewDocument(&ast.Document{
+		Definitions: []ast.Node{
+			ast.NewOperationDefinition(&ast.OperationDefinition{
+				Operation:           operation.Operation,
+				Name:                operation.Name,
+				VariableDefinitions: variableDefinitions,
+				SelectionSet:        ast.NewSelectionSet(&ast.SelectionSet{Selections: selections}),
+			}),
+		},
+	})
+	names := []string{}
+	for name := range b.used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		doc.Definitions = append(doc.Definitions, b.used[name])
+	}
+
+	request := Request{
+		Query:     printer.Print(doc).(string),
+		Variables: variables,
+	}
+	if operation.Name != nil {
+		request.OperationName = operation.Name.Value
+	}
+	return request
+}
+
+func (b *requestBuilder) field(field *ast.Field) *ast.Field {
+	for _, arg := range field.Arguments {
+		b.value(arg.Value)
+	}
+	b.directives(field.Directives)
+	return ast.NewField(&ast.Field{
+		Alias:        field.Alias,
+		Name:         field.Name,
+		Arguments:    field.Arguments,
+		Directives:   field.Directives,
+		SelectionSet: b.selectionSet(field.SelectionSet, true),
+	})
+}
+
+func (b *requestBuilder) selectionSet(set *ast.SelectionSet, typename bool) *ast.SelectionSet {
+	if set == nil {
+		return nil
+	}
+	selections := []ast.Selection{}
+	if typename {
+		selections = append(selections, ast.NewField(&ast.Field{
+			Name: ast.NewName(&ast.Name{Value: "__typename"}),
+		}))
+	}
+	for _, selection := range set.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selections = append(selections, b.field(selection))
+		case *ast.InlineFragment:
+			b.directives(selection.Directives)
+			selections = append(selections, ast.NewInlineFragment(&ast.InlineFragment{
+				TypeCondition: selection.TypeCondition,
+				Directives:    selection.Directives,
+				SelectionSet:  b.selectionSet(selection.SelectionSet, false),
+			}))
+		case *ast.FragmentSpread:
+			b.directives(selection.Directives)
+			b.fragment(selection.Name.Value)
+			selections = append(selections, selection)
+		}
+	}
+	return ast.NewSelectionSet(&ast.SelectionSet{Selections: selections})
+}
+
+func (b *requestBuilder) fragment(name string) {
+	if _, ok := b.used[name]; ok {
+		return
+	}
+	def, ok := b.fragments[name].(*ast.FragmentDefinition)
+	if !ok {
+		return
+	}
+	// mark the fragment before copying it, fragments may spread each other
+	b.used[name] = def
+	b.directives(def.Directives)
+	b.used[name] = ast.NewFragmentDefinition(&ast.FragmentDefinition{
+		Name:          def.Name,
+		TypeCondition: def.TypeCondition,
+		Directives:    def.Directives,
+		SelectionSet:  b.selectionSet(def.SelectionSet, false),
+	})
+}
+
+func (b *requestBuilder) directives(directives []*ast.Directive) {
+	for _, directive := range directives {
+		for _, arg := range directive.Arguments {
+			b.value(arg.Value)
+		}
+	}
+}
+
+func (b *requestBuilder) value(value ast.Value) {
+	switch value := value.(type) {
+	case *ast.Variable:
+		b.variables[value.Name.Value] = true
+	case *ast.ListValue:
+		for _, item := range value.Values {
+			b.value(item)
+		}
+	case *ast.ObjectValue:
+		for _, field := range value.Fields {
+			b.value(field.Value)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package delegate_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/delegate"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type user struct {
+	ID      string
+	Name    string
+	Friends []string
+}
+
+var users = map[string]*user{
+	"1": {ID: "1", Name: "Ada", Friends: []string{"2", "3"}},
+	"2": {ID: "2", Name: "Grace"},
+	"3": {ID: "3", Name: "Linus"},
+}
+
+func remoteSchema(t *testing.T) graphql.Schema {
+	var userType *graphql.Object
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name": &graphql.Field{Type: graphql.String},
+				"email": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						u := p.Source.(*user)
+						if u.ID == "2" {
+							return nil, errors.New("email is private")
+						}
+						return u.Name + "@example.com", nil
+					},
+				},
+				"friends": &graphql.Field{
+					Type: graphql.NewList(userType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						friends := []*user{}
+						for _, id := range p.Source.(*user).Friends {
+							friends = append(friends, users[id])
+						}
+						return friends, nil
+					},
+				},
+			}
+		}),
+	})
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"title": &graphql.Field{Type: graphql.String},
+		},
+	})
+	searchResult := graphql.NewUnion(graphql.UnionConfig{
+		Name:  "SearchResult",
+		Types: []*graphql.Object{userType, postType},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			if _, ok := p.Value.(*user); ok {
+				return userType
+			}
+			return postType
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return users[p.Args["id"].(string)], nil
+					},
+				},
+				"search": &graphql.Field{
+					Type: graphql.NewList(searchResult),
+					Args: graphql.FieldConfigArgument{
+						"text": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{
+							users["3"],
+							map[string]interface{}{"title": "About " + p.Args["text"].(string)},
+						}, nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"rename": &graphql.Field{
+					Type: userType,
+					Args: graphql.FieldConfigArgument{
+						"id":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+						"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						u := *users[p.Args["id"].(string)]
+						u.Name = p.Args["name"].(string)
+						return &u, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+// recordingExecutor forwards requests to a remote server and keeps them.
+type recordingExecutor struct {
+	executor delegate.RemoteExecutor
+
+	mu       sync.Mutex
+	requests []delegate.Request
+}
+
+func (e *recordingExecutor) Execute(ctx context.Context, request delegate.Request) (*delegate.Response, error) {
+	e.mu.Lock()
+	e.requests = append(e.requests, request)
+	e.mu.Unlock()
+	return e.executor.Execute(ctx, request)
+}
+
+func newRemote(t *testing.T) (graphql.Schema, *recordingExecutor, func()) {
+	remote := remoteSchema(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request delegate.Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result := graphql.Do(graphql.Params{
+			Schema:         remote,
+			RequestString:  request.Query,
+			OperationName:  request.OperationName,
+			VariableValues: request.Variables,
+		})
+		json.NewEncoder(w).Encode(result)
+	}))
+	executor := &recordingExecutor{executor: delegate.NewHTTPExecutor(server.URL, nil)}
+	schema, err := delegate.NewRemoteSchema(context.Background(), executor)
+	if err != nil {
+		server.Close()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	executor.requests = nil
+	return schema, executor, server.Close
+}
+
+// expectSameResult runs the query against the remote schema directly and
+// through delegation, and compares the data and the messages and paths of the
+// errors.
+func expectSameResult(t *testing.T, local graphql.Schema, query string, variables map[string]interface{}) *graphql.Result {
+	direct := graphql.Do(graphql.Params{
+		Schema:         remoteSchema(t),
+		RequestString:  query,
+		VariableValues: variables,
+	})
+	delegated := graphql.Do(graphql.Params{
+		Schema:         local,
+		RequestString:  query,
+		VariableValues: variables,
+	})
+	if direct.Data == nil {
+		t.Fatalf("unexpected errors: %v", direct.Errors)
+	}
+	if !reflect.DeepEqual(direct.Data, delegated.Data) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(direct.Data, delegated.Data))
+	}
+	if len(direct.Errors) != len(delegated.Errors) {
+		t.Fatalf("expected errors %v, got %v", direct.Errors, delegated.Errors)
+	}
+	for i := range direct.Errors {
+		if direct.Errors[i].Message != delegated.Errors[i].Message ||
+			!reflect.DeepEqual(direct.Errors[i].Path, delegated.Errors[i].Path) {
+			t.Fatalf("expected error %v, got %v", direct.Errors[i], delegated.Errors[i])
+		}
+	}
+	return delegated
+}
+
+func TestNewRemoteSchema_DelegatesQueries(t *testing.T) {
+	schema, executor, closeServer := newRemote(t)
+	defer closeServer()
+
+	query := `
+		query Search($id: ID!, $text: String) {
+			ada: user(id: $id) {
+				...userFields
+				friends { handle: name }
+			}
+			search(text: $text) {
+				... on User { name }
+				... on Post { title }
+			}
+		}
+		fragment userFields on User { id name }
+	`
+	expectSameResult(t, schema, query, map[string]interface{}{
+		"id":   "1",
+		"text": "Go",
+	})
+
+	if len(executor.requests) != 2 {
+		t.Fatalf("expected one request per root field, got %v", len(executor.requests))
+	}
+	variables := map[string]map[string]interface{}{}
+	for _, request := range executor.requests {
+		if request.OperationName != "Search" {
+			t.Fatalf("unexpected operation name: %v", request.OperationName)
+		}
+		for name := range request.Variables {
+			variables[name] = request.Variables
+		}
+	}
+	expectedVariables := map[string]map[string]interface{}{
+		"id":   {"id": "1"},
+		"text": {"text": "Go"},
+	}
+	if !reflect.DeepEqual(variables, expectedVariables) {
+		t.Fatalf("expected only the used variables to be forwarded, got %v", variables)
+	}
+}
+
+func TestNewRemoteSchema_RemapsErrors(t *testing.T) {
+	schema, _, closeServer := newRemote(t)
+	defer closeServer()
+
+	result := expectSameResult(t, schema, `{
+		me: user(id: "1") {
+			friends { name email }
+		}
+	}`, nil)
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got %v", result.Errors)
+	}
+	expectedPath := []interface{}{"me", "friends", 0, "email"}
+	if !reflect.DeepEqual(result.Errors[0].Path, expectedPath) {
+		t.Fatalf("expected path %v, got %v", expectedPath, result.Errors[0].Path)
+	}
+}
+
+func TestNewRemoteSchema_DelegatesMutations(t *testing.T) {
+	schema, _, closeServer := newRemote(t)
+	defer closeServer()
+
+	expectSameResult(t, schema, `mutation { rename(id: "3", name: "Linus T.") { id name } }`, nil)
+}
+
+func TestDelegate_MergedWithLocalSchema(t *testing.T) {
+	remote, _, closeServer := newRemote(t)
+	defer closeServer()
+
+	local, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hello": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return "world", nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema, err := graphql.MergeSchemas(graphql.MergeOptions{}, local, remote)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hello user(id: "2") { name } search(text: "Go") { __typename } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hello": "world",
+			"user":  map[string]interface{}{"name": "Grace"},
+			"search": []interface{}{
+				map[string]interface{}{"__typename": "User"},
+				map[string]interface{}{"__typename": "Post"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestHTTPExecutor_ReportsInvalidResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := delegate.NewHTTPExecutor(server.URL, nil).Execute(context.Background(), delegate.Request{Query: "{ a }"})
+	expected := "delegate: remote server responded with status 503 Service Unavailable"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
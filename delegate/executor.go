@@ -0,0 +1,75 @@
+package delegate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/internal/transport"
+)
+
+// Request is a GraphQL request sent to a remote server.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is the result of a request as returned by the remote server.
+type Response struct {
+	Data   map[string]interface{}     `json:"data"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+// RemoteExecutor executes requests against a remote GraphQL server. An error
+// is returned when no response could be obtained, GraphQL errors are part of
+// the response.
+type RemoteExecutor interface {
+	Execute(ctx context.Context, request Request) (*Response, error)
+}
+
+// RemoteExecutorFunc is an adapter to use a function as a RemoteExecutor.
+type RemoteExecutorFunc func(ctx context.Context, request Request) (*Response, error)
+
+// Execute calls f(ctx, request).
+func (f RemoteExecutorFunc) Execute(ctx context.Context, request Request) (*Response, error) {
+	return f(ctx, request)
+}
+
+// HTTPExecutor sends requests to a GraphQL server as JSON POST requests.
+type HTTPExecutor struct {
+	URL        string
+	HTTPClient *http.Client
+
+	// Header is added to every request, e.g. for authorization.
+	Header http.Header
+}
+
+// NewHTTPExecutor returns an executor for the server at url. If httpClient is
+// nil, http.DefaultClient is used.
+func NewHTTPExecutor(url string, httpClient *http.Client) *HTTPExecutor {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPExecutor{
+		URL:        url,
+		HTTPClient: httpClient,
+		Header:     http.Header{},
+	}
+}
+
+// Execute implements RemoteExecutor.
+func (e *HTTPExecutor) Execute(ctx context.Context, request Request) (*Response, error) {
+	response := &Response{}
+	if err := transport.Post(ctx, e.HTTPClient, e.URL, e.Header, request, response); err != nil {
+		switch err := err.(type) {
+		case *transport.StatusError:
+			return nil, fmt.Errorf("delegate: remote server responded with status %v", err.Status)
+		case *transport.DecodeError:
+			return nil, fmt.Errorf("delegate: invalid response: %v", err.Err)
+		}
+		return nil, err
+	}
+	return response, nil
+}
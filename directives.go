@@ -32,6 +32,7 @@ var SpecifiedDirectives = []*Directive{
 	IncludeDirective,
 	SkipDirective,
 	DeprecatedDirective,
+	SpecifiedByDirective,
 }
 
 // Directive structs are used by the GraphQL runtime as a way of modifying execution
@@ -146,3 +147,18 @@ var DeprecatedDirective = NewDirective(DirectiveConfig{
 		DirectiveLocationEnumValue,
 	},
 })
+
+// SpecifiedByDirective Used to provide a URL for specifying the behaviour of custom scalar definitions.
+var SpecifiedByDirective = NewDirective(DirectiveConfig{
+	Name:        "specifiedBy",
+	Description: "Exposes a URL that specifies the behaviour of this scalar.",
+	Args: FieldConfigArgument{
+		"url": &ArgumentConfig{
+			Type:        NewNonNull(String),
+			Description: "The URL that specifies the behaviour of this scalar.",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationScalar,
+	},
+})
@@ -179,7 +179,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptStringLiterals(t *testing.T) {
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Message: "Argument \"fromEnum\" has invalid value \"GREEN\".\nExpected type \"Color\", found \"GREEN\".",
+				Message: "Expected value of type \"Color\", found \"GREEN\".",
 				Locations: []location.SourceLocation{
 					{Line: 1, Column: 23},
 				},
@@ -209,7 +209,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptInternalValueInPlaceOfEnumLiteral(t
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Message: "Argument \"fromEnum\" has invalid value 1.\nExpected type \"Color\", found 1.",
+				Message: "Expected value of type \"Color\", found 1.",
 				Locations: []location.SourceLocation{
 					{Line: 1, Column: 23},
 				},
@@ -228,7 +228,7 @@ func TestTypeSystem_EnumValues_DoesNotAcceptEnumLiteralInPlaceOfInt(t *testing.T
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Message: "Argument \"fromInt\" has invalid value GREEN.\nExpected type \"Int\", found GREEN.",
+				Message: "Expected value of type \"Int\", found GREEN.",
 				Locations: []location.SourceLocation{
 					{Line: 1, Column: 23},
 				},
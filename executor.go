@@ -22,6 +22,11 @@ type ExecuteParams struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// PreserveFieldOrder makes the objects of the result data *OrderedMap
+	// values, keeping the selection order of the fields when marshaled to
+	// JSON, instead of map[string]interface{}.
+	PreserveFieldOrder bool
 }
 
 func Execute(p ExecuteParams) (result *Result) {
@@ -67,6 +72,8 @@ func Execute(p ExecuteParams) (result *Result) {
 			Args:          p.Args,
 			Result:        result,
 			Context:       p.Context,
+
+			PreserveFieldOrder: p.PreserveFieldOrder,
 		})
 
 		if err != nil {
@@ -100,6 +107,8 @@ type buildExecutionCtxParams struct {
 	Args          map[string]interface{}
 	Result        *Result
 	Context       context.Context
+
+	PreserveFieldOrder bool
 }
 
 type executionContext struct {
@@ -110,6 +119,8 @@ type executionContext struct {
 	VariableValues map[string]interface{}
 	Errors         []gqlerrors.FormattedError
 	Context        context.Context
+
+	PreserveFieldOrder bool
 }
 
 func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error) {
@@ -155,6 +166,7 @@ func buildExecutionContext(p buildExecutionCtxParams) (*executionContext, error)
 	eCtx.Operation = operation
 	eCtx.VariableValues = variableValues
 	eCtx.Context = p.Context
+	eCtx.PreserveFieldOrder = p.PreserveFieldOrder
 	return eCtx, nil
 }
 
@@ -255,6 +267,7 @@ func executeFieldsSerially(p executeFieldsParams) *Result {
 	}
 
 	finalResults := make(map[string]interface{}, len(p.Fields))
+	keys := make([]string, 0, len(p.Fields))
 	for _, orderedField := range orderedFields(p.Fields) {
 		responseName := orderedField.responseName
 		fieldASTs := orderedField.fieldASTs
@@ -264,9 +277,16 @@ func executeFieldsSerially(p executeFieldsParams) *Result {
 			continue
 		}
 		finalResults[responseName] = resolved
+		keys = append(keys, responseName)
 	}
 	dethunkMapDepthFirst(finalResults)
 
+	if p.ExecutionContext.PreserveFieldOrder {
+		return &Result{
+			Data:   &OrderedMap{Keys: keys, Values: finalResults},
+			Errors: p.ExecutionContext.Errors,
+		}
+	}
 	return &Result{
 		Data:   finalResults,
 		Errors: p.ExecutionContext.Errors,
@@ -277,7 +297,7 @@ func executeFieldsSerially(p executeFieldsParams) *Result {
 func executeFields(p executeFieldsParams) *Result {
 	finalResults := executeSubFields(p)
 
-	dethunkMapWithBreadthFirstTraversal(finalResults)
+	dethunkValueWithBreadthFirstTraversal(finalResults)
 
 	return &Result{
 		Data:   finalResults,
@@ -285,7 +305,9 @@ func executeFields(p executeFieldsParams) *Result {
 	}
 }
 
-func executeSubFields(p executeFieldsParams) map[string]interface{} {
+// executeSubFields returns the results of the fields, a map[string]interface{}
+// or, with PreserveFieldOrder, an *OrderedMap.
+func executeSubFields(p executeFieldsParams) interface{} {
 
 	if p.Source == nil {
 		p.Source = map[string]interface{}{}
@@ -295,6 +317,21 @@ func executeSubFields(p executeFieldsParams) map[string]interface{} {
 	}
 
 	finalResults := make(map[string]interface{}, len(p.Fields))
+	if p.ExecutionContext.PreserveFieldOrder {
+		keys := make([]string, 0, len(p.Fields))
+		for _, orderedField := range orderedFields(p.Fields) {
+			responseName := orderedField.responseName
+			fieldPath := p.Path.WithKey(responseName)
+			resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, orderedField.fieldASTs, fieldPath)
+			if state.hasNoFieldDefs {
+				continue
+			}
+			finalResults[responseName] = resolved
+			keys = append(keys, responseName)
+		}
+		return &OrderedMap{Keys: keys, Values: finalResults}
+	}
+
 	for responseName, fieldASTs := range p.Fields {
 		fieldPath := p.Path.WithKey(responseName)
 		resolved, state := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldASTs, fieldPath)
@@ -322,13 +359,18 @@ func (d *dethunkQueue) shift() func() {
 	return f
 }
 
-// dethunkWithBreadthFirstTraversal performs a breadth-first descent of the map, calling any thunks
+// dethunkValueWithBreadthFirstTraversal performs a breadth-first descent of the map, calling any thunks
 // in the map values and replacing each thunk with that thunk's return value. This parallels
 // the reference graphql-js implementation, which calls Promise.all on thunks at each depth (which
 // is an implicit parallel descent).
-func dethunkMapWithBreadthFirstTraversal(finalResults map[string]interface{}) {
+func dethunkValueWithBreadthFirstTraversal(finalResults interface{}) {
 	dethunkQueue := &dethunkQueue{DethunkFuncs: []func(){}}
-	dethunkMapBreadthFirst(finalResults, dethunkQueue)
+	switch val := finalResults.(type) {
+	case map[string]interface{}:
+		dethunkMapBreadthFirst(val, dethunkQueue)
+	case *OrderedMap:
+		dethunkMapBreadthFirst(val.Values, dethunkQueue)
+	}
 	for len(dethunkQueue.DethunkFuncs) > 0 {
 		f := dethunkQueue.shift()
 		f()
@@ -343,6 +385,8 @@ func dethunkMapBreadthFirst(m map[string]interface{}, dethunkQueue *dethunkQueue
 		switch val := m[k].(type) {
 		case map[string]interface{}:
 			dethunkQueue.push(func() { dethunkMapBreadthFirst(val, dethunkQueue) })
+		case *OrderedMap:
+			dethunkQueue.push(func() { dethunkMapBreadthFirst(val.Values, dethunkQueue) })
 		case []interface{}:
 			dethunkQueue.push(func() { dethunkListBreadthFirst(val, dethunkQueue) })
 		}
@@ -357,6 +401,8 @@ func dethunkListBreadthFirst(list []interface{}, dethunkQueue *dethunkQueue) {
 		switch val := list[i].(type) {
 		case map[string]interface{}:
 			dethunkQueue.push(func() { dethunkMapBreadthFirst(val, dethunkQueue) })
+		case *OrderedMap:
+			dethunkQueue.push(func() { dethunkMapBreadthFirst(val.Values, dethunkQueue) })
 		case []interface{}:
 			dethunkQueue.push(func() { dethunkListBreadthFirst(val, dethunkQueue) })
 		}
@@ -375,6 +421,8 @@ func dethunkMapDepthFirst(m map[string]interface{}) {
 		switch val := m[k].(type) {
 		case map[string]interface{}:
 			dethunkMapDepthFirst(val)
+		case *OrderedMap:
+			dethunkMapDepthFirst(val.Values)
 		case []interface{}:
 			dethunkListDepthFirst(val)
 		}
@@ -389,6 +437,8 @@ func dethunkListDepthFirst(list []interface{}) {
 		switch val := list[i].(type) {
 		case map[string]interface{}:
 			dethunkMapDepthFirst(val)
+		case *OrderedMap:
+			dethunkMapDepthFirst(val.Values)
 		case []interface{}:
 			dethunkListDepthFirst(val)
 		}
@@ -604,18 +654,30 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 		return result, resultState
 	}()
 
-	fieldAST := fieldASTs[0]
-	fieldName := ""
-	if fieldAST.Name != nil {
-		fieldName = fieldAST.Name.Value
-	}
-
-	fieldDef := getFieldDef(eCtx.Schema, parentType, fieldName)
+	fieldDef := getFieldDef(eCtx.Context, eCtx.Schema, parentType, fieldASTName(fieldASTs[0]))
 	if fieldDef == nil {
 		resultState.hasNoFieldDefs = true
 		return nil, resultState
 	}
 	returnType = fieldDef.Type
+
+	info, result := resolveFieldValue(eCtx, fieldDef, parentType, source, fieldASTs, path)
+
+	completed := completeValueCatchingError(eCtx, returnType, fieldASTs, info, path, result)
+	return completed, resultState
+}
+
+func fieldASTName(fieldAST *ast.Field) string {
+	if fieldAST.Name != nil {
+		return fieldAST.Name.Value
+	}
+	return ""
+}
+
+// resolveFieldValue calls the resolve function of the field, notifying the
+// extensions, and returns the info passed to it with its result. Errors of
+// the resolve function are panicked.
+func resolveFieldValue(eCtx *executionContext, fieldDef *FieldDefinition, parentType *Object, source interface{}, fieldASTs []*ast.Field, path *ResponsePath) (ResolveInfo, interface{}) {
 	resolveFn := fieldDef.Resolve
 	if resolveFn == nil {
 		resolveFn = DefaultResolveFn
@@ -624,13 +686,13 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 	// Build a map of arguments from the field.arguments AST, using the
 	// variables scope to fulfill any variable references.
 	// TODO: find a way to memoize, in case this field is within a List type.
-	args := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	args := getArgumentValues(fieldDef.Args, fieldASTs[0].Arguments, eCtx.VariableValues)
 
 	info := ResolveInfo{
-		FieldName:      fieldName,
+		FieldName:      fieldASTName(fieldASTs[0]),
 		FieldASTs:      fieldASTs,
 		Path:           path,
-		ReturnType:     returnType,
+		ReturnType:     fieldDef.Type,
 		ParentType:     parentType,
 		Schema:         eCtx.Schema,
 		Fragments:      eCtx.Fragments,
@@ -639,14 +701,12 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 		VariableValues: eCtx.VariableValues,
 	}
 
-	var resolveFnError error
-
 	extErrs, resolveFieldFinishFn := handleExtensionsResolveFieldDidStart(eCtx.Schema.extensions, eCtx, &info)
 	if len(extErrs) != 0 {
 		eCtx.Errors = append(eCtx.Errors, extErrs...)
 	}
 
-	result, resolveFnError = resolveFn(ResolveParams{
+	result, resolveFnError := resolveFn(ResolveParams{
 		Source:  source,
 		Args:    args,
 		Info:    info,
@@ -661,9 +721,7 @@ func resolveField(eCtx *executionContext, parentType *Object, source interface{}
 	if resolveFnError != nil {
 		panic(resolveFnError)
 	}
-
-	completed := completeValueCatchingError(eCtx, returnType, fieldASTs, info, path, result)
-	return completed, resultState
+	return info, result
 }
 
 func completeValueCatchingError(eCtx *executionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result interface{}) (completed interface{}) {
@@ -721,7 +779,12 @@ func completeValue(eCtx *executionContext, returnType Type, fieldASTs []*ast.Fie
 	// If field type is a leaf type, Scalar or Enum, serialize to a valid value,
 	// returning null if serialization is not possible.
 	if returnType, ok := returnType.(*Scalar); ok {
-		return completeLeafValue(returnType, result)
+		serialized := completeLeafValue(returnType, result)
+		if err, ok := serialized.(error); ok {
+			err := NewLocatedErrorWithPath(err, FieldASTsToNodeASTs(fieldASTs), path.AsArray())
+			panic(gqlerrors.FormatError(err))
+		}
+		return serialized
 	}
 	if returnType, ok := returnType.(*Enum); ok {
 		return completeLeafValue(returnType, result)
@@ -760,17 +823,7 @@ func completeThunkValueCatchingError(eCtx *executionContext, returnType Type, fi
 		}
 	}()
 
-	propertyFn, ok := result.(func() (interface{}, error))
-	if !ok {
-		err := gqlerrors.NewFormattedError("Error resolving func. Expected `func() (interface{}, error)` signature")
-		panic(gqlerrors.FormatError(err))
-	}
-	fnResult, err := propertyFn()
-	if err != nil {
-		panic(gqlerrors.FormatError(err))
-	}
-
-	result = fnResult
+	result = callThunk(result)
 
 	if returnType, ok := returnType.(*NonNull); ok {
 		completed := completeValue(eCtx, returnType, fieldASTs, info, path, result)
@@ -784,6 +837,26 @@ func completeThunkValueCatchingError(eCtx *executionContext, returnType Type, fi
 // completeAbstractValue completes value of an Abstract type (Union / Interface) by determining the runtime type
 // of that value, then completing based on that type.
 func completeAbstractValue(eCtx *executionContext, returnType Abstract, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result interface{}) interface{} {
+	if typed, ok := result.(TypedValue); ok {
+		if isNullish(typed.Value) {
+			return nil
+		}
+		runtimeType, ok := eCtx.Schema.Type(typed.TypeName).(*Object)
+		if !ok || !eCtx.Schema.IsPossibleType(returnType, runtimeType) {
+			panic(gqlerrors.NewFormattedError(
+				fmt.Sprintf(`Runtime Object type "%v" is not a possible type `+
+					`for "%v".`, typed.TypeName, returnType),
+			))
+		}
+		return completeObjectValue(eCtx, runtimeType, fieldASTs, info, path, typed.Value)
+	}
+	runtimeType := resolveRuntimeType(eCtx, returnType, info, result)
+	return completeObjectValue(eCtx, runtimeType, fieldASTs, info, path, result)
+}
+
+// resolveRuntimeType determines the Object type of the value of an abstract
+// type, panicking if it is not a possible type.
+func resolveRuntimeType(eCtx *executionContext, returnType Abstract, info ResolveInfo, result interface{}) *Object {
 
 	var runtimeType *Object
 
@@ -813,16 +886,26 @@ func completeAbstractValue(eCtx *executionContext, returnType Abstract, fieldAST
 				`for "%v".`, runtimeType, returnType),
 		))
 	}
-
-	return completeObjectValue(eCtx, runtimeType, fieldASTs, info, path, result)
+	return runtimeType
 }
 
 // completeObjectValue complete an Object value by executing all sub-selections.
 func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result interface{}) interface{} {
+	checkIsTypeOf(eCtx, returnType, info, result)
 
-	// If there is an isTypeOf predicate function, call it with the
-	// current result. If isTypeOf returns false, then raise an error rather
-	// than continuing execution.
+	executeFieldsParams := executeFieldsParams{
+		ExecutionContext: eCtx,
+		ParentType:       returnType,
+		Source:           result,
+		Fields:           collectSubFields(eCtx, returnType, fieldASTs),
+		Path:             path,
+	}
+	return executeSubFields(executeFieldsParams)
+}
+
+// checkIsTypeOf panics if returnType has an isTypeOf predicate function
+// returning false for the value.
+func checkIsTypeOf(eCtx *executionContext, returnType *Object, info ResolveInfo, result interface{}) {
 	if returnType.IsTypeOf != nil {
 		p := IsTypeOfParams{
 			Value:   result,
@@ -835,8 +918,11 @@ func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs [
 			))
 		}
 	}
+}
 
-	// Collect sub-fields to execute to complete this value.
+// collectSubFields collects the sub-fields of the fields to execute to
+// complete a value of returnType.
+func collectSubFields(eCtx *executionContext, returnType *Object, fieldASTs []*ast.Field) map[string][]*ast.Field {
 	subFieldASTs := map[string][]*ast.Field{}
 	visitedFragmentNames := map[string]bool{}
 	for _, fieldAST := range fieldASTs {
@@ -855,14 +941,7 @@ func completeObjectValue(eCtx *executionContext, returnType *Object, fieldASTs [
 			subFieldASTs = collectFields(innerParams)
 		}
 	}
-	executeFieldsParams := executeFieldsParams{
-		ExecutionContext: eCtx,
-		ParentType:       returnType,
-		Source:           result,
-		Fields:           subFieldASTs,
-		Path:             path,
-	}
-	return executeSubFields(executeFieldsParams)
+	return subFieldASTs
 }
 
 // completeLeafValue complete a leaf value (Scalar / Enum) by serializing to a valid value, returning nil if serialization is not possible.
@@ -876,6 +955,22 @@ func completeLeafValue(returnType Leaf, result interface{}) interface{} {
 
 // completeListValue complete a list value by completing each item in the list with the inner type
 func completeListValue(eCtx *executionContext, returnType *List, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result interface{}) interface{} {
+	resultVal := listValue(info, result)
+
+	itemType := returnType.OfType
+	completedResults := make([]interface{}, 0, resultVal.Len())
+	for i := 0; i < resultVal.Len(); i++ {
+		val := resultVal.Index(i).Interface()
+		fieldPath := path.WithKey(i)
+		completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, info, fieldPath, val)
+		completedResults = append(completedResults, completedItem)
+	}
+	return completedResults
+}
+
+// listValue returns the iterable value of a list, panicking if result is not
+// iterable.
+func listValue(info ResolveInfo, result interface{}) reflect.Value {
 	resultVal := reflect.ValueOf(result)
 	if resultVal.Kind() == reflect.Ptr {
 		resultVal = resultVal.Elem()
@@ -892,16 +987,22 @@ func completeListValue(eCtx *executionContext, returnType *List, fieldASTs []*as
 	if err != nil {
 		panic(gqlerrors.FormatError(err))
 	}
+	return resultVal
+}
 
-	itemType := returnType.OfType
-	completedResults := make([]interface{}, 0, resultVal.Len())
-	for i := 0; i < resultVal.Len(); i++ {
-		val := resultVal.Index(i).Interface()
-		fieldPath := path.WithKey(i)
-		completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, info, fieldPath, val)
-		completedResults = append(completedResults, completedItem)
+// callThunk returns the value of a thunk returned by a resolve function,
+// panicking with its error.
+func callThunk(result interface{}) interface{} {
+	propertyFn, ok := result.(func() (interface{}, error))
+	if !ok {
+		err := gqlerrors.NewFormattedError("Error resolving func. Expected `func() (interface{}, error)` signature")
+		panic(gqlerrors.FormatError(err))
 	}
-	return completedResults
+	fnResult, err := propertyFn()
+	if err != nil {
+		panic(gqlerrors.FormatError(err))
+	}
+	return fnResult
 }
 
 // defaultResolveTypeFn If a resolveType function is not given, then a default resolve behavior is
@@ -1019,8 +1120,9 @@ func DefaultResolveFn(p ResolveParams) (interface{}, error) {
 // queried as a field, even in situations where no other fields
 // are allowed, like on a Union. __schema could get automatically
 // added to the query type, but that would require mutating type
-// definitions, which would cause issues.
-func getFieldDef(schema Schema, parentType *Object, fieldName string) *FieldDefinition {
+// definitions, which would cause issues. Fields hidden from the request of
+// ctx by the visibility of the schema are not found.
+func getFieldDef(ctx context.Context, schema Schema, parentType *Object, fieldName string) *FieldDefinition {
 
 	if parentType == nil {
 		return nil
@@ -1037,7 +1139,11 @@ func getFieldDef(schema Schema, parentType *Object, fieldName string) *FieldDefi
 	if fieldName == TypeNameMetaFieldDef.Name {
 		return TypeNameMetaFieldDef
 	}
-	return parentType.Fields()[fieldName]
+	fieldDef := parentType.Fields()[fieldName]
+	if !schema.isFieldVisible(ctx, parentType, fieldDef) {
+		return nil
+	}
+	return fieldDef
 }
 
 // contains field information that will be placed in an ordered slice
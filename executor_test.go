@@ -2414,3 +2414,112 @@ func TestQuery_OriginalErrorPanic(t *testing.T) {
 		t.Fatalf("unexpected error: %v", reflect.TypeOf(err))
 	}
 }
+
+func orderedFieldsSchema(t *testing.T) graphql.Schema {
+	var itemType *graphql.Object
+	itemType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"name": &graphql.Field{Type: graphql.String},
+				"id":   &graphql.Field{Type: graphql.ID},
+				"child": &graphql.Field{
+					Type: itemType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return func() (interface{}, error) {
+							return map[string]interface{}{"id": "c", "name": "child"}, nil
+						}, nil
+					},
+				},
+				"broken": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("broken")
+					},
+				},
+			}
+		}),
+	})
+	items := func(p graphql.ResolveParams) (interface{}, error) {
+		return []interface{}{
+			map[string]interface{}{"id": "1", "name": "one"},
+			map[string]interface{}{"id": "2", "name": "two"},
+		}, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{Type: graphql.NewList(itemType), Resolve: items},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"zeta":  &graphql.Field{Type: graphql.NewList(itemType), Resolve: items},
+				"alpha": &graphql.Field{Type: graphql.NewList(itemType), Resolve: items},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestExecute_PreserveFieldOrderKeepsSelectionOrder(t *testing.T) {
+	schema := orderedFieldsSchema(t)
+	query := `{
+		z: items { name id child { name id } }
+		items { id ...F }
+		a: items { child { broken } }
+	}
+	fragment F on Item { name child { id } }`
+
+	result := graphql.Do(graphql.Params{
+		Schema:             schema,
+		RequestString:      query,
+		PreserveFieldOrder: true,
+	})
+	if len(result.Errors) != 2 {
+		t.Fatalf("expected two errors, got %v", result.Errors)
+	}
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"z":[{"name":"one","id":"1","child":{"name":"child","id":"c"}},{"name":"two","id":"2","child":{"name":"child","id":"c"}}],` +
+		`"items":[{"id":"1","name":"one","child":{"id":"c"}},{"id":"2","name":"two","child":{"id":"c"}}],` +
+		`"a":[{"child":null},{"child":null}]}`
+	if string(data) != expected {
+		t.Fatalf("unexpected JSON:\n%s\nexpected:\n%s", data, expected)
+	}
+
+	unordered := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	ordered := result.Data.(*graphql.OrderedMap)
+	if !reflect.DeepEqual(unordered.Data, ordered.ToMap()) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(unordered.Data, ordered.ToMap()))
+	}
+	if items, ok := ordered.Get("items"); !ok || len(items.([]interface{})) != 2 {
+		t.Fatalf("unexpected items: %v", items)
+	}
+}
+
+func TestExecute_PreserveFieldOrderInMutations(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:             orderedFieldsSchema(t),
+		RequestString:      `mutation { zeta { id } alpha { name } }`,
+		PreserveFieldOrder: true,
+	})
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"data":{"zeta":[{"id":"1"},{"id":"2"}],"alpha":[{"name":"one"},{"name":"two"}]}}`
+	if string(data) != expected {
+		t.Fatalf("unexpected JSON:\n%s\nexpected:\n%s", data, expected)
+	}
+}
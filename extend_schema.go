@@ -0,0 +1,304 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// ExtendSchema returns a new schema made of schema and the type definitions,
+// type extensions, directive definitions and schema extensions of doc. The
+// given schema is left untouched: the types it defines are rebuilt with the
+// added fields, interfaces, members and values, keeping their resolvers.
+//
+// The document is validated with ValidateSDL first, its errors being returned
+// as gqlerrors.FormattedErrors. Like with BuildASTSchema,
+// the fields added by doc are resolved with DefaultResolveFn and the abstract
+// types it defines are resolved from the `__typename` key of map sources.
+func ExtendSchema(schema Schema, doc *ast.Document) (Schema, error) {
+	if doc == nil {
+		return Schema{}, gqlerrors.NewFormattedError("Must provide a document.")
+	}
+	if result := ValidateSDL(doc, &schema); !result.IsValid {
+		return Schema{}, gqlerrors.FormattedErrors(result.Errors)
+	}
+
+	e := &schemaExtender{
+		schema: &schema,
+		types:  TypeMap{},
+	}
+	e.builder = newASTTypeBuilder(e.namedType)
+	operationTypeDefs := []*ast.OperationTypeDefinition{}
+	directiveDefs := []*ast.DirectiveDefinition{}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.SchemaExtensionDefinition:
+			if def.Definition != nil {
+				operationTypeDefs = append(operationTypeDefs, def.Definition.OperationTypes...)
+			}
+		case *ast.DirectiveDefinition:
+			directiveDefs = append(directiveDefs, def)
+		case *ast.ScalarExtensionDefinition, *ast.TypeExtensionDefinition, *ast.InterfaceExtensionDefinition,
+			*ast.UnionExtensionDefinition, *ast.EnumExtensionDefinition, *ast.InputObjectExtensionDefinition:
+			e.builder.addExtension(def)
+		case ast.TypeDefinition:
+			e.builder.defs[typeDefinitionName(def)] = def
+		default:
+			return Schema{}, gqlerrors.NewError(
+				fmt.Sprintf("Cannot extend a schema with a document containing a %v.", def.GetKind()),
+				[]ast.Node{def}, "", nil, []int{}, nil,
+			)
+		}
+	}
+	if len(e.builder.defs) == 0 && len(e.builder.extensions) == 0 &&
+		len(directiveDefs) == 0 && len(operationTypeDefs) == 0 {
+		return schema, nil
+	}
+
+	config := SchemaConfig{
+		Extensions: schema.Extensions(),
+		Visibility: schema.Visibility(),
+	}
+	if query := schema.QueryType(); query != nil {
+		config.Query, _ = e.namedType(query.Name()).(*Object)
+	}
+	if mutation := schema.MutationType(); mutation != nil {
+		config.Mutation, _ = e.namedType(mutation.Name()).(*Object)
+	}
+	if subscription := schema.SubscriptionType(); subscription != nil {
+		config.Subscription, _ = e.namedType(subscription.Name()).(*Object)
+	}
+	for _, opType := range operationTypeDefs {
+		if opType.Type == nil || opType.Type.Name == nil {
+			continue
+		}
+		name := opType.Type.Name.Value
+		object, ok := e.builder.namedType(name).(*Object)
+		if !ok {
+			return Schema{}, gqlerrors.NewFormattedError(fmt.Sprintf(`Root %v type "%v" must be an Object type.`, opType.Operation, name))
+		}
+		switch opType.Operation {
+		case ast.OperationTypeQuery:
+			config.Query = object
+		case ast.OperationTypeMutation:
+			config.Mutation = object
+		case ast.OperationTypeSubscription:
+			config.Subscription = object
+		}
+	}
+
+	names := []string{}
+	for name := range schema.TypeMap() {
+		if !strings.HasPrefix(name, "__") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		config.Types = append(config.Types, e.namedType(name))
+	}
+	for _, name := range e.builder.sortedDefNames() {
+		config.Types = append(config.Types, e.builder.namedType(name))
+	}
+	for _, directive := range schema.Directives() {
+		config.Directives = append(config.Directives, e.directive(directive))
+	}
+	for _, def := range directiveDefs {
+		config.Directives = append(config.Directives, e.builder.buildDirective(def))
+	}
+	if e.builder.err != nil {
+		return Schema{}, e.builder.err
+	}
+
+	extended, err := NewSchema(config)
+	if err != nil {
+		return extended, err
+	}
+	// thunks are evaluated while the type map is built
+	if e.builder.err != nil {
+		return Schema{}, e.builder.err
+	}
+	return extended, nil
+}
+
+// schemaExtender maps the types of the extended schema to their counterparts
+// in the new schema. Types are rebuilt lazily, as they are referred to: every
+// object, interface, union and input object is copied, since its fields or
+// members may refer to extended types, while scalars and enums are only copied
+// when they are extended.
+type schemaExtender struct {
+	schema  *Schema
+	builder *astTypeBuilder
+	types   TypeMap
+}
+
+func (e *schemaExtender) namedType(name string) Type {
+	if ttype, ok := e.types[name]; ok {
+		return ttype
+	}
+	existing := e.schema.Type(name)
+	if existing == nil {
+		return nil
+	}
+	ttype := e.extendType(existing)
+	e.types[name] = ttype
+	return ttype
+}
+
+// typeRef returns the type standing for ttype of the extended schema.
+func (e *schemaExtender) typeRef(ttype Type) Type {
+	switch ttype := ttype.(type) {
+	case *List:
+		return NewList(e.typeRef(ttype.OfType))
+	case *NonNull:
+		return NewNonNull(e.typeRef(ttype.OfType))
+	}
+	if extended := e.namedType(ttype.Name()); extended != nil {
+		return extended
+	}
+	return ttype
+}
+
+func (e *schemaExtender) extendType(ttype Type) Type {
+	name := ttype.Name()
+	if strings.HasPrefix(name, "__") || specifiedScalarTypes[name] == ttype {
+		return ttype
+	}
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		url := specifiedByURL(e.builder.extensionDirectives(name))
+		if url == "" {
+			return ttype
+		}
+		config := ttype.scalarConfig
+		config.SpecifiedByURL = url
+		return NewScalar(config)
+	case *Enum:
+		valueDefs := e.builder.extensionValues(name)
+		if len(valueDefs) == 0 {
+			return ttype
+		}
+		config := ttype.enumConfig
+		config.Values = EnumValueConfigMap{}
+		for valueName, value := range ttype.enumConfig.Values {
+			config.Values[valueName] = value
+		}
+		e.builder.buildEnumValues(config.Values, valueDefs)
+		return NewEnum(config)
+	case *Object:
+		return NewObject(ObjectConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			IsTypeOf:    ttype.IsTypeOf,
+			Interfaces: InterfacesThunk(func() []*Interface {
+				interfaces := []*Interface{}
+				for _, iface := range ttype.Interfaces() {
+					interfaces = append(interfaces, e.namedType(iface.Name()).(*Interface))
+				}
+				return append(interfaces, e.builder.buildInterfaces(name, e.builder.extensionInterfaces(name))...)
+			}),
+			Fields: FieldsThunk(func() Fields {
+				return e.fields(ttype.Fields(), e.builder.extensionFields(name))
+			}),
+		})
+	case *Interface:
+		return NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			ResolveType: e.resolveType(ttype.ResolveType),
+			Fields: FieldsThunk(func() Fields {
+				return e.fields(ttype.Fields(), e.builder.extensionFields(name))
+			}),
+		})
+	case *Union:
+		return NewUnion(UnionConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			ResolveType: e.resolveType(ttype.ResolveType),
+			Types: UnionTypesThunk(func() []*Object {
+				objects := []*Object{}
+				for _, object := range ttype.Types() {
+					objects = append(objects, e.namedType(object.Name()).(*Object))
+				}
+				return append(objects, e.builder.buildMembers(name, e.builder.extensionMembers(name))...)
+			}),
+		})
+	case *InputObject:
+		return NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for fieldName, field := range ttype.Fields() {
+					fields[fieldName] = &InputObjectFieldConfig{
+						Type:         e.typeRef(field.Type).(Input),
+						DefaultValue: field.DefaultValue,
+						Description:  field.Description(),
+					}
+				}
+				e.builder.buildInputFields(fields, e.builder.extensionInputFields(name))
+				return fields
+			}),
+		})
+	}
+	return ttype
+}
+
+// fields copies the fields of an existing type, pointing them to the types of
+// the new schema, and adds the fields of its extensions.
+func (e *schemaExtender) fields(fieldMap FieldDefinitionMap, fieldDefs []*ast.FieldDefinition) Fields {
+	fields := Fields{}
+	for name, def := range fieldMap {
+		field := def.ToFieldConfig()
+		field.Type = e.typeRef(def.Type).(Output)
+		for _, arg := range field.Args {
+			arg.Type = e.typeRef(arg.Type).(Input)
+		}
+		fields[name] = field
+	}
+	for name, field := range e.builder.buildFields(fieldDefs) {
+		fields[name] = field
+	}
+	return fields
+}
+
+// resolveType maps the object returned by the resolver of an existing abstract
+// type to the object of the same name in the new schema.
+func (e *schemaExtender) resolveType(fn ResolveTypeFn) ResolveTypeFn {
+	if fn == nil {
+		return nil
+	}
+	return func(p ResolveTypeParams) *Object {
+		object := fn(p)
+		if object == nil {
+			return nil
+		}
+		if extended, ok := e.namedType(object.Name()).(*Object); ok {
+			return extended
+		}
+		return object
+	}
+}
+
+func (e *schemaExtender) directive(directive *Directive) *Directive {
+	if isSpecifiedDirective(directive) {
+		return directive
+	}
+	args := FieldConfigArgument{}
+	for _, arg := range directive.Args {
+		args[arg.Name()] = &ArgumentConfig{
+			Type:         e.typeRef(arg.Type).(Input),
+			DefaultValue: arg.DefaultValue,
+			Description:  arg.Description(),
+		}
+	}
+	return NewDirective(DirectiveConfig{
+		Name:        directive.Name,
+		Description: directive.Description,
+		Locations:   directive.Locations,
+		Args:        args,
+	})
+}
@@ -0,0 +1,206 @@
+package graphql_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func extendSchemaTestSchema(t *testing.T) graphql.Schema {
+	petType := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Pet",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+	dogType := graphql.NewObject(graphql.ObjectConfig{
+		Name:       "Dog",
+		Interfaces: []*graphql.Interface{petType},
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return strings.ToUpper(p.Source.(map[string]interface{})["name"].(string)), nil
+				},
+			},
+		},
+	})
+	petType.ResolveType = func(p graphql.ResolveTypeParams) *graphql.Object {
+		return dogType
+	}
+	colorType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED": &graphql.EnumValueConfig{Value: 0},
+		},
+	})
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Filter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"color": &graphql.InputObjectFieldConfig{Type: colorType},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"pet": &graphql.Field{
+					Type: petType,
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{Type: filterType},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"name": "odie", "nickname": "Od"}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{dogType},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestExtendSchema_AddsToTypesWithoutMutatingTheSchema(t *testing.T) {
+	schema := extendSchemaTestSchema(t)
+	extended, err := graphql.ExtendSchema(schema, testutil.TestParse(t, `
+		extend type Query {
+			version: String
+		}
+		extend interface Pet {
+			nickname: String
+		}
+		extend type Dog {
+			nickname: String
+		}
+		extend enum Color {
+			BLUE
+		}
+		extend input Filter {
+			limit: Int
+		}
+		type Cat implements Pet {
+			name: String
+			nickname: String
+		}
+		union Animal = Dog
+		extend union Animal = Cat
+		directive @tag(name: String) on FIELD_DEFINITION
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := extended.QueryType().Fields()["version"]; !ok {
+		t.Fatalf("expected Query.version in the extended schema")
+	}
+	if _, ok := schema.QueryType().Fields()["version"]; ok {
+		t.Fatalf("expected the original schema to be left untouched")
+	}
+	if _, ok := extended.Type("Pet").(*graphql.Interface).Fields()["nickname"]; !ok {
+		t.Fatalf("expected Pet.nickname in the extended schema")
+	}
+	if len(extended.Type("Color").(*graphql.Enum).Values()) != 2 || len(schema.Type("Color").(*graphql.Enum).Values()) != 1 {
+		t.Fatalf("expected BLUE to be added to Color of the extended schema only")
+	}
+	if _, ok := extended.Type("Filter").(*graphql.InputObject).Fields()["limit"]; !ok {
+		t.Fatalf("expected Filter.limit in the extended schema")
+	}
+	if len(extended.Type("Animal").(*graphql.Union).Types()) != 2 {
+		t.Fatalf("expected Animal to contain Dog and Cat")
+	}
+	if !extended.IsPossibleType(extended.Type("Pet").(*graphql.Interface), extended.Type("Cat").(*graphql.Object)) {
+		t.Fatalf("expected Cat to implement Pet")
+	}
+	if extended.Directive("tag") == nil || extended.Directive("skip") == nil {
+		t.Fatalf("expected custom and specified directives")
+	}
+	if schema.Type("Cat") != nil || schema.Directive("tag") != nil {
+		t.Fatalf("expected the original schema to be left untouched")
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        extended,
+		RequestString: `{ pet(filter: {color: BLUE, limit: 1}) { name ... on Dog { nickname } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"pet": map[string]interface{}{
+				"name":     "ODIE",
+				"nickname": "Od",
+			},
+		},
+	}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestExtendSchema_AddsRootOperationTypes(t *testing.T) {
+	schema := extendSchemaTestSchema(t)
+	extended, err := graphql.ExtendSchema(schema, testutil.TestParse(t, `
+		type Mutation {
+			ping: String
+		}
+		extend schema {
+			mutation: Mutation
+		}
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if extended.MutationType() == nil || extended.MutationType().Name() != "Mutation" {
+		t.Fatalf("expected Mutation root type")
+	}
+	if schema.MutationType() != nil {
+		t.Fatalf("expected the original schema to be left untouched")
+	}
+}
+
+func TestExtendSchema_ReturnsTheSchemaForEmptyDocuments(t *testing.T) {
+	schema := extendSchemaTestSchema(t)
+	extended, err := graphql.ExtendSchema(schema, ast.NewDocument(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if extended.QueryType() != schema.QueryType() {
+		t.Fatalf("expected the schema to be returned as is")
+	}
+}
+
+func TestExtendSchema_RejectsInvalidExtensions(t *testing.T) {
+	tests := map[string]string{
+		"unknown type":     `extend type Unknown { a: String }`,
+		"wrong kind":       `extend enum Dog { A }`,
+		"existing field":   `extend type Dog { name: String }`,
+		"existing type":    `type Dog { a: String }`,
+		"existing root":    `extend schema { query: Dog }`,
+		"schema":           `schema { query: Dog }`,
+		"unknown ref type": `extend type Dog { a: Unknown }`,
+	}
+	for name, sdl := range tests {
+		if _, err := graphql.ExtendSchema(extendSchemaTestSchema(t), testutil.TestParse(t, sdl)); err == nil {
+			t.Fatalf("%v: expected an error", name)
+		}
+	}
+}
+
+func TestExtendSchema_ReturnsEveryValidationError(t *testing.T) {
+	_, err := graphql.ExtendSchema(extendSchemaTestSchema(t), testutil.TestParse(t, `
+		extend type Unknown { a: String }
+		extend enum Dog { A }
+	`))
+	errs, ok := err.(gqlerrors.FormattedErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("expected two validation errors, got: %#v", err)
+	}
+	if err.Error() != errs[0].Message+"\n"+errs[1].Message {
+		t.Fatalf("unexpected error message: %v", err.Error())
+	}
+}
@@ -0,0 +1,146 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/graphql-go/graphql"
+)
+
+// entityResolver resolves the `_entities` field. Representations are grouped
+// by type and every item of the result is a thunk, so the references of a type
+// are only fetched, in a single batch, once the executor completes the list.
+type entityResolver struct {
+	types map[string]TypeConfig
+}
+
+func newEntityResolver(types map[string]TypeConfig) *entityResolver {
+	return &entityResolver{types: types}
+}
+
+// entityBatch holds the representations of one type requested by an
+// `_entities` field.
+type entityBatch struct {
+	typename        string
+	config          TypeConfig
+	representations []map[string]interface{}
+
+	once    sync.Once
+	results []interface{}
+	err     error
+}
+
+func (b *entityBatch) load(ctx context.Context) {
+	b.once.Do(func() {
+		if b.config.ResolveReferences != nil {
+			b.results, b.err = b.config.ResolveReferences(ctx, b.representations)
+			if b.err == nil && len(b.results) != len(b.representations) {
+				b.err = fmt.Errorf(`federation: reference resolver of "%v" returned %v results for %v representations`,
+					b.typename, len(b.results), len(b.representations))
+			}
+			return
+		}
+		b.results = make([]interface{}, len(b.representations))
+		for i, representation := range b.representations {
+			result, err := b.config.ResolveReference(ctx, representation)
+			if err != nil {
+				result = err
+			}
+			b.results[i] = result
+		}
+	})
+}
+
+func (r *entityResolver) resolve(p graphql.ResolveParams) (interface{}, error) {
+	representations, _ := p.Args["representations"].([]interface{})
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	batches := map[string]*entityBatch{}
+	results := make([]interface{}, len(representations))
+	for i, value := range representations {
+		representation, ok := value.(map[string]interface{})
+		if !ok {
+			results[i] = failedEntity(fmt.Errorf("federation: representation must be an object, got %v", value))
+			continue
+		}
+		typename, _ := representation["__typename"].(string)
+		config, ok := r.types[typename]
+		if !ok {
+			results[i] = failedEntity(fmt.Errorf(`federation: unknown entity type "%v"`, typename))
+			continue
+		}
+		batch, ok := batches[typename]
+		if !ok {
+			batch = &entityBatch{typename: typename, config: config}
+			batches[typename] = batch
+		}
+		index := len(batch.representations)
+		batch.representations = append(batch.representations, representation)
+		results[i] = func() (interface{}, error) {
+			batch.load(ctx)
+			if batch.err != nil {
+				return nil, batch.err
+			}
+			result := batch.results[index]
+			if err, ok := result.(error); ok {
+				return nil, err
+			}
+			return tag(result, typename), nil
+		}
+	}
+	return results, nil
+}
+
+func failedEntity(err error) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return nil, err
+	}
+}
+
+// tag returns an entity along with its type: maps get a copy with
+// `__typename` set for resolveType, other values are wrapped in a
+// graphql.TypedValue.
+func tag(value interface{}, typename string) interface{} {
+	if value == nil {
+		return nil
+	}
+	if m, ok := value.(map[string]interface{}); ok {
+		tagged := make(map[string]interface{}, len(m)+1)
+		for k, v := range m {
+			tagged[k] = v
+		}
+		tagged["__typename"] = typename
+		return tagged
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+	return graphql.TypedValue{TypeName: typename, Value: value}
+}
+
+func (r *entityResolver) resolveType(p graphql.ResolveTypeParams) *graphql.Object {
+	typename := ""
+	if m, ok := p.Value.(map[string]interface{}); ok {
+		typename, _ = m["__typename"].(string)
+	}
+	if object, ok := p.Info.Schema.Type(typename).(*graphql.Object); ok {
+		return object
+	}
+	// fall back to IsTypeOf for values that could not be tagged
+	for name := range r.types {
+		object, ok := p.Info.Schema.Type(name).(*graphql.Object)
+		if ok && object.IsTypeOf != nil && object.IsTypeOf(graphql.IsTypeOfParams{
+			Value:   p.Value,
+			Info:    p.Info,
+			Context: p.Context,
+		}) {
+			return object
+		}
+	}
+	return nil
+}
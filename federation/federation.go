@@ -0,0 +1,282 @@
+// Package federation turns a schema into an Apollo Federation subgraph.
+//
+// NewSchema adds the `_service` and `_entities` root fields, the `_Any`,
+// `FieldSet`, `_Service` and `_Entity` types and the federation directives to
+// an existing schema. Entities and field level directives are declared in
+// Config since schemas built in Go carry no directives.
+package federation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// ReferenceResolver resolves an entity from its representation, an object
+// holding `__typename` and the fields of one of its keys.
+type ReferenceResolver func(ctx context.Context, representation map[string]interface{}) (interface{}, error)
+
+// BatchReferenceResolver resolves all the representations of an entity type
+// requested by an `_entities` query at once. It must return one result per
+// representation, in order; a result that is an error fails only that entity.
+type BatchReferenceResolver func(ctx context.Context, representations []map[string]interface{}) ([]interface{}, error)
+
+// TypeConfig declares the federation directives of an object type.
+type TypeConfig struct {
+	// Keys are the field sets identifying the entity, one @key directive is
+	// added for each. A type with keys is an entity.
+	Keys []string
+
+	// Shareable adds the @shareable directive.
+	Shareable bool
+
+	// ResolveReference resolves a single entity. It is used when
+	// ResolveReferences is not set.
+	ResolveReference ReferenceResolver
+
+	// ResolveReferences resolves the entities of this type in one batch.
+	ResolveReferences BatchReferenceResolver
+}
+
+// FieldConfig declares the federation directives of a field.
+type FieldConfig struct {
+	External  bool
+	Requires  string
+	Provides  string
+	Shareable bool
+}
+
+// Config configures NewSchema.
+type Config struct {
+	Schema graphql.Schema
+
+	// Types by object type name.
+	Types map[string]TypeConfig
+
+	// Fields by coordinate, e.g. "Product.price".
+	Fields map[string]FieldConfig
+}
+
+// AnyScalar is the `_Any` scalar used for entity representations.
+var AnyScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         "_Any",
+	Serialize:    func(value interface{}) interface{} { return value },
+	ParseValue:   func(value interface{}) interface{} { return value },
+	ParseLiteral: func(valueAST ast.Value) interface{} { return parseAnyLiteral(valueAST) },
+})
+
+// FieldSetScalar is the `FieldSet` scalar used by directive arguments.
+var FieldSetScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:       "FieldSet",
+	Serialize:  graphql.String.Serialize,
+	ParseValue: graphql.String.ParseValue,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return valueAST.Value
+		}
+		return nil
+	},
+})
+
+// ServiceType is the `_Service` type returned by the `_service` field.
+var ServiceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "_Service",
+	Fields: graphql.Fields{
+		"sdl": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+	},
+})
+
+func fieldSetArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"fields": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(FieldSetScalar),
+		},
+	}
+}
+
+// KeyDirective is the @key directive.
+var KeyDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "key",
+	Locations: []string{graphql.DirectiveLocationObject, graphql.DirectiveLocationInterface},
+	Args: graphql.FieldConfigArgument{
+		"fields": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(FieldSetScalar),
+		},
+		"resolvable": &graphql.ArgumentConfig{
+			Type:         graphql.Boolean,
+			DefaultValue: true,
+		},
+	},
+})
+
+// ExternalDirective is the @external directive.
+var ExternalDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "external",
+	Locations: []string{graphql.DirectiveLocationFieldDefinition, graphql.DirectiveLocationObject},
+})
+
+// RequiresDirective is the @requires directive.
+var RequiresDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "requires",
+	Locations: []string{graphql.DirectiveLocationFieldDefinition},
+	Args:      fieldSetArgs(),
+})
+
+// ProvidesDirective is the @provides directive.
+var ProvidesDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "provides",
+	Locations: []string{graphql.DirectiveLocationFieldDefinition},
+	Args:      fieldSetArgs(),
+})
+
+// ShareableDirective is the @shareable directive.
+var ShareableDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "shareable",
+	Locations: []string{graphql.DirectiveLocationObject, graphql.DirectiveLocationFieldDefinition},
+})
+
+// Directives are the federation directives added to the schema.
+var Directives = []*graphql.Directive{
+	KeyDirective,
+	ExternalDirective,
+	RequiresDirective,
+	ProvidesDirective,
+	ShareableDirective,
+}
+
+// NewSchema returns a copy of config.Schema extended with the federation
+// types, fields and directives. The original schema is left untouched.
+func NewSchema(config Config) (graphql.Schema, error) {
+	schema := config.Schema
+	query := schema.QueryType()
+	if query == nil {
+		return graphql.Schema{}, fmt.Errorf("federation: schema must have a query type")
+	}
+
+	entities := []*graphql.Object{}
+	references := map[string]TypeConfig{}
+	for name, typeConfig := range config.Types {
+		object, ok := schema.Type(name).(*graphql.Object)
+		if !ok {
+			return graphql.Schema{}, fmt.Errorf(`federation: "%v" must be an object type of the schema`, name)
+		}
+		if len(typeConfig.Keys) == 0 {
+			continue
+		}
+		if typeConfig.ResolveReference == nil && typeConfig.ResolveReferences == nil {
+			return graphql.Schema{}, fmt.Errorf(`federation: entity "%v" must provide a reference resolver`, name)
+		}
+		entities = append(entities, object)
+		references[name] = typeConfig
+	}
+	for coordinate := range config.Fields {
+		parts := strings.SplitN(coordinate, ".", 2)
+		if len(parts) != 2 {
+			return graphql.Schema{}, fmt.Errorf(`federation: invalid field coordinate "%v"`, coordinate)
+		}
+		object, ok := schema.Type(parts[0]).(*graphql.Object)
+		if !ok {
+			return graphql.Schema{}, fmt.Errorf(`federation: "%v" must be an object type of the schema`, parts[0])
+		}
+		if _, ok := object.Fields()[parts[1]]; !ok {
+			return graphql.Schema{}, fmt.Errorf(`federation: unknown field "%v"`, coordinate)
+		}
+	}
+	sort.Slice(entities, func(i, j int) bool { return entities[i].Name() < entities[j].Name() })
+
+	sdl := printSubgraphSchema(config)
+
+	queryFields := graphql.Fields{}
+	for name, def := range query.Fields() {
+		queryFields[name] = def.ToFieldConfig()
+	}
+	queryFields["_service"] = &graphql.Field{
+		Type: graphql.NewNonNull(ServiceType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return map[string]interface{}{"sdl": sdl}, nil
+		},
+	}
+	types := []graphql.Type{AnyScalar, FieldSetScalar}
+	if len(entities) > 0 {
+		resolver := newEntityResolver(references)
+		entityUnion := graphql.NewUnion(graphql.UnionConfig{
+			Name:        "_Entity",
+			Types:       entities,
+			ResolveType: resolver.resolveType,
+		})
+		queryFields["_entities"] = &graphql.Field{
+			Type: graphql.NewNonNull(graphql.NewList(entityUnion)),
+			Args: graphql.FieldConfigArgument{
+				"representations": &graphql.ArgumentConfig{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(AnyScalar))),
+				},
+			},
+			Resolve: resolver.resolve,
+		}
+		types = append(types, entityUnion)
+	}
+	federatedQuery := graphql.NewObject(graphql.ObjectConfig{
+		Name:        query.Name(),
+		Description: query.Description(),
+		Interfaces:  query.Interfaces(),
+		IsTypeOf:    query.IsTypeOf,
+		Fields:      queryFields,
+	})
+
+	for name, ttype := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") || ttype == query {
+			continue
+		}
+		types = append(types, ttype)
+	}
+	directives := append([]*graphql.Directive{}, schema.Directives()...)
+	directives = append(directives, Directives...)
+
+	federated, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:        federatedQuery,
+		Mutation:     schema.MutationType(),
+		Subscription: schema.SubscriptionType(),
+		Types:        types,
+		Directives:   directives,
+		Visibility:   schema.Visibility(),
+	})
+	if err != nil {
+		return federated, err
+	}
+	federated.AddExtensions(schema.Extensions()...)
+	return federated, nil
+}
+
+func parseAnyLiteral(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		obj := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			obj[field.Name.Value] = parseAnyLiteral(field.Value)
+		}
+		return obj
+	case *ast.ListValue:
+		list := []interface{}{}
+		for _, value := range valueAST.Values {
+			list = append(list, parseAnyLiteral(value))
+		}
+		return list
+	case *ast.IntValue:
+		return graphql.Int.ParseLiteral(valueAST)
+	case *ast.FloatValue:
+		return graphql.Float.ParseLiteral(valueAST)
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	}
+	return nil
+}
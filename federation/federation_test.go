@@ -0,0 +1,363 @@
+package federation_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/federation"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type product struct {
+	UPC   string
+	Name  string
+	Price int
+}
+
+var products = map[string]*product{
+	"1": {UPC: "1", Name: "Table", Price: 899},
+	"2": {UPC: "2", Name: "Couch", Price: 1299},
+}
+
+var productType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Product",
+	Fields: graphql.Fields{
+		"upc": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.String),
+		},
+		"name": &graphql.Field{
+			Type: graphql.String,
+		},
+		"price": &graphql.Field{
+			Type: graphql.Int,
+		},
+	},
+})
+
+var reviewType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Review",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+		},
+		"body": &graphql.Field{
+			Type: graphql.String,
+		},
+	},
+})
+
+func productsSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"topProducts": &graphql.Field{
+					Type: graphql.NewList(productType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []*product{products["1"], products["2"]}, nil
+					},
+				},
+			},
+		}),
+		Types: []graphql.Type{reviewType},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func resolveProduct(ctx context.Context, representation map[string]interface{}) (interface{}, error) {
+	upc, _ := representation["upc"].(string)
+	if p, ok := products[upc]; ok {
+		return p, nil
+	}
+	return nil, errors.New("product not found")
+}
+
+func federatedSchema(t *testing.T, reviewResolver federation.BatchReferenceResolver) graphql.Schema {
+	schema, err := federation.NewSchema(federation.Config{
+		Schema: productsSchema(t),
+		Types: map[string]federation.TypeConfig{
+			"Product": {
+				Keys:             []string{"upc"},
+				ResolveReference: resolveProduct,
+			},
+			"Review": {
+				Keys:              []string{"id"},
+				ResolveReferences: reviewResolver,
+			},
+		},
+		Fields: map[string]federation.FieldConfig{
+			"Product.price": {Shareable: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func reviewsByID(ctx context.Context, representations []map[string]interface{}) ([]interface{}, error) {
+	results := []interface{}{}
+	for _, representation := range representations {
+		results = append(results, map[string]interface{}{
+			"id":   representation["id"],
+			"body": "review " + representation["id"].(string),
+		})
+	}
+	return results, nil
+}
+
+func TestNewSchema_ServiceSDL(t *testing.T) {
+	schema := federatedSchema(t, reviewsByID)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ _service { sdl } }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	sdl := result.Data.(map[string]interface{})["_service"].(map[string]interface{})["sdl"].(string)
+	expected := `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@external", "@requires", "@provides", "@shareable"])
+
+type Product @key(fields: "upc") {
+  name: String
+  price: Int @shareable
+  upc: String!
+}
+
+type Query {
+  topProducts: [Product]
+}
+
+type Review @key(fields: "id") {
+  body: String
+  id: ID!
+}
+`
+	if sdl != expected {
+		t.Fatalf("unexpected sdl, got:\n%v\nwant:\n%v", sdl, expected)
+	}
+}
+
+func TestNewSchema_KeepsOriginalFields(t *testing.T) {
+	schema := federatedSchema(t, reviewsByID)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ topProducts { upc name } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"topProducts": []interface{}{
+				map[string]interface{}{"upc": "1", "name": "Table"},
+				map[string]interface{}{"upc": "2", "name": "Couch"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestNewSchema_ResolvesEntities(t *testing.T) {
+	calls := 0
+	schema := federatedSchema(t, func(ctx context.Context, representations []map[string]interface{}) ([]interface{}, error) {
+		calls++
+		return reviewsByID(ctx, representations)
+	})
+	query := `
+		query ($representations: [_Any!]!) {
+			_entities(representations: $representations) {
+				__typename
+				... on Product { upc name price }
+				... on Review { id body }
+			}
+		}
+	`
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		VariableValues: map[string]interface{}{
+			"representations": []interface{}{
+				map[string]interface{}{"__typename": "Review", "id": "a"},
+				map[string]interface{}{"__typename": "Product", "upc": "2"},
+				map[string]interface{}{"__typename": "Review", "id": "b"},
+			},
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"_entities": []interface{}{
+				map[string]interface{}{"__typename": "Review", "id": "a", "body": "review a"},
+				map[string]interface{}{"__typename": "Product", "upc": "2", "name": "Couch", "price": 1299},
+				map[string]interface{}{"__typename": "Review", "id": "b", "body": "review b"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if calls != 1 {
+		t.Fatalf("expected reviews to be resolved in one batch, got %v calls", calls)
+	}
+}
+
+func TestNewSchema_ReportsEntityErrors(t *testing.T) {
+	schema := federatedSchema(t, reviewsByID)
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			_entities(representations: [
+				{__typename: "Product", upc: "1"},
+				{__typename: "Product", upc: "404"},
+				{__typename: "User", id: "1"}
+			]) {
+				... on Product { name }
+			}
+		}`,
+	})
+	entities := result.Data.(map[string]interface{})["_entities"]
+	expectedEntities := []interface{}{
+		map[string]interface{}{"name": "Table"},
+		nil,
+		nil,
+	}
+	if !reflect.DeepEqual(expectedEntities, entities) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedEntities, entities))
+	}
+	if len(result.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got %v", result.Errors)
+	}
+	if result.Errors[0].Message != "product not found" {
+		t.Fatalf("unexpected error: %v", result.Errors[0].Message)
+	}
+	if !reflect.DeepEqual(result.Errors[0].Path, []interface{}{"_entities", 1}) {
+		t.Fatalf("unexpected error path: %v", result.Errors[0].Path)
+	}
+	if !strings.Contains(result.Errors[1].Message, `unknown entity type "User"`) {
+		t.Fatalf("unexpected error: %v", result.Errors[1].Message)
+	}
+}
+
+func TestNewSchema_ValidatesConfig(t *testing.T) {
+	tests := []struct {
+		config   federation.Config
+		expected string
+	}{
+		{
+			config: federation.Config{
+				Types: map[string]federation.TypeConfig{
+					"Missing": {Keys: []string{"id"}, ResolveReference: resolveProduct},
+				},
+			},
+			expected: `federation: "Missing" must be an object type of the schema`,
+		},
+		{
+			config: federation.Config{
+				Types: map[string]federation.TypeConfig{
+					"Product": {Keys: []string{"upc"}},
+				},
+			},
+			expected: `federation: entity "Product" must provide a reference resolver`,
+		},
+		{
+			config: federation.Config{
+				Fields: map[string]federation.FieldConfig{
+					"Product.weight": {External: true},
+				},
+			},
+			expected: `federation: unknown field "Product.weight"`,
+		},
+	}
+	for _, test := range tests {
+		test.config.Schema = productsSchema(t)
+		_, err := federation.NewSchema(test.config)
+		if err == nil || err.Error() != test.expected {
+			t.Fatalf("expected error %q, got %v", test.expected, err)
+		}
+	}
+}
+
+// sharedEntity is the value of both Left and Right entities, holding a map in
+// an interface field.
+type sharedEntity struct {
+	ID    string
+	Extra interface{}
+}
+
+func TestNewSchema_ResolvesTheTypeOfEqualEntitiesPerRequest(t *testing.T) {
+	fields := graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.NewNonNull(graphql.ID),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(sharedEntity).ID, nil
+			},
+		},
+	}
+	left := graphql.NewObject(graphql.ObjectConfig{Name: "Left", Fields: fields})
+	right := graphql.NewObject(graphql.ObjectConfig{Name: "Right", Fields: fields})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"left": &graphql.Field{Type: left}},
+		}),
+		Types: []graphql.Type{right},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resolve := func(ctx context.Context, representation map[string]interface{}) (interface{}, error) {
+		return sharedEntity{ID: "1", Extra: map[string]interface{}{}}, nil
+	}
+	schema, err = federation.NewSchema(federation.Config{
+		Schema: schema,
+		Types: map[string]federation.TypeConfig{
+			"Left":  {Keys: []string{"id"}, ResolveReference: resolve},
+			"Right": {Keys: []string{"id"}, ResolveReference: resolve},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		typenames := []string{"Left", "Right"}
+		if i%2 == 1 {
+			typenames = []string{"Right", "Left"}
+		}
+		go func() {
+			representations := []interface{}{}
+			expected := []interface{}{}
+			for _, typename := range typenames {
+				representations = append(representations, map[string]interface{}{"__typename": typename, "id": "1"})
+				expected = append(expected, map[string]interface{}{"__typename": typename, "id": "1"})
+			}
+			result := graphql.Do(graphql.Params{
+				Schema:         schema,
+				RequestString:  `query ($r: [_Any!]!) { _entities(representations: $r) { __typename ... on Left { id } ... on Right { id } } }`,
+				VariableValues: map[string]interface{}{"r": representations},
+			})
+			if len(result.Errors) != 0 {
+				done <- fmt.Errorf("unexpected errors: %v", result.Errors)
+				return
+			}
+			if entities := result.Data.(map[string]interface{})["_entities"]; !reflect.DeepEqual(expected, entities) {
+				done <- fmt.Errorf("expected %v, got %v", expected, entities)
+				return
+			}
+			done <- nil
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
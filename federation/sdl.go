@@ -0,0 +1,71 @@
+package federation
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+const linkDirective = `extend schema @link(url: "https://specs.apollo.dev/federation/v2.0", import: ["@key", "@external", "@requires", "@provides", "@shareable"])`
+
+// printSubgraphSchema prints the SDL returned by `_service`: the original
+// schema annotated with the directives declared in config.
+func printSubgraphSchema(config Config) string {
+	doc := graphql.ASTFromSchema(config.Schema)
+	for _, def := range doc.Definitions {
+		object, ok := def.(*ast.ObjectDefinition)
+		if !ok {
+			continue
+		}
+		if typeConfig, ok := config.Types[object.Name.Value]; ok {
+			for _, key := range typeConfig.Keys {
+				object.Directives = append(object.Directives, directiveAST("key", "fields", key))
+			}
+			if typeConfig.Shareable {
+				object.Directives = append(object.Directives, directiveAST("shareable", "", ""))
+			}
+		}
+		for _, field := range object.Fields {
+			fieldConfig, ok := config.Fields[object.Name.Value+"."+field.Name.Value]
+			if !ok {
+				continue
+			}
+			if fieldConfig.External {
+				field.Directives = append(field.Directives, directiveAST("external", "", ""))
+			}
+			if fieldConfig.Requires != "" {
+				field.Directives = append(field.Directives, directiveAST("requires", "fields", fieldConfig.Requires))
+			}
+			if fieldConfig.Provides != "" {
+				field.Directives = append(field.Directives, directiveAST("provides", "fields", fieldConfig.Provides))
+			}
+			if fieldConfig.Shareable {
+				field.Directives = append(field.Directives, directiveAST("shareable", "", ""))
+			}
+		}
+	}
+
+	printed, _ := printer.Print(doc).(string)
+	lines := strings.Split(strings.TrimSpace(printed), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return linkDirective + "\n\n" + strings.Join(lines, "\n") + "\n"
+}
+
+func directiveAST(name, argName, argValue string) *ast.Directive {
+	directive := ast.NewDirective(&ast.Directive{
+		Name: ast.NewName(&ast.Name{Value: name}),
+	})
+	if argName != "" {
+		directive.Arguments = []*ast.Argument{
+			ast.NewArgument(&ast.Argument{
+				Name:  ast.NewName(&ast.Name{Value: argName}),
+				Value: ast.NewStringValue(&ast.StringValue{Value: argValue}),
+			}),
+		}
+	}
+	return directive
+}
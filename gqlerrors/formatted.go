@@ -2,6 +2,7 @@ package gqlerrors
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/graphql-go/graphql/language/location"
 )
@@ -27,6 +28,15 @@ func (g FormattedError) Error() string {
 	return g.Message
 }
 
+// Error joins the messages of the errors, one per line.
+func (errs FormattedErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
 func NewFormattedError(message string) FormattedError {
 	err := errors.New(message)
 	return FormatError(err)
@@ -63,6 +73,12 @@ func FormatError(err error) FormattedError {
 func FormatErrors(errs ...error) []FormattedError {
 	formattedErrors := []FormattedError{}
 	for _, err := range errs {
+		if syntaxErrs, ok := err.(SyntaxErrors); ok {
+			for _, syntaxErr := range syntaxErrs {
+				formattedErrors = append(formattedErrors, FormatError(syntaxErr))
+			}
+			continue
+		}
 		formattedErrors = append(formattedErrors, FormatError(err))
 	}
 	return formattedErrors
@@ -22,6 +22,18 @@ func NewSyntaxError(s *source.Source, position int, description string) *Error {
 	)
 }
 
+// SyntaxErrors lists the syntax errors of a document parsed with the
+// Tolerant parse option, in the order they appear in the document.
+type SyntaxErrors []*Error
+
+func (errs SyntaxErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
 // printCharCode here is slightly different from lexer.printCharCode()
 func printCharCode(code rune) string {
 	// print as ASCII for printable range
@@ -50,10 +62,7 @@ func highlightSourceAtLocation(s *source.Source, l location.SourceLocation) stri
 		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, prevLineNum), printLine(lines[line-2]))
 	}
 	highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, lineNum), printLine(lines[line-1]))
-	for i := 1; i < (2 + padLen + l.Column); i++ {
-		highlight += " "
-	}
-	highlight += "^\n"
+	highlight += strings.Repeat(" ", 1+padLen+l.Column) + "^\n"
 	if line < len(lines) {
 		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, nextLineNum), printLine(lines[line]))
 	}
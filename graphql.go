@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/source"
 )
@@ -31,38 +32,83 @@ type Params struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// PreserveFieldOrder makes the objects of the result data *OrderedMap
+	// values, which are marshaled to JSON with the fields in selection order.
+	PreserveFieldOrder bool
+
+	// MaxTokens and MaxDepth bound the number of tokens and the nesting depth
+	// of requestString; requests over the limits fail with a syntax error
+	// before being parsed entirely. See parser.ParseOptions.
+	MaxTokens int
+	MaxDepth  int
+
+	// ValidationRules are the rules to validate requestString with,
+	// SpecifiedRules if empty. For instance, DisableIntrospectionRule can be
+	// added to them for the requests which may not introspect the schema.
+	ValidationRules []ValidationRuleFn
 }
 
 func Do(p Params) *Result {
+	AST, result := parseAndValidate(&p)
+	if result != nil {
+		return result
+	}
+
+	return Execute(ExecuteParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           AST,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		Context:       p.Context,
+
+		PreserveFieldOrder: p.PreserveFieldOrder,
+	})
+}
+
+func parseOptions(p *Params) parser.ParseOptions {
+	return parser.ParseOptions{
+		MaxTokens: p.MaxTokens,
+		MaxDepth:  p.MaxDepth,
+	}
+}
+
+// parseAndValidate parses and validates the request of p, notifying the
+// extensions. It returns the result to respond with if the request is invalid.
+func parseAndValidate(p *Params) (*ast.Document, *Result) {
 	source := source.NewSource(&source.Source{
 		Body: []byte(p.RequestString),
 		Name: "GraphQL request",
 	})
 
 	// run init on the extensions
-	extErrs := handleExtensionsInits(&p)
+	extErrs := handleExtensionsInits(p)
 	if len(extErrs) != 0 {
-		return &Result{
+		return nil, &Result{
 			Errors: extErrs,
 		}
 	}
 
-	extErrs, parseFinishFn := handleExtensionsParseDidStart(&p)
+	extErrs, parseFinishFn := handleExtensionsParseDidStart(p)
 	if len(extErrs) != 0 {
-		return &Result{
+		return nil, &Result{
 			Errors: extErrs,
 		}
 	}
 
 	// parse the source
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	AST, err := parser.Parse(parser.ParseParams{
+		Source:  source,
+		Options: parseOptions(p),
+	})
 	if err != nil {
 		// run parseFinishFuncs for extensions
 		extErrs = parseFinishFn(err)
 
 		// merge the errors from extensions and the original error from parser
 		extErrs = append(extErrs, gqlerrors.FormatErrors(err)...)
-		return &Result{
+		return nil, &Result{
 			Errors: extErrs,
 		}
 	}
@@ -70,21 +116,21 @@ func Do(p Params) *Result {
 	// run parseFinish functions for extensions
 	extErrs = parseFinishFn(err)
 	if len(extErrs) != 0 {
-		return &Result{
+		return nil, &Result{
 			Errors: extErrs,
 		}
 	}
 
 	// notify extensions about the start of the validation
-	extErrs, validationFinishFn := handleExtensionsValidationDidStart(&p)
+	extErrs, validationFinishFn := handleExtensionsValidationDidStart(p)
 	if len(extErrs) != 0 {
-		return &Result{
+		return nil, &Result{
 			Errors: extErrs,
 		}
 	}
 
 	// validate document
-	validationResult := ValidateDocument(&p.Schema, AST, nil)
+	validationResult := ValidateDocumentWithContext(p.Context, &p.Schema, AST, p.ValidationRules)
 
 	if !validationResult.IsValid {
 		// run validation finish functions for extensions
@@ -92,7 +138,7 @@ func Do(p Params) *Result {
 
 		// merge the errors from extensions and the original error from parser
 		extErrs = append(extErrs, validationResult.Errors...)
-		return &Result{
+		return nil, &Result{
 			Errors: extErrs,
 		}
 	}
@@ -100,19 +146,10 @@ func Do(p Params) *Result {
 	// run the validationFinishFuncs for extensions
 	extErrs = validationFinishFn(validationResult.Errors)
 	if len(extErrs) != 0 {
-		return &Result{
+		return nil, &Result{
 			Errors: extErrs,
 		}
 	}
 
-	// notify extensions about the start of the execution
-	// notify extensions about the start of the execution
-	return Execute(ExecuteParams{
-		Schema:        p.Schema,
-		Root:          p.RootObject,
-		AST:           AST,
-		OperationName: p.OperationName,
-		Args:          p.VariableValues,
-		Context:       p.Context,
-	})
+	return AST, nil
 }
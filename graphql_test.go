@@ -3,6 +3,7 @@ package graphql_test
 import (
 	"context"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/graphql-go/graphql"
@@ -268,3 +269,36 @@ func TestEmptyStringIsNotNull(t *testing.T) {
 		t.Errorf("wrong result, query: %v, graphql result diff: %v", query, testutil.Diff(expected, result))
 	}
 }
+
+func TestDoAbortsParsingOverLimits(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"a": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("wrong result, unexpected errors: %v", err.Error())
+	}
+	tests := []struct {
+		params   graphql.Params
+		expected string
+	}{
+		{
+			graphql.Params{Schema: schema, RequestString: `{ a a a }`, MaxTokens: 4},
+			"Syntax Error GraphQL request (1:9) Document contains more than 4 tokens. Parsing aborted.",
+		},
+		{
+			graphql.Params{Schema: schema, RequestString: `{ a(b: [[1]]) }`, MaxDepth: 2},
+			"Syntax Error GraphQL request (1:9) Document is nested deeper than 2 levels. Parsing aborted.",
+		},
+	}
+	for _, test := range tests {
+		result := graphql.Do(test.params)
+		if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0].Message, test.expected) {
+			t.Fatalf("expected error %q, got %v", test.expected, result.Errors)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package diff computes line differences between texts, for showing them
+// in tools and test failures.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines printed around changes.
+const diffContext = 3
+
+type diffLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// Unified returns the differences between the lines of a and b, named aName
+// and bName, in the unified format, or an empty string when they are equal.
+func Unified(aName, bName, a, b string) string {
+	lines := diffLines(splitLines(a), splitLines(b))
+	var out strings.Builder
+	for start := 0; start < len(lines); {
+		// find the next change and the end of its hunk
+		change := start
+		for change < len(lines) && lines[change].op == ' ' {
+			change++
+		}
+		if change == len(lines) {
+			break
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+		}
+		first := change - diffContext
+		if first < start {
+			first = start
+		}
+		last, unchanged := change, 0
+		for i := change; i < len(lines) && unchanged <= 2*diffContext; i++ {
+			if lines[i].op == ' ' {
+				unchanged++
+			} else {
+				last, unchanged = i, 0
+			}
+		}
+		end := last + 1 + diffContext
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		aLine, bLine := 1, 1
+		for _, line := range lines[:first] {
+			if line.op != '+' {
+				aLine++
+			}
+			if line.op != '-' {
+				bLine++
+			}
+		}
+		aCount, bCount := 0, 0
+		for _, line := range lines[first:end] {
+			if line.op != '+' {
+				aCount++
+			}
+			if line.op != '-' {
+				bCount++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+		for _, line := range lines[first:end] {
+			fmt.Fprintf(&out, "%c%s\n", line.op, line.text)
+		}
+		start = end
+	}
+	return out.String()
+}
+
+func splitLines(text string) []string {
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines lines up a and b along their longest common subsequence.
+func diffLines(a, b []string) []diffLine {
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lengths[i+1][j] >= lengths[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	return lines
+}
@@ -0,0 +1,20 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql/internal/diff"
+)
+
+func TestUnified(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\ni\n"
+	b := "a\nb\nc\nD\ne\nf\ng\nh\ni\nj\n"
+	expected := "--- old\n+++ new\n" +
+		"@@ -1,9 +1,10 @@\n a\n b\n c\n-d\n+D\n e\n f\n g\n h\n i\n+j\n"
+	if got := diff.Unified("old", "new", a, b); got != expected {
+		t.Fatalf("expected:\n%v\ngot:\n%v", expected, got)
+	}
+	if got := diff.Unified("old", "new", a, a); got != "" {
+		t.Fatalf("expected no differences, got:\n%v", got)
+	}
+}
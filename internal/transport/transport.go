@@ -0,0 +1,65 @@
+// Package transport sends GraphQL requests to remote servers as JSON POST
+// requests, for the packages acting as GraphQL clients.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// StatusError is returned when a server responds with a status other than
+// 200 OK and a body which is not JSON.
+type StatusError struct {
+	Status string
+}
+
+func (err *StatusError) Error() string {
+	return "unexpected status " + err.Status
+}
+
+// DecodeError is returned when the body of a response is not valid JSON.
+type DecodeError struct {
+	Err error
+}
+
+func (err *DecodeError) Error() string {
+	return err.Err.Error()
+}
+
+// Post sends request, encoded as JSON along with header, to the server at
+// url with client, and decodes the JSON body of its response into response.
+// Other errors than StatusError and DecodeError are those of client.
+func Post(ctx context.Context, client *http.Client, url string, header http.Header, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		if res.StatusCode != http.StatusOK {
+			return &StatusError{Status: res.Status}
+		}
+		return &DecodeError{Err: err}
+	}
+	return nil
+}
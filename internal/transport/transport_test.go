@@ -0,0 +1,46 @@
+package transport_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/graphql-go/graphql/internal/transport"
+)
+
+func TestPost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "token" {
+			t.Errorf("unexpected request: %v %v", r.Method, r.Header)
+		}
+		switch string(body) {
+		case `{"query":"{ a }"}`:
+			w.Write([]byte(`{"data":{"a":1}}`))
+		case `{"query":"{ b }"}`:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`<html>`))
+		}
+	}))
+	defer server.Close()
+
+	post := func(query string, response interface{}) error {
+		header := http.Header{"Authorization": {"token"}}
+		return transport.Post(context.Background(), http.DefaultClient, server.URL, header, map[string]string{"query": query}, response)
+	}
+	var response struct {
+		Data map[string]int `json:"data"`
+	}
+	if err := post("{ a }", &response); err != nil || response.Data["a"] != 1 {
+		t.Fatalf("unexpected response: %+v, %v", response, err)
+	}
+	if err, ok := post("{ b }", &response).(*transport.StatusError); !ok || err.Status != "503 Service Unavailable" {
+		t.Fatalf("expected a status error, got %v", err)
+	}
+	if err, ok := post("{ c }", &response).(*transport.DecodeError); !ok {
+		t.Fatalf("expected a decode error, got %v", err)
+	}
+}
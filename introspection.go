@@ -226,6 +226,15 @@ func init() {
 			"description": &Field{
 				Type: String,
 			},
+			"specifiedByURL": &Field{
+				Type: String,
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					if scalar, ok := p.Source.(*Scalar); ok && scalar.SpecifiedByURL() != "" {
+						return scalar.SpecifiedByURL(), nil
+					}
+					return nil, nil
+				},
+			},
 			"fields":        &Field{},
 			"interfaces":    &Field{},
 			"possibleTypes": &Field{},
@@ -262,14 +271,14 @@ func init() {
 						if isNullish(inputVal.DefaultValue) {
 							return nil, nil
 						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
+						astVal := astFromValue(inputVal.DefaultValue, inputVal.Type)
 						return printer.Print(astVal), nil
 					}
 					if inputVal, ok := p.Source.(*InputObjectField); ok {
 						if inputVal.DefaultValue == nil {
 							return nil, nil
 						}
-						astVal := astFromValue(inputVal.DefaultValue, inputVal)
+						astVal := astFromValue(inputVal.DefaultValue, inputVal.Type)
 						return printer.Print(astVal), nil
 					}
 					return nil, nil
@@ -535,8 +544,9 @@ func init() {
 					return nil, nil
 				}
 				fields := []*FieldDefinition{}
+				visible := p.Info.Schema.visibleFields(p.Context, ttype, ttype.Fields())
 				var fieldNames sort.StringSlice
-				for name, field := range ttype.Fields() {
+				for name, field := range visible {
 					if !includeDeprecated && field.DeprecationReason != "" {
 						continue
 					}
@@ -544,7 +554,7 @@ func init() {
 				}
 				sort.Sort(fieldNames)
 				for _, name := range fieldNames {
-					fields = append(fields, ttype.Fields()[name])
+					fields = append(fields, visible[name])
 				}
 				return fields, nil
 			case *Interface:
@@ -552,7 +562,7 @@ func init() {
 					return nil, nil
 				}
 				fields := []*FieldDefinition{}
-				for _, field := range ttype.Fields() {
+				for _, field := range p.Info.Schema.visibleFields(p.Context, ttype, ttype.Fields()) {
 					if !includeDeprecated && field.DeprecationReason != "" {
 						continue
 					}
@@ -6,10 +6,11 @@ import (
 
 // Argument implements Node
 type Argument struct {
-	Kind  string
-	Loc   *Location
-	Name  *Name
-	Value Value
+	Kind     string
+	Loc      *Location
+	Name     *Name
+	Value    Value
+	Comments *Trivia
 }
 
 func NewArgument(arg *Argument) *Argument {
@@ -27,3 +28,11 @@ func (arg *Argument) GetKind() string {
 func (arg *Argument) GetLoc() *Location {
 	return arg.Loc
 }
+
+func (arg *Argument) GetComments() *Trivia {
+	return arg.Comments
+}
+
+func (arg *Argument) SetComments(comments *Trivia) {
+	arg.Comments = comments
+}
@@ -9,6 +9,10 @@ type Document struct {
 	Kind        string
 	Loc         *Location
 	Definitions []Node
+
+	// Comments holds the comments after the last definition of the document,
+	// when it is parsed with the Comments parse option.
+	Comments *Trivia
 }
 
 func NewDocument(d *Document) *Document {
@@ -19,6 +23,7 @@ func NewDocument(d *Document) *Document {
 		Kind:        kinds.Document,
 		Loc:         d.Loc,
 		Definitions: d.Definitions,
+		Comments:    d.Comments,
 	}
 }
 
@@ -29,3 +34,52 @@ func (node *Document) GetKind() string {
 func (node *Document) GetLoc() *Location {
 	return node.Loc
 }
+
+func (node *Document) GetComments() *Trivia {
+	return node.Comments
+}
+
+func (node *Document) SetComments(comments *Trivia) {
+	node.Comments = comments
+}
+
+// Trivia holds the comments attached to a node by the parser when a document
+// is parsed with the Comments parse option. A comment is attached to the
+// definition, selection, argument, field or list item it precedes or follows
+// on the same line, or to the innermost node enclosing it otherwise.
+type Trivia struct {
+	// Leading are the comments on the lines before the node.
+	Leading []*Comment
+
+	// Trailing is the comment following the node on its last line.
+	Trailing *Comment
+
+	// Inner are the comments inside the node after its last item, such as
+	// the comments before the closing brace of a selection set.
+	Inner []*Comment
+}
+
+// Comment implements Node
+type Comment struct {
+	Kind string
+	Loc  *Location
+
+	// Value is the text of the comment following the "#".
+	Value string
+}
+
+func NewComment(c *Comment) *Comment {
+	if c == nil {
+		c = &Comment{}
+	}
+	c.Kind = kinds.Comment
+	return c
+}
+
+func (node *Comment) GetKind() string {
+	return node.Kind
+}
+
+func (node *Comment) GetLoc() *Location {
+	return node.Loc
+}
@@ -0,0 +1,51 @@
+package ast
+
+import (
+	"github.com/graphql-go/graphql/language/kinds"
+)
+
+// Invalid implements Node, Definition, Selection.
+// It stands for a definition or a selection that failed to parse when the
+// document is parsed with ParseOptions.Tolerant, its location spanning the
+// skipped source.
+type Invalid struct {
+	Kind     string
+	Loc      *Location
+	Comments *Trivia
+}
+
+func NewInvalid(node *Invalid) *Invalid {
+	if node == nil {
+		node = &Invalid{}
+	}
+	node.Kind = kinds.Invalid
+	return node
+}
+
+func (node *Invalid) GetKind() string {
+	return node.Kind
+}
+
+func (node *Invalid) GetLoc() *Location {
+	return node.Loc
+}
+
+func (node *Invalid) GetComments() *Trivia {
+	return node.Comments
+}
+
+func (node *Invalid) SetComments(comments *Trivia) {
+	node.Comments = comments
+}
+
+func (node *Invalid) GetOperation() string {
+	return ""
+}
+
+func (node *Invalid) GetVariableDefinitions() []*VariableDefinition {
+	return nil
+}
+
+func (node *Invalid) GetSelectionSet() *SelectionSet {
+	return nil
+}
@@ -5,10 +5,18 @@ type Node interface {
 	GetLoc() *Location
 }
 
+// CommentedNode is a node comments can be attached to.
+type CommentedNode interface {
+	Node
+	GetComments() *Trivia
+	SetComments(comments *Trivia)
+}
+
 // The list of all possible AST node graphql.
 // Ensure that all node types implements Node interface
 var _ Node = (*Name)(nil)
 var _ Node = (*Document)(nil)
+var _ Node = (*Comment)(nil)
 var _ Node = (*OperationDefinition)(nil)
 var _ Node = (*VariableDefinition)(nil)
 var _ Node = (*Variable)(nil)
@@ -41,5 +49,53 @@ var _ Node = (*UnionDefinition)(nil)
 var _ Node = (*EnumDefinition)(nil)
 var _ Node = (*EnumValueDefinition)(nil)
 var _ Node = (*InputObjectDefinition)(nil)
+var _ Node = (*SchemaExtensionDefinition)(nil)
+var _ Node = (*ScalarExtensionDefinition)(nil)
 var _ Node = (*TypeExtensionDefinition)(nil)
+var _ Node = (*InterfaceExtensionDefinition)(nil)
+var _ Node = (*UnionExtensionDefinition)(nil)
+var _ Node = (*EnumExtensionDefinition)(nil)
+var _ Node = (*InputObjectExtensionDefinition)(nil)
 var _ Node = (*DirectiveDefinition)(nil)
+var _ Node = (*Invalid)(nil)
+
+// Ensure that the node types comments are attached to implement CommentedNode
+var _ CommentedNode = (*Document)(nil)
+var _ CommentedNode = (*OperationDefinition)(nil)
+var _ CommentedNode = (*VariableDefinition)(nil)
+var _ CommentedNode = (*Variable)(nil)
+var _ CommentedNode = (*SelectionSet)(nil)
+var _ CommentedNode = (*Field)(nil)
+var _ CommentedNode = (*Argument)(nil)
+var _ CommentedNode = (*FragmentSpread)(nil)
+var _ CommentedNode = (*InlineFragment)(nil)
+var _ CommentedNode = (*FragmentDefinition)(nil)
+var _ CommentedNode = (*IntValue)(nil)
+var _ CommentedNode = (*FloatValue)(nil)
+var _ CommentedNode = (*StringValue)(nil)
+var _ CommentedNode = (*BooleanValue)(nil)
+var _ CommentedNode = (*NullValue)(nil)
+var _ CommentedNode = (*EnumValue)(nil)
+var _ CommentedNode = (*ListValue)(nil)
+var _ CommentedNode = (*ObjectValue)(nil)
+var _ CommentedNode = (*ObjectField)(nil)
+var _ CommentedNode = (*SchemaDefinition)(nil)
+var _ CommentedNode = (*OperationTypeDefinition)(nil)
+var _ CommentedNode = (*ScalarDefinition)(nil)
+var _ CommentedNode = (*ObjectDefinition)(nil)
+var _ CommentedNode = (*FieldDefinition)(nil)
+var _ CommentedNode = (*InputValueDefinition)(nil)
+var _ CommentedNode = (*InterfaceDefinition)(nil)
+var _ CommentedNode = (*UnionDefinition)(nil)
+var _ CommentedNode = (*EnumDefinition)(nil)
+var _ CommentedNode = (*EnumValueDefinition)(nil)
+var _ CommentedNode = (*InputObjectDefinition)(nil)
+var _ CommentedNode = (*SchemaExtensionDefinition)(nil)
+var _ CommentedNode = (*ScalarExtensionDefinition)(nil)
+var _ CommentedNode = (*TypeExtensionDefinition)(nil)
+var _ CommentedNode = (*InterfaceExtensionDefinition)(nil)
+var _ CommentedNode = (*UnionExtensionDefinition)(nil)
+var _ CommentedNode = (*EnumExtensionDefinition)(nil)
+var _ CommentedNode = (*InputObjectExtensionDefinition)(nil)
+var _ CommentedNode = (*DirectiveDefinition)(nil)
+var _ CommentedNode = (*Invalid)(nil)
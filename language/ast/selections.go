@@ -12,6 +12,7 @@ type Selection interface {
 var _ Selection = (*Field)(nil)
 var _ Selection = (*FragmentSpread)(nil)
 var _ Selection = (*InlineFragment)(nil)
+var _ Selection = (*Invalid)(nil)
 
 // Field implements Node, Selection
 type Field struct {
@@ -22,6 +23,7 @@ type Field struct {
 	Arguments    []*Argument
 	Directives   []*Directive
 	SelectionSet *SelectionSet
+	Comments     *Trivia
 }
 
 func NewField(f *Field) *Field {
@@ -40,6 +42,14 @@ func (f *Field) GetLoc() *Location {
 	return f.Loc
 }
 
+func (f *Field) GetComments() *Trivia {
+	return f.Comments
+}
+
+func (f *Field) SetComments(comments *Trivia) {
+	f.Comments = comments
+}
+
 func (f *Field) GetSelectionSet() *SelectionSet {
 	return f.SelectionSet
 }
@@ -50,6 +60,7 @@ type FragmentSpread struct {
 	Loc        *Location
 	Name       *Name
 	Directives []*Directive
+	Comments   *Trivia
 }
 
 func NewFragmentSpread(fs *FragmentSpread) *FragmentSpread {
@@ -61,6 +72,7 @@ func NewFragmentSpread(fs *FragmentSpread) *FragmentSpread {
 		Loc:        fs.Loc,
 		Name:       fs.Name,
 		Directives: fs.Directives,
+		Comments:   fs.Comments,
 	}
 }
 
@@ -72,6 +84,14 @@ func (fs *FragmentSpread) GetLoc() *Location {
 	return fs.Loc
 }
 
+func (fs *FragmentSpread) GetComments() *Trivia {
+	return fs.Comments
+}
+
+func (fs *FragmentSpread) SetComments(comments *Trivia) {
+	fs.Comments = comments
+}
+
 func (fs *FragmentSpread) GetSelectionSet() *SelectionSet {
 	return nil
 }
@@ -83,6 +103,7 @@ type InlineFragment struct {
 	TypeCondition *Named
 	Directives    []*Directive
 	SelectionSet  *SelectionSet
+	Comments      *Trivia
 }
 
 func NewInlineFragment(f *InlineFragment) *InlineFragment {
@@ -95,6 +116,7 @@ func NewInlineFragment(f *InlineFragment) *InlineFragment {
 		TypeCondition: f.TypeCondition,
 		Directives:    f.Directives,
 		SelectionSet:  f.SelectionSet,
+		Comments:      f.Comments,
 	}
 }
 
@@ -106,6 +128,14 @@ func (f *InlineFragment) GetLoc() *Location {
 	return f.Loc
 }
 
+func (f *InlineFragment) GetComments() *Trivia {
+	return f.Comments
+}
+
+func (f *InlineFragment) SetComments(comments *Trivia) {
+	f.Comments = comments
+}
+
 func (f *InlineFragment) GetSelectionSet() *SelectionSet {
 	return f.SelectionSet
 }
@@ -115,6 +145,7 @@ type SelectionSet struct {
 	Kind       string
 	Loc        *Location
 	Selections []Selection
+	Comments   *Trivia
 }
 
 func NewSelectionSet(ss *SelectionSet) *SelectionSet {
@@ -132,3 +163,11 @@ func (ss *SelectionSet) GetKind() string {
 func (ss *SelectionSet) GetLoc() *Location {
 	return ss.Loc
 }
+
+func (ss *SelectionSet) GetComments() *Trivia {
+	return ss.Comments
+}
+
+func (ss *SelectionSet) SetComments(comments *Trivia) {
+	ss.Comments = comments
+}
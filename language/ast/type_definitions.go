@@ -35,7 +35,13 @@ type TypeSystemDefinition interface {
 
 var _ TypeSystemDefinition = (*SchemaDefinition)(nil)
 var _ TypeSystemDefinition = (TypeDefinition)(nil)
+var _ TypeSystemDefinition = (*SchemaExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*ScalarExtensionDefinition)(nil)
 var _ TypeSystemDefinition = (*TypeExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*InterfaceExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*UnionExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*EnumExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*InputObjectExtensionDefinition)(nil)
 var _ TypeSystemDefinition = (*DirectiveDefinition)(nil)
 
 // SchemaDefinition implements Node, Definition
@@ -44,6 +50,7 @@ type SchemaDefinition struct {
 	Loc            *Location
 	Directives     []*Directive
 	OperationTypes []*OperationTypeDefinition
+	Comments       *Trivia
 }
 
 func NewSchemaDefinition(def *SchemaDefinition) *SchemaDefinition {
@@ -55,6 +62,7 @@ func NewSchemaDefinition(def *SchemaDefinition) *SchemaDefinition {
 		Loc:            def.Loc,
 		Directives:     def.Directives,
 		OperationTypes: def.OperationTypes,
+		Comments:       def.Comments,
 	}
 }
 
@@ -66,6 +74,14 @@ func (def *SchemaDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *SchemaDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *SchemaDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *SchemaDefinition) GetVariableDefinitions() []*VariableDefinition {
 	return []*VariableDefinition{}
 }
@@ -84,6 +100,7 @@ type OperationTypeDefinition struct {
 	Loc       *Location
 	Operation string
 	Type      *Named
+	Comments  *Trivia
 }
 
 func NewOperationTypeDefinition(def *OperationTypeDefinition) *OperationTypeDefinition {
@@ -95,6 +112,7 @@ func NewOperationTypeDefinition(def *OperationTypeDefinition) *OperationTypeDefi
 		Loc:       def.Loc,
 		Operation: def.Operation,
 		Type:      def.Type,
+		Comments:  def.Comments,
 	}
 }
 
@@ -106,6 +124,14 @@ func (def *OperationTypeDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *OperationTypeDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *OperationTypeDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 // ScalarDefinition implements Node, Definition
 type ScalarDefinition struct {
 	Kind        string
@@ -113,6 +139,7 @@ type ScalarDefinition struct {
 	Description *StringValue
 	Name        *Name
 	Directives  []*Directive
+	Comments    *Trivia
 }
 
 func NewScalarDefinition(def *ScalarDefinition) *ScalarDefinition {
@@ -125,6 +152,7 @@ func NewScalarDefinition(def *ScalarDefinition) *ScalarDefinition {
 		Description: def.Description,
 		Name:        def.Name,
 		Directives:  def.Directives,
+		Comments:    def.Comments,
 	}
 }
 
@@ -136,6 +164,14 @@ func (def *ScalarDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *ScalarDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *ScalarDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *ScalarDefinition) GetName() *Name {
 	return def.Name
 }
@@ -165,6 +201,7 @@ type ObjectDefinition struct {
 	Interfaces  []*Named
 	Directives  []*Directive
 	Fields      []*FieldDefinition
+	Comments    *Trivia
 }
 
 func NewObjectDefinition(def *ObjectDefinition) *ObjectDefinition {
@@ -179,6 +216,7 @@ func NewObjectDefinition(def *ObjectDefinition) *ObjectDefinition {
 		Interfaces:  def.Interfaces,
 		Directives:  def.Directives,
 		Fields:      def.Fields,
+		Comments:    def.Comments,
 	}
 }
 
@@ -190,6 +228,14 @@ func (def *ObjectDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *ObjectDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *ObjectDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *ObjectDefinition) GetName() *Name {
 	return def.Name
 }
@@ -219,6 +265,7 @@ type FieldDefinition struct {
 	Arguments   []*InputValueDefinition
 	Type        Type
 	Directives  []*Directive
+	Comments    *Trivia
 }
 
 func NewFieldDefinition(def *FieldDefinition) *FieldDefinition {
@@ -233,6 +280,7 @@ func NewFieldDefinition(def *FieldDefinition) *FieldDefinition {
 		Arguments:   def.Arguments,
 		Type:        def.Type,
 		Directives:  def.Directives,
+		Comments:    def.Comments,
 	}
 }
 
@@ -244,6 +292,14 @@ func (def *FieldDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *FieldDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *FieldDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *FieldDefinition) GetDescription() *StringValue {
 	return def.Description
 }
@@ -257,6 +313,7 @@ type InputValueDefinition struct {
 	Type         Type
 	DefaultValue Value
 	Directives   []*Directive
+	Comments     *Trivia
 }
 
 func NewInputValueDefinition(def *InputValueDefinition) *InputValueDefinition {
@@ -271,6 +328,7 @@ func NewInputValueDefinition(def *InputValueDefinition) *InputValueDefinition {
 		Type:         def.Type,
 		DefaultValue: def.DefaultValue,
 		Directives:   def.Directives,
+		Comments:     def.Comments,
 	}
 }
 
@@ -282,6 +340,14 @@ func (def *InputValueDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *InputValueDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *InputValueDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *InputValueDefinition) GetDescription() *StringValue {
 	return def.Description
 }
@@ -294,6 +360,7 @@ type InterfaceDefinition struct {
 	Description *StringValue
 	Directives  []*Directive
 	Fields      []*FieldDefinition
+	Comments    *Trivia
 }
 
 func NewInterfaceDefinition(def *InterfaceDefinition) *InterfaceDefinition {
@@ -307,6 +374,7 @@ func NewInterfaceDefinition(def *InterfaceDefinition) *InterfaceDefinition {
 		Description: def.Description,
 		Directives:  def.Directives,
 		Fields:      def.Fields,
+		Comments:    def.Comments,
 	}
 }
 
@@ -318,6 +386,14 @@ func (def *InterfaceDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *InterfaceDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *InterfaceDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *InterfaceDefinition) GetName() *Name {
 	return def.Name
 }
@@ -346,6 +422,7 @@ type UnionDefinition struct {
 	Description *StringValue
 	Directives  []*Directive
 	Types       []*Named
+	Comments    *Trivia
 }
 
 func NewUnionDefinition(def *UnionDefinition) *UnionDefinition {
@@ -359,6 +436,7 @@ func NewUnionDefinition(def *UnionDefinition) *UnionDefinition {
 		Description: def.Description,
 		Directives:  def.Directives,
 		Types:       def.Types,
+		Comments:    def.Comments,
 	}
 }
 
@@ -370,6 +448,14 @@ func (def *UnionDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *UnionDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *UnionDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *UnionDefinition) GetName() *Name {
 	return def.Name
 }
@@ -398,6 +484,7 @@ type EnumDefinition struct {
 	Description *StringValue
 	Directives  []*Directive
 	Values      []*EnumValueDefinition
+	Comments    *Trivia
 }
 
 func NewEnumDefinition(def *EnumDefinition) *EnumDefinition {
@@ -411,6 +498,7 @@ func NewEnumDefinition(def *EnumDefinition) *EnumDefinition {
 		Description: def.Description,
 		Directives:  def.Directives,
 		Values:      def.Values,
+		Comments:    def.Comments,
 	}
 }
 
@@ -422,6 +510,14 @@ func (def *EnumDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *EnumDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *EnumDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *EnumDefinition) GetName() *Name {
 	return def.Name
 }
@@ -449,6 +545,7 @@ type EnumValueDefinition struct {
 	Name        *Name
 	Description *StringValue
 	Directives  []*Directive
+	Comments    *Trivia
 }
 
 func NewEnumValueDefinition(def *EnumValueDefinition) *EnumValueDefinition {
@@ -461,6 +558,7 @@ func NewEnumValueDefinition(def *EnumValueDefinition) *EnumValueDefinition {
 		Name:        def.Name,
 		Description: def.Description,
 		Directives:  def.Directives,
+		Comments:    def.Comments,
 	}
 }
 
@@ -472,6 +570,14 @@ func (def *EnumValueDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *EnumValueDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *EnumValueDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *EnumValueDefinition) GetDescription() *StringValue {
 	return def.Description
 }
@@ -484,6 +590,7 @@ type InputObjectDefinition struct {
 	Description *StringValue
 	Directives  []*Directive
 	Fields      []*InputValueDefinition
+	Comments    *Trivia
 }
 
 func NewInputObjectDefinition(def *InputObjectDefinition) *InputObjectDefinition {
@@ -497,6 +604,7 @@ func NewInputObjectDefinition(def *InputObjectDefinition) *InputObjectDefinition
 		Description: def.Description,
 		Directives:  def.Directives,
 		Fields:      def.Fields,
+		Comments:    def.Comments,
 	}
 }
 
@@ -508,6 +616,14 @@ func (def *InputObjectDefinition) GetLoc() *Location {
 	return def.Loc
 }
 
+func (def *InputObjectDefinition) GetComments() *Trivia {
+	return def.Comments
+}
+
+func (def *InputObjectDefinition) SetComments(comments *Trivia) {
+	def.Comments = comments
+}
+
 func (def *InputObjectDefinition) GetName() *Name {
 	return def.Name
 }
@@ -16,15 +16,17 @@ var _ Value = (*IntValue)(nil)
 var _ Value = (*FloatValue)(nil)
 var _ Value = (*StringValue)(nil)
 var _ Value = (*BooleanValue)(nil)
+var _ Value = (*NullValue)(nil)
 var _ Value = (*EnumValue)(nil)
 var _ Value = (*ListValue)(nil)
 var _ Value = (*ObjectValue)(nil)
 
 // Variable implements Node, Value
 type Variable struct {
-	Kind string
-	Loc  *Location
-	Name *Name
+	Kind     string
+	Loc      *Location
+	Name     *Name
+	Comments *Trivia
 }
 
 func NewVariable(v *Variable) *Variable {
@@ -43,6 +45,14 @@ func (v *Variable) GetLoc() *Location {
 	return v.Loc
 }
 
+func (v *Variable) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *Variable) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
 // GetValue alias to Variable.GetName()
 func (v *Variable) GetValue() interface{} {
 	return v.GetName()
@@ -54,9 +64,10 @@ func (v *Variable) GetName() interface{} {
 
 // IntValue implements Node, Value
 type IntValue struct {
-	Kind  string
-	Loc   *Location
-	Value string
+	Kind     string
+	Loc      *Location
+	Value    string
+	Comments *Trivia
 }
 
 func NewIntValue(v *IntValue) *IntValue {
@@ -64,9 +75,10 @@ func NewIntValue(v *IntValue) *IntValue {
 		v = &IntValue{}
 	}
 	return &IntValue{
-		Kind:  kinds.IntValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.IntValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -78,15 +90,24 @@ func (v *IntValue) GetLoc() *Location {
 	return v.Loc
 }
 
+func (v *IntValue) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *IntValue) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
 func (v *IntValue) GetValue() interface{} {
 	return v.Value
 }
 
 // FloatValue implements Node, Value
 type FloatValue struct {
-	Kind  string
-	Loc   *Location
-	Value string
+	Kind     string
+	Loc      *Location
+	Value    string
+	Comments *Trivia
 }
 
 func NewFloatValue(v *FloatValue) *FloatValue {
@@ -94,9 +115,10 @@ func NewFloatValue(v *FloatValue) *FloatValue {
 		v = &FloatValue{}
 	}
 	return &FloatValue{
-		Kind:  kinds.FloatValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.FloatValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -108,15 +130,24 @@ func (v *FloatValue) GetLoc() *Location {
 	return v.Loc
 }
 
+func (v *FloatValue) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *FloatValue) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
 func (v *FloatValue) GetValue() interface{} {
 	return v.Value
 }
 
 // StringValue implements Node, Value
 type StringValue struct {
-	Kind  string
-	Loc   *Location
-	Value string
+	Kind     string
+	Loc      *Location
+	Value    string
+	Comments *Trivia
 }
 
 func NewStringValue(v *StringValue) *StringValue {
@@ -124,9 +155,10 @@ func NewStringValue(v *StringValue) *StringValue {
 		v = &StringValue{}
 	}
 	return &StringValue{
-		Kind:  kinds.StringValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.StringValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -138,15 +170,24 @@ func (v *StringValue) GetLoc() *Location {
 	return v.Loc
 }
 
+func (v *StringValue) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *StringValue) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
 func (v *StringValue) GetValue() interface{} {
 	return v.Value
 }
 
 // BooleanValue implements Node, Value
 type BooleanValue struct {
-	Kind  string
-	Loc   *Location
-	Value bool
+	Kind     string
+	Loc      *Location
+	Value    bool
+	Comments *Trivia
 }
 
 func NewBooleanValue(v *BooleanValue) *BooleanValue {
@@ -154,9 +195,10 @@ func NewBooleanValue(v *BooleanValue) *BooleanValue {
 		v = &BooleanValue{}
 	}
 	return &BooleanValue{
-		Kind:  kinds.BooleanValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.BooleanValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -168,15 +210,63 @@ func (v *BooleanValue) GetLoc() *Location {
 	return v.Loc
 }
 
+func (v *BooleanValue) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *BooleanValue) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
 func (v *BooleanValue) GetValue() interface{} {
 	return v.Value
 }
 
+// NullValue implements Node, Value. It is not produced by the parser: it
+// stands for the null values of the variables substituted in literals.
+type NullValue struct {
+	Kind     string
+	Loc      *Location
+	Comments *Trivia
+}
+
+func NewNullValue(v *NullValue) *NullValue {
+	if v == nil {
+		v = &NullValue{}
+	}
+	return &NullValue{
+		Kind:     kinds.NullValue,
+		Loc:      v.Loc,
+		Comments: v.Comments,
+	}
+}
+
+func (v *NullValue) GetKind() string {
+	return v.Kind
+}
+
+func (v *NullValue) GetLoc() *Location {
+	return v.Loc
+}
+
+func (v *NullValue) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *NullValue) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
+func (v *NullValue) GetValue() interface{} {
+	return nil
+}
+
 // EnumValue implements Node, Value
 type EnumValue struct {
-	Kind  string
-	Loc   *Location
-	Value string
+	Kind     string
+	Loc      *Location
+	Value    string
+	Comments *Trivia
 }
 
 func NewEnumValue(v *EnumValue) *EnumValue {
@@ -184,9 +274,10 @@ func NewEnumValue(v *EnumValue) *EnumValue {
 		v = &EnumValue{}
 	}
 	return &EnumValue{
-		Kind:  kinds.EnumValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.EnumValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -198,15 +289,24 @@ func (v *EnumValue) GetLoc() *Location {
 	return v.Loc
 }
 
+func (v *EnumValue) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *EnumValue) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
 func (v *EnumValue) GetValue() interface{} {
 	return v.Value
 }
 
 // ListValue implements Node, Value
 type ListValue struct {
-	Kind   string
-	Loc    *Location
-	Values []Value
+	Kind     string
+	Loc      *Location
+	Values   []Value
+	Comments *Trivia
 }
 
 func NewListValue(v *ListValue) *ListValue {
@@ -214,9 +314,10 @@ func NewListValue(v *ListValue) *ListValue {
 		v = &ListValue{}
 	}
 	return &ListValue{
-		Kind:   kinds.ListValue,
-		Loc:    v.Loc,
-		Values: v.Values,
+		Kind:     kinds.ListValue,
+		Loc:      v.Loc,
+		Values:   v.Values,
+		Comments: v.Comments,
 	}
 }
 
@@ -228,6 +329,14 @@ func (v *ListValue) GetLoc() *Location {
 	return v.Loc
 }
 
+func (v *ListValue) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *ListValue) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
 // GetValue alias to ListValue.GetValues()
 func (v *ListValue) GetValue() interface{} {
 	return v.GetValues()
@@ -240,9 +349,10 @@ func (v *ListValue) GetValues() interface{} {
 
 // ObjectValue implements Node, Value
 type ObjectValue struct {
-	Kind   string
-	Loc    *Location
-	Fields []*ObjectField
+	Kind     string
+	Loc      *Location
+	Fields   []*ObjectField
+	Comments *Trivia
 }
 
 func NewObjectValue(v *ObjectValue) *ObjectValue {
@@ -250,9 +360,10 @@ func NewObjectValue(v *ObjectValue) *ObjectValue {
 		v = &ObjectValue{}
 	}
 	return &ObjectValue{
-		Kind:   kinds.ObjectValue,
-		Loc:    v.Loc,
-		Fields: v.Fields,
+		Kind:     kinds.ObjectValue,
+		Loc:      v.Loc,
+		Fields:   v.Fields,
+		Comments: v.Comments,
 	}
 }
 
@@ -264,6 +375,14 @@ func (v *ObjectValue) GetLoc() *Location {
 	return v.Loc
 }
 
+func (v *ObjectValue) GetComments() *Trivia {
+	return v.Comments
+}
+
+func (v *ObjectValue) SetComments(comments *Trivia) {
+	v.Comments = comments
+}
+
 func (v *ObjectValue) GetValue() interface{} {
 	// TODO: verify ObjectValue.GetValue()
 	return v.Fields
@@ -271,10 +390,11 @@ func (v *ObjectValue) GetValue() interface{} {
 
 // ObjectField implements Node, Value
 type ObjectField struct {
-	Kind  string
-	Name  *Name
-	Loc   *Location
-	Value Value
+	Kind     string
+	Name     *Name
+	Loc      *Location
+	Value    Value
+	Comments *Trivia
 }
 
 func NewObjectField(f *ObjectField) *ObjectField {
@@ -282,10 +402,11 @@ func NewObjectField(f *ObjectField) *ObjectField {
 		f = &ObjectField{}
 	}
 	return &ObjectField{
-		Kind:  kinds.ObjectField,
-		Loc:   f.Loc,
-		Name:  f.Name,
-		Value: f.Value,
+		Kind:     kinds.ObjectField,
+		Loc:      f.Loc,
+		Name:     f.Name,
+		Value:    f.Value,
+		Comments: f.Comments,
 	}
 }
 
@@ -297,6 +418,14 @@ func (f *ObjectField) GetLoc() *Location {
 	return f.Loc
 }
 
+func (f *ObjectField) GetComments() *Trivia {
+	return f.Comments
+}
+
+func (f *ObjectField) SetComments(comments *Trivia) {
+	f.Comments = comments
+}
+
 func (f *ObjectField) GetValue() interface{} {
 	return f.Value
 }
@@ -0,0 +1,301 @@
+// Package astutil provides transformations of GraphQL documents. The given
+// documents are left untouched: the transformations are applied with the edit
+// support of visitor.Visit to clones of them.
+package astutil
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+const typenameField = "__typename"
+
+// Clone returns a deep copy of node, locations included.
+func Clone(node ast.Node) ast.Node {
+	if isNil(node) {
+		return node
+	}
+	result := visitor.Visit(node, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.Node)
+			if !ok {
+				return visitor.ActionNoChange, nil
+			}
+			// the copy is visited in place of node, its children being
+			// replaced by their own copies when it is left
+			return visitor.ActionUpdate, shallowCopy(node)
+		},
+	}, nil)
+	cloned, _ := result.(ast.Node)
+	return cloned
+}
+
+// shallowCopy copies the struct node points to, along with its location and
+// its comments, which are not visited.
+func shallowCopy(node ast.Node) ast.Node {
+	value := reflect.ValueOf(node)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return node
+	}
+	copied := reflect.New(value.Elem().Type())
+	copied.Elem().Set(value.Elem())
+	if loc := copied.Elem().FieldByName("Loc"); loc.IsValid() {
+		if location, ok := loc.Interface().(*ast.Location); ok && location != nil {
+			loc.Set(reflect.ValueOf(ast.NewLocation(location)))
+		}
+	}
+	if node, ok := copied.Interface().(ast.CommentedNode); ok && node.GetComments() != nil {
+		node.SetComments(copyTrivia(node.GetComments()))
+	}
+	return copied.Interface().(ast.Node)
+}
+
+func copyTrivia(trivia *ast.Trivia) *ast.Trivia {
+	copyComments := func(comments []*ast.Comment) []*ast.Comment {
+		if comments == nil {
+			return nil
+		}
+		copied := make([]*ast.Comment, len(comments))
+		for i, comment := range comments {
+			copied[i] = shallowCopy(comment).(*ast.Comment)
+		}
+		return copied
+	}
+	copied := &ast.Trivia{
+		Leading: copyComments(trivia.Leading),
+		Inner:   copyComments(trivia.Inner),
+	}
+	if trivia.Trailing != nil {
+		copied.Trailing = shallowCopy(trivia.Trailing).(*ast.Comment)
+	}
+	return copied
+}
+
+func isNil(node ast.Node) bool {
+	if node == nil {
+		return true
+	}
+	value := reflect.ValueOf(node)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+// ConcatAST returns a document made of the definitions of docs, in order. The
+// comments after the last definition of each of them are kept at the end of
+// the result.
+func ConcatAST(docs ...*ast.Document) *ast.Document {
+	definitions := []ast.Node{}
+	var comments *ast.Trivia
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		definitions = append(definitions, doc.Definitions...)
+		if doc.Comments != nil {
+			if comments == nil {
+				comments = &ast.Trivia{}
+			}
+			comments.Inner = append(comments.Inner, doc.Comments.Inner...)
+		}
+	}
+	return ast.NewDocument(&ast.Document{Definitions: definitions, Comments: comments})
+}
+
+// InlineFragments returns a copy of doc in which fragment spreads are
+// replaced by inline fragments holding the selections of the fragments, and
+// the fragment definitions removed. Spreads of unknown fragments, and spreads
+// of fragments within themselves, are kept.
+func InlineFragments(doc *ast.Document) *ast.Document {
+	fragments := fragmentDefinitions(doc)
+	// expanding holds the fragments being inlined, inlined the inline
+	// fragment made of each of them
+	expanding := map[string]bool{}
+	inlined := map[*ast.InlineFragment]string{}
+	result := visitor.Visit(Clone(doc), &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.FragmentDefinition: {
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionUpdate, nil
+				},
+			},
+			kinds.FragmentSpread: {
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					spread, ok := p.Node.(*ast.FragmentSpread)
+					if !ok || spread.Name == nil {
+						return visitor.ActionNoChange, nil
+					}
+					def, ok := fragments[spread.Name.Value]
+					if !ok || expanding[spread.Name.Value] {
+						return visitor.ActionNoChange, nil
+					}
+					expanding[spread.Name.Value] = true
+					fragment := ast.NewInlineFragment(&ast.InlineFragment{
+						Loc:          spread.Loc,
+						Directives:   spread.Directives,
+						SelectionSet: Clone(def.SelectionSet).(*ast.SelectionSet),
+					})
+					if def.TypeCondition != nil {
+						fragment.TypeCondition = Clone(def.TypeCondition).(*ast.Named)
+					}
+					inlined[fragment] = spread.Name.Value
+					// the spreads of the fragment are inlined as it is visited
+					return visitor.ActionUpdate, fragment
+				},
+			},
+			kinds.InlineFragment: {
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if fragment, ok := p.Node.(*ast.InlineFragment); ok {
+						if name, ok := inlined[fragment]; ok {
+							delete(expanding, name)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return result.(*ast.Document)
+}
+
+// SeparateOperations returns a document per operation of doc, keyed by
+// operation name, the name of an anonymous operation being "". Each document
+// holds the operation and the fragments it uses directly or indirectly, in
+// the order of doc. The documents share the definitions of doc.
+func SeparateOperations(doc *ast.Document) map[string]*ast.Document {
+	fragments := fragmentDefinitions(doc)
+	documents := map[string]*ast.Document{}
+	for _, def := range doc.Definitions {
+		operation, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		used := usedFragments(fragments, operation)
+		definitions := []ast.Node{}
+		for _, def := range doc.Definitions {
+			switch def := def.(type) {
+			case *ast.OperationDefinition:
+				if def == operation {
+					definitions = append(definitions, def)
+				}
+			case *ast.FragmentDefinition:
+				if def.Name != nil && used[def.Name.Value] {
+					definitions = append(definitions, def)
+				}
+			}
+		}
+		name := ""
+		if operation.Name != nil {
+			name = operation.Name.Value
+		}
+		documents[name] = ast.NewDocument(&ast.Document{Loc: doc.Loc, Definitions: definitions})
+	}
+	return documents
+}
+
+// RemoveUnusedFragments returns a copy of doc without the fragments used by
+// none of its operations, directly or indirectly.
+func RemoveUnusedFragments(doc *ast.Document) *ast.Document {
+	operations := []ast.Node{}
+	for _, def := range doc.Definitions {
+		if operation, ok := def.(*ast.OperationDefinition); ok {
+			operations = append(operations, operation)
+		}
+	}
+	used := usedFragments(fragmentDefinitions(doc), operations...)
+	result := visitor.Visit(Clone(doc), &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: {
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: {
+				Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if def, ok := p.Node.(*ast.FragmentDefinition); ok && def.Name != nil && !used[def.Name.Value] {
+						return visitor.ActionUpdate, nil
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}, nil)
+	if result == nil {
+		return Clone(doc).(*ast.Document)
+	}
+	return result.(*ast.Document)
+}
+
+// AddTypename returns a copy of doc in which a __typename field is added to
+// every selection set but the ones of operations, which are selected on
+// root types, and the ones already selecting it.
+func AddTypename(doc *ast.Document) *ast.Document {
+	result := visitor.Visit(Clone(doc), &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.SelectionSet: {
+				Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+					selectionSet, ok := p.Node.(*ast.SelectionSet)
+					if _, isOperation := p.Parent.(*ast.OperationDefinition); !ok || isOperation {
+						return visitor.ActionNoChange, nil
+					}
+					for _, selection := range selectionSet.Selections {
+						if field, ok := selection.(*ast.Field); ok && field.Alias == nil && field.Name != nil && field.Name.Value == typenameField {
+							return visitor.ActionNoChange, nil
+						}
+					}
+					selections := append([]ast.Selection{}, selectionSet.Selections...)
+					selections = append(selections, ast.NewField(&ast.Field{
+						Name: ast.NewName(&ast.Name{Value: typenameField}),
+					}))
+					return visitor.ActionUpdate, ast.NewSelectionSet(&ast.SelectionSet{
+						Loc:        selectionSet.Loc,
+						Selections: selections,
+					})
+				},
+			},
+		},
+	}, nil)
+	if result == nil {
+		return Clone(doc).(*ast.Document)
+	}
+	return result.(*ast.Document)
+}
+
+func fragmentDefinitions(doc *ast.Document) map[string]*ast.FragmentDefinition {
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			fragments[fragment.Name.Value] = fragment
+		}
+	}
+	return fragments
+}
+
+// usedFragments returns the names of the fragments spread in roots, or in the
+// fragments spread in them.
+func usedFragments(fragments map[string]*ast.FragmentDefinition, roots ...ast.Node) map[string]bool {
+	used := map[string]bool{}
+	for len(roots) > 0 {
+		root := roots[0]
+		roots = roots[1:]
+		visitor.Visit(root, &visitor.VisitorOptions{
+			KindFuncMap: map[string]visitor.NamedVisitFuncs{
+				kinds.FragmentSpread: {
+					Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+						spread, ok := p.Node.(*ast.FragmentSpread)
+						if !ok || spread.Name == nil || used[spread.Name.Value] {
+							return visitor.ActionNoChange, nil
+						}
+						used[spread.Name.Value] = true
+						if def, ok := fragments[spread.Name.Value]; ok {
+							roots = append(roots, def)
+						}
+						return visitor.ActionNoChange, nil
+					},
+				},
+			},
+		}, nil)
+	}
+	return used
+}
@@ -0,0 +1,206 @@
+package astutil_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+func parse(t *testing.T, query string) *ast.Document {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return doc
+}
+
+// print returns node printed and stripped of its ignored characters.
+func print(t *testing.T, node ast.Node) string {
+	printed, _ := printer.Print(node).(string)
+	stripped, err := astutil.StripIgnoredCharacters(printed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return stripped
+}
+
+// nodes returns the nodes of the tree rooted at node.
+func nodes(node ast.Node) map[ast.Node]bool {
+	found := map[ast.Node]bool{}
+	visitor.Visit(node, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			if node, ok := p.Node.(ast.Node); ok {
+				found[node] = true
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}, nil)
+	return found
+}
+
+func TestClone_CopiesEveryNode(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	doc := parse(t, string(b))
+	before := print(t, doc)
+
+	clone := astutil.Clone(doc).(*ast.Document)
+	if after := print(t, clone); after != before {
+		t.Fatalf("unexpected clone:\n%v\nexpected:\n%v", after, before)
+	}
+	if !reflect.DeepEqual(doc, clone) {
+		t.Fatalf("expected the clone to be deeply equal to the document")
+	}
+	original := nodes(doc)
+	for node := range nodes(clone) {
+		if original[node] {
+			t.Fatalf("expected no node to be shared, got %#v", node)
+		}
+	}
+	if clone.Loc == doc.Loc {
+		t.Fatalf("expected the location to be copied")
+	}
+	if print(t, doc) != before {
+		t.Fatalf("expected the document to be untouched")
+	}
+}
+
+func TestInlineFragments_ExpandsSpreads(t *testing.T) {
+	doc := parse(t, `
+		query Q {
+			hero {
+				...HeroFields @include(if: $withFields)
+				...Unknown
+			}
+		}
+		fragment HeroFields on Character {
+			name
+			friends { ...Names }
+		}
+		fragment Names on Character {
+			name
+			friends { ...Names }
+		}
+	`)
+	before := print(t, doc)
+	inlined := print(t, astutil.InlineFragments(doc))
+	expected := `query Q{hero{...on Character@include(if:$withFields){name friends{...on Character{name friends{...Names}}}}...Unknown}}`
+	if inlined != expected {
+		t.Fatalf("unexpected document:\n%v\nexpected:\n%v", inlined, expected)
+	}
+	if print(t, doc) != before {
+		t.Fatalf("expected the document to be untouched")
+	}
+}
+
+func TestSeparateOperations_KeepsTheFragmentsOfEachOperation(t *testing.T) {
+	doc := parse(t, `
+		fragment A on T { a ...B }
+		query One { ...A }
+		fragment B on T { b }
+		fragment C on T { ...C }
+		mutation Two { ...C }
+		{ x }
+	`)
+	documents := astutil.SeparateOperations(doc)
+	names := []string{}
+	for name := range documents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if expected := []string{"", "One", "Two"}; !reflect.DeepEqual(expected, names) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+	expected := map[string]string{
+		"":    `{x}`,
+		"One": `fragment A on T{a...B}query One{...A}fragment B on T{b}`,
+		"Two": `fragment C on T{...C}mutation Two{...C}`,
+	}
+	for name, document := range documents {
+		if printed := print(t, document); printed != expected[name] {
+			t.Fatalf("%q: expected %v, got %v", name, expected[name], printed)
+		}
+	}
+}
+
+func TestConcatAST_JoinsDefinitions(t *testing.T) {
+	doc := astutil.ConcatAST(parse(t, `{ a }`), parse(t, `fragment F on T { b }`), parse(t, `type T { b: Int }`))
+	if printed, expected := print(t, doc), `{a}fragment F on T{b}type T{b:Int}`; printed != expected {
+		t.Fatalf("expected %v, got %v", expected, printed)
+	}
+}
+
+func TestRemoveUnusedFragments_KeepsTheFragmentsOfOperations(t *testing.T) {
+	doc := parse(t, `
+		query Q { ...A }
+		fragment A on T { ...B }
+		fragment B on T { b }
+		fragment C on T { ...D }
+		fragment D on T { d }
+	`)
+	before := print(t, doc)
+	if printed, expected := print(t, astutil.RemoveUnusedFragments(doc)), `query Q{...A}fragment A on T{...B}fragment B on T{b}`; printed != expected {
+		t.Fatalf("expected %v, got %v", expected, printed)
+	}
+	if print(t, doc) != before {
+		t.Fatalf("expected the document to be untouched")
+	}
+}
+
+func TestAddTypename_SelectsTheTypeOfCompositeFields(t *testing.T) {
+	doc := parse(t, `
+		query Q {
+			hero {
+				name
+				friends { __typename name }
+				... on Droid { primaryFunction }
+				...F
+			}
+		}
+		fragment F on Character { kind: __typename }
+	`)
+	before := print(t, doc)
+	printed := print(t, astutil.AddTypename(doc))
+	expected := `query Q{hero{name friends{__typename name}...on Droid{primaryFunction __typename}...F __typename}}` +
+		`fragment F on Character{kind:__typename __typename}`
+	if printed != expected {
+		t.Fatalf("unexpected document:\n%v\nexpected:\n%v", printed, expected)
+	}
+	if print(t, doc) != before {
+		t.Fatalf("expected the document to be untouched")
+	}
+}
+
+func TestStripIgnoredCharacters(t *testing.T) {
+	tests := map[string]string{
+		"query Q ( $a : Int = 1 , $b : [ String ] ) { a ( x : 1 , y : $a ) }": `query Q($a:Int=1$b:[String]){a(x:1 y:$a)}`,
+		"{\n  # comment\n  a,\n  b\n  ...F\n  ... on T { c }\n}":              `{a b...F...on T{c}}`,
+		`{ a(s: "  spaced \"string\"  ", f: 1.5e3) }`:                         `{a(s:"  spaced \"string\"  "f:1.5e3)}`,
+		"{ a(s: \"\"\"\n    block \"quoted\"\n      string\n\"\"\") }":        `{a(s:"block \"quoted\"\n  string")}`,
+		`{ f(a: ["", "x", """y"""]) }`:                                        `{f(a:["" "x" "y"])}`,
+	}
+	for body, expected := range tests {
+		stripped, err := astutil.StripIgnoredCharacters(body)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stripped != expected {
+			t.Fatalf("%q: expected %v, got %v", body, expected, stripped)
+		}
+		if _, err := parser.Parse(parser.ParseParams{Source: stripped}); err != nil {
+			t.Fatalf("%q: expected a valid document, got %v", stripped, err)
+		}
+	}
+	if _, err := astutil.StripIgnoredCharacters(`{ a(s: "unterminated) }`); err == nil {
+		t.Fatalf("expected a syntax error")
+	}
+}
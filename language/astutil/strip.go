@@ -0,0 +1,81 @@
+package astutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/lexer"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// StripIgnoredCharacters returns body without the characters which do not
+// change its meaning: white space, line terminators, commas and comments.
+// A space is kept where two names or numbers would merge otherwise, and
+// between two strings, whose quotes would start a block string. Block
+// strings are turned into the equivalent quoted strings.
+func StripIgnoredCharacters(body string) (string, error) {
+	lex := lexer.Lex(source.NewSource(&source.Source{Body: []byte(body)}))
+	var sb strings.Builder
+	previous := lexer.Token{}
+	for position := 0; ; {
+		token, err := lex(position)
+		if err != nil {
+			return "", err
+		}
+		if token.Kind == lexer.EOF {
+			break
+		}
+		if isWordToken(previous.Kind) && isWordToken(token.Kind) ||
+			isStringToken(previous.Kind) && isStringToken(token.Kind) {
+			sb.WriteByte(' ')
+		}
+		if token.Kind == lexer.BLOCK_STRING {
+			sb.WriteString(quote(token.Value))
+		} else {
+			sb.WriteString(body[token.Start:token.End])
+		}
+		position = token.End
+		previous = token
+	}
+	return sb.String(), nil
+}
+
+func isWordToken(kind lexer.TokenKind) bool {
+	return kind == lexer.NAME || kind == lexer.INT || kind == lexer.FLOAT
+}
+
+func isStringToken(kind lexer.TokenKind) bool {
+	return kind == lexer.STRING || kind == lexer.BLOCK_STRING
+}
+
+// quote returns str as a GraphQL string literal.
+func quote(str string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
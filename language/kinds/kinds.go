@@ -6,6 +6,7 @@ const (
 
 	// Document
 	Document            = "Document"
+	Comment             = "Comment"
 	OperationDefinition = "OperationDefinition"
 	VariableDefinition  = "VariableDefinition"
 	Variable            = "Variable"
@@ -23,6 +24,7 @@ const (
 	FloatValue   = "FloatValue"
 	StringValue  = "StringValue"
 	BooleanValue = "BooleanValue"
+	NullValue    = "NullValue"
 	EnumValue    = "EnumValue"
 	ListValue    = "ListValue"
 	ObjectValue  = "ObjectValue"
@@ -52,8 +54,17 @@ const (
 	InputObjectDefinition = "InputObjectDefinition" // previously InputObjectTypeDefinition
 
 	// Types Extensions
-	TypeExtensionDefinition = "TypeExtensionDefinition"
+	SchemaExtensionDefinition      = "SchemaExtensionDefinition"
+	ScalarExtensionDefinition      = "ScalarExtensionDefinition"
+	TypeExtensionDefinition        = "TypeExtensionDefinition"
+	InterfaceExtensionDefinition   = "InterfaceExtensionDefinition"
+	UnionExtensionDefinition       = "UnionExtensionDefinition"
+	EnumExtensionDefinition        = "EnumExtensionDefinition"
+	InputObjectExtensionDefinition = "InputObjectExtensionDefinition"
 
 	// Directive Definitions
 	DirectiveDefinition = "DirectiveDefinition"
+
+	// Placeholder for the parts of a document that failed to parse
+	Invalid = "Invalid"
 )
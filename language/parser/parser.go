@@ -7,6 +7,7 @@ import (
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/lexer"
 	"github.com/graphql-go/graphql/language/source"
+	"github.com/graphql-go/graphql/language/visitor"
 )
 
 type parseFn func(parser *Parser) (interface{}, error)
@@ -38,8 +39,35 @@ func init() {
 type ParseOptions struct {
 	NoLocation bool
 	NoSource   bool
+
+	// Comments keeps the "#" comments of the document as trivia attached to
+	// its nodes, for instance to format the document without losing them.
+	// See ast.Trivia for the nodes they are attached to.
+	Comments bool
+
+	// MaxTokens aborts parsing with a syntax error once the document has more
+	// tokens than this. Zero means no limit.
+	MaxTokens int
+
+	// MaxDepth aborts parsing with a syntax error once selection sets, list
+	// and object values or list types are nested deeper than this. Zero means
+	// DefaultMaxDepth.
+	MaxDepth int
+
+	// Tolerant makes Parse recover from syntax errors instead of stopping at
+	// the first one. Definitions and selections that fail to parse are
+	// replaced by ast.Invalid nodes and arguments that fail to parse are left
+	// out, parsing resuming at the next definition, selection or argument.
+	// Parse then returns the partial document along with a
+	// gqlerrors.SyntaxErrors listing every error. Exceeding MaxTokens or
+	// MaxDepth still aborts parsing.
+	Tolerant bool
 }
 
+// DefaultMaxDepth is the nesting depth allowed when ParseOptions.MaxDepth is
+// not set, which keeps any document from exhausting the stack of the parser.
+const DefaultMaxDepth = 10000
+
 type ParseParams struct {
 	Source  interface{}
 	Options ParseOptions
@@ -51,6 +79,12 @@ type Parser struct {
 	Options  ParseOptions
 	PrevEnd  int
 	Token    lexer.Token
+
+	tokens   int
+	depth    int
+	errors   gqlerrors.SyntaxErrors
+	aborted  error
+	comments []*ast.Comment
 }
 
 func Parse(p ParseParams) (*ast.Document, error) {
@@ -70,6 +104,9 @@ func Parse(p ParseParams) (*ast.Document, error) {
 	if err != nil {
 		return nil, err
 	}
+	if len(parser.errors) > 0 {
+		return doc, parser.errors
+	}
 	return doc, nil
 }
 
@@ -111,15 +148,27 @@ func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
 	lexToken := lexer.Lex(s)
 	token, err := lexToken(0)
 	if err != nil {
-		return &Parser{}, err
+		if !opts.Tolerant {
+			return &Parser{}, err
+		}
+		parser := &Parser{
+			LexToken: lexToken,
+			Source:   s,
+			Options:  opts,
+		}
+		addError(parser, err)
+		return parser, skipToken(parser)
 	}
-	return &Parser{
+	parser := &Parser{
 		LexToken: lexToken,
 		Source:   s,
 		Options:  opts,
 		PrevEnd:  0,
 		Token:    token,
-	}, nil
+		tokens:   1,
+	}
+	collectComments(parser, 0, token.Start)
+	return parser, nil
 }
 
 /* Implements the parsing rules in the Document section. */
@@ -138,23 +187,40 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
 		} else if skp {
 			break
 		}
+		defStart := parser.Token.Start
 		switch kind := parser.Token.Kind; kind {
 		case lexer.BRACE_L:
 			item = parseOperationDefinition
 		case lexer.NAME, lexer.STRING, lexer.BLOCK_STRING:
 			item = parseTypeSystemDefinition
 		default:
-			return nil, unexpected(parser, lexer.Token{})
+			item = nil
 		}
-		if node, err = item(parser); err != nil {
-			return nil, err
+		if item != nil {
+			node, err = item(parser)
+		} else {
+			err = unexpected(parser, lexer.Token{})
+		}
+		if err != nil {
+			if !parser.Options.Tolerant || parser.aborted != nil {
+				return nil, err
+			}
+			addError(parser, err)
+			if err := skipDefinition(parser, defStart); err != nil {
+				return nil, err
+			}
+			node = ast.NewInvalid(&ast.Invalid{
+				Loc: loc(parser, defStart),
+			})
 		}
 		nodes = append(nodes, node)
 	}
-	return ast.NewDocument(&ast.Document{
+	doc := ast.NewDocument(&ast.Document{
 		Loc:         loc(parser, start),
 		Definitions: nodes,
-	}), nil
+	})
+	attachComments(parser, doc)
+	return doc, nil
 }
 
 /* Implements the parsing rules in the Operations section. */
@@ -316,10 +382,18 @@ func parseVariable(parser *Parser) (*ast.Variable, error) {
  */
 func parseSelectionSet(parser *Parser) (*ast.SelectionSet, error) {
 	start := parser.Token.Start
+	if err := enterNesting(parser); err != nil {
+		return nil, err
+	}
+	defer leaveNesting(parser)
 	selections := []ast.Selection{}
-	if iSelections, err := reverse(parser,
+	if iSelections, err := many(parser,
 		lexer.BRACE_L, parseSelection, lexer.BRACE_R,
-		true,
+		true, peekSelection, func(start int) interface{} {
+			return ast.NewInvalid(&ast.Invalid{
+				Loc: loc(parser, start),
+			})
+		},
 	); err != nil {
 		return nil, err
 	} else {
@@ -347,6 +421,11 @@ func parseSelection(parser *Parser) (interface{}, error) {
 	return parseField(parser)
 }
 
+// peekSelection determines if the next token may start a selection
+func peekSelection(parser *Parser) bool {
+	return peek(parser, lexer.NAME) || peek(parser, lexer.SPREAD)
+}
+
 /**
  * Field : Alias? Name Arguments? Directives? SelectionSet?
  *
@@ -400,9 +479,11 @@ func parseField(parser *Parser) (*ast.Field, error) {
 func parseArguments(parser *Parser) ([]*ast.Argument, error) {
 	arguments := []*ast.Argument{}
 	if peek(parser, lexer.PAREN_L) {
-		if iArguments, err := reverse(parser,
+		if iArguments, err := many(parser,
 			lexer.PAREN_L, parseArgument, lexer.PAREN_R,
-			true,
+			true, func(parser *Parser) bool {
+				return peek(parser, lexer.NAME)
+			}, nil,
 		); err != nil {
 			return arguments, err
 		} else {
@@ -571,9 +652,14 @@ func parseFragmentName(parser *Parser) (*ast.Name, error) {
 func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 	token := parser.Token
 	switch token.Kind {
-	case lexer.BRACKET_L:
-		return parseList(parser, isConst)
-	case lexer.BRACE_L:
+	case lexer.BRACKET_L, lexer.BRACE_L:
+		if err := enterNesting(parser); err != nil {
+			return nil, err
+		}
+		defer leaveNesting(parser)
+		if token.Kind == lexer.BRACKET_L {
+			return parseList(parser, isConst)
+		}
 		return parseObject(parser, isConst)
 	case lexer.INT:
 		if err := advance(parser); err != nil {
@@ -775,15 +861,17 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 	// [ String! ]!
 	switch token.Kind {
 	case lexer.BRACKET_L:
+		if err = enterNesting(parser); err != nil {
+			return nil, err
+		}
+		defer leaveNesting(parser)
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
 		if ttype, err = parseType(parser); err != nil {
 			return nil, err
 		}
-		fallthrough
-	case lexer.BRACKET_R:
-		if err = advance(parser); err != nil {
+		if _, err = expect(parser, lexer.BRACKET_R); err != nil {
 			return nil, err
 		}
 		ttype = ast.NewList(&ast.List{
@@ -794,6 +882,8 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 		if ttype, err = parseNamed(parser); err != nil {
 			return nil, err
 		}
+	default:
+		return nil, unexpected(parser, lexer.Token{})
 	}
 
 	// BANG must be executed
@@ -953,7 +1043,7 @@ func parseScalarTypeDefinition(parser *Parser) (ast.Node, error) {
 /**
  * ObjectTypeDefinition :
  *   Description?
- *   type Name ImplementsInterfaces? Directives? { FieldDefinition+ }
+ *   type Name ImplementsInterfaces? Directives? FieldsDefinition?
  */
 func parseObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -977,12 +1067,14 @@ func parseObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	iFields, err := reverse(parser,
-		lexer.BRACE_L, parseFieldDefinition, lexer.BRACE_R,
-		false,
-	)
-	if err != nil {
-		return nil, err
+	iFields := []interface{}{}
+	if peek(parser, lexer.BRACE_L) {
+		if iFields, err = reverse(parser,
+			lexer.BRACE_L, parseFieldDefinition, lexer.BRACE_R,
+			false,
+		); err != nil {
+			return nil, err
+		}
 	}
 	fields := []*ast.FieldDefinition{}
 	for _, iField := range iFields {
@@ -1144,7 +1236,7 @@ func parseInputValueDef(parser *Parser) (interface{}, error) {
 /**
  * InterfaceTypeDefinition :
  *   Description?
- *   interface Name Directives? { FieldDefinition+ }
+ *   interface Name Directives? FieldsDefinition?
  */
 func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1164,12 +1256,14 @@ func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	iFields, err := reverse(parser,
-		lexer.BRACE_L, parseFieldDefinition, lexer.BRACE_R,
-		false,
-	)
-	if err != nil {
-		return nil, err
+	iFields := []interface{}{}
+	if peek(parser, lexer.BRACE_L) {
+		if iFields, err = reverse(parser,
+			lexer.BRACE_L, parseFieldDefinition, lexer.BRACE_R,
+			false,
+		); err != nil {
+			return nil, err
+		}
 	}
 	fields := []*ast.FieldDefinition{}
 	for _, iField := range iFields {
@@ -1187,7 +1281,9 @@ func parseInterfaceTypeDefinition(parser *Parser) (ast.Node, error) {
 }
 
 /**
- * UnionTypeDefinition : Description? union Name Directives? = UnionMembers
+ * UnionTypeDefinition : Description? union Name Directives? UnionMemberTypes?
+ *
+ * UnionMemberTypes : = UnionMembers
  */
 func parseUnionTypeDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1207,13 +1303,13 @@ func parseUnionTypeDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.EQUALS)
-	if err != nil {
-		return nil, err
-	}
-	types, err := parseUnionMembers(parser)
-	if err != nil {
+	types := []*ast.Named{}
+	if skp, err := skip(parser, lexer.EQUALS); err != nil {
 		return nil, err
+	} else if skp {
+		if types, err = parseUnionMembers(parser); err != nil {
+			return nil, err
+		}
 	}
 	return ast.NewUnionDefinition(&ast.UnionDefinition{
 		Name:        name,
@@ -1247,7 +1343,8 @@ func parseUnionMembers(parser *Parser) ([]*ast.Named, error) {
 }
 
 /**
- * EnumTypeDefinition : Description? enum Name Directives? { EnumValueDefinition+ }
+ * EnumTypeDefinition :
+ *   Description? enum Name Directives? EnumValuesDefinition?
  */
 func parseEnumTypeDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1267,12 +1364,14 @@ func parseEnumTypeDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	iEnumValueDefs, err := reverse(parser,
-		lexer.BRACE_L, parseEnumValueDefinition, lexer.BRACE_R,
-		false,
-	)
-	if err != nil {
-		return nil, err
+	iEnumValueDefs := []interface{}{}
+	if peek(parser, lexer.BRACE_L) {
+		if iEnumValueDefs, err = reverse(parser,
+			lexer.BRACE_L, parseEnumValueDefinition, lexer.BRACE_R,
+			false,
+		); err != nil {
+			return nil, err
+		}
 	}
 	values := []*ast.EnumValueDefinition{}
 	for _, iEnumValueDef := range iEnumValueDefs {
@@ -1318,7 +1417,7 @@ func parseEnumValueDefinition(parser *Parser) (interface{}, error) {
 
 /**
  * InputObjectTypeDefinition :
- *   - Description? input Name Directives? { InputValueDefinition+ }
+ *   - Description? input Name Directives? InputFieldsDefinition?
  */
 func parseInputObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1338,12 +1437,14 @@ func parseInputObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	iInputValueDefinitions, err := reverse(parser,
-		lexer.BRACE_L, parseInputValueDef, lexer.BRACE_R,
-		false,
-	)
-	if err != nil {
-		return nil, err
+	iInputValueDefinitions := []interface{}{}
+	if peek(parser, lexer.BRACE_L) {
+		if iInputValueDefinitions, err = reverse(parser,
+			lexer.BRACE_L, parseInputValueDef, lexer.BRACE_R,
+			false,
+		); err != nil {
+			return nil, err
+		}
 	}
 	fields := []*ast.InputValueDefinition{}
 	for _, iInputValueDefinition := range iInputValueDefinitions {
@@ -1361,7 +1462,20 @@ func parseInputObjectTypeDefinition(parser *Parser) (ast.Node, error) {
 }
 
 /**
- * TypeExtensionDefinition : extend ObjectTypeDefinition
+ * TypeSystemExtension :
+ *   - SchemaExtension
+ *   - TypeExtension
+ *
+ * TypeExtension :
+ *   - ScalarTypeExtension
+ *   - ObjectTypeExtension
+ *   - InterfaceTypeExtension
+ *   - UnionTypeExtension
+ *   - EnumTypeExtension
+ *   - InputObjectTypeExtension
+ *
+ * Extensions have no description and must add at least one directive,
+ * interface, field, value or member.
  */
 func parseTypeExtensionDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -1369,14 +1483,136 @@ func parseTypeExtensionDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	keywordToken := parser.Token
+	if keywordToken.Kind != lexer.NAME {
+		return nil, unexpected(parser, keywordToken)
+	}
 
-	definition, err := parseObjectTypeDefinition(parser)
+	var (
+		definition ast.Node
+		empty      bool
+	)
+	switch keywordToken.Value {
+	case lexer.SCHEMA:
+		def, err := parseSchemaExtension(parser)
+		if err != nil {
+			return nil, err
+		}
+		definition = ast.NewSchemaExtensionDefinition(&ast.SchemaExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: def,
+		})
+		empty = len(def.Directives) == 0 && len(def.OperationTypes) == 0
+	case lexer.SCALAR:
+		def, err := parseScalarTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		scalar := def.(*ast.ScalarDefinition)
+		definition = ast.NewScalarExtensionDefinition(&ast.ScalarExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: scalar,
+		})
+		empty = len(scalar.Directives) == 0
+	case lexer.TYPE:
+		def, err := parseObjectTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		object := def.(*ast.ObjectDefinition)
+		definition = ast.NewTypeExtensionDefinition(&ast.TypeExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: object,
+		})
+		empty = len(object.Interfaces) == 0 && len(object.Directives) == 0 && len(object.Fields) == 0
+	case lexer.INTERFACE:
+		def, err := parseInterfaceTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		iface := def.(*ast.InterfaceDefinition)
+		definition = ast.NewInterfaceExtensionDefinition(&ast.InterfaceExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: iface,
+		})
+		empty = len(iface.Directives) == 0 && len(iface.Fields) == 0
+	case lexer.UNION:
+		def, err := parseUnionTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		union := def.(*ast.UnionDefinition)
+		definition = ast.NewUnionExtensionDefinition(&ast.UnionExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: union,
+		})
+		empty = len(union.Directives) == 0 && len(union.Types) == 0
+	case lexer.ENUM:
+		def, err := parseEnumTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		enum := def.(*ast.EnumDefinition)
+		definition = ast.NewEnumExtensionDefinition(&ast.EnumExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: enum,
+		})
+		empty = len(enum.Directives) == 0 && len(enum.Values) == 0
+	case lexer.INPUT:
+		def, err := parseInputObjectTypeDefinition(parser)
+		if err != nil {
+			return nil, err
+		}
+		input := def.(*ast.InputObjectDefinition)
+		definition = ast.NewInputObjectExtensionDefinition(&ast.InputObjectExtensionDefinition{
+			Loc:        loc(parser, start),
+			Definition: input,
+		})
+		empty = len(input.Directives) == 0 && len(input.Fields) == 0
+	default:
+		return nil, unexpected(parser, keywordToken)
+	}
+	if empty {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return definition, nil
+}
+
+/**
+ * SchemaExtension :
+ *   - extend schema Directives? { OperationTypeDefinition+ }
+ *   - extend schema Directives
+ */
+func parseSchemaExtension(parser *Parser) (*ast.SchemaDefinition, error) {
+	start := parser.Token.Start
+	_, err := expectKeyWord(parser, "schema")
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewTypeExtensionDefinition(&ast.TypeExtensionDefinition{
-		Loc:        loc(parser, start),
-		Definition: definition.(*ast.ObjectDefinition),
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	operationTypes := []*ast.OperationTypeDefinition{}
+	if peek(parser, lexer.BRACE_L) {
+		operationTypesI, err := reverse(
+			parser,
+			lexer.BRACE_L, parseOperationTypeDefinition, lexer.BRACE_R,
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range operationTypesI {
+			if op, ok := op.(*ast.OperationTypeDefinition); ok {
+				operationTypes = append(operationTypes, op)
+			}
+		}
+	}
+	return ast.NewSchemaDefinition(&ast.SchemaDefinition{
+		OperationTypes: operationTypes,
+		Directives:     directives,
+		Loc:            loc(parser, start),
 	}), nil
 }
 
@@ -1497,9 +1733,145 @@ func advance(parser *Parser) error {
 		return err
 	}
 	parser.Token = token
+	collectComments(parser, parser.PrevEnd, token.Start)
+	if token.Kind != lexer.EOF {
+		parser.tokens++
+		if max := parser.Options.MaxTokens; max > 0 && parser.tokens > max {
+			descp := fmt.Sprintf("Document contains more than %d tokens. Parsing aborted.", max)
+			parser.aborted = gqlerrors.NewSyntaxError(parser.Source, token.Start, descp)
+			return parser.aborted
+		}
+	}
+	return nil
+}
+
+// collectComments keeps the comments found between two tokens when parsing
+// with the Comments option. Only ignored tokens lie between them, of which a
+// "#" can only start a comment running to the end of the line.
+func collectComments(parser *Parser, start, end int) {
+	if !parser.Options.Comments {
+		return
+	}
+	body := parser.Source.Body
+	for i := start; i < end; i++ {
+		if body[i] != '#' {
+			continue
+		}
+		commentStart := i
+		for i < end && body[i] != '\n' && body[i] != '\r' {
+			i++
+		}
+		comment := ast.NewComment(&ast.Comment{
+			Value: string(body[commentStart+1 : i]),
+		})
+		if !parser.Options.NoSource {
+			comment.Loc = ast.NewLocation(&ast.Location{Start: commentStart, End: i, Source: parser.Source})
+		} else {
+			comment.Loc = ast.NewLocation(&ast.Location{Start: commentStart, End: i})
+		}
+		parser.comments = append(parser.comments, comment)
+	}
+}
+
+// attachComments attaches the comments collected while parsing doc to its
+// nodes, which are walked in source order. A comment preceding an item, that
+// is a definition, selection, argument, field or list value, is attached to
+// the outermost item it precedes, and a comment following an item on its last
+// line to the outermost item it follows. The other comments are attached to
+// the innermost node enclosing them, or to the document past its definitions.
+func attachComments(parser *Parser, doc *ast.Document) {
+	if !parser.Options.Comments {
+		return
+	}
+	comments := parser.comments
+	trivia := func(node ast.CommentedNode) *ast.Trivia {
+		if node.GetComments() == nil {
+			node.SetComments(&ast.Trivia{})
+		}
+		return node.GetComments()
+	}
+	// trailing is the item comments[0] follows on the same line, if any,
+	// which it is attached to once no enclosing item ends there too
+	var trailing ast.CommentedNode
+	attachTrailing := func() {
+		if trailing != nil {
+			trivia(trailing).Trailing = comments[0]
+			comments = comments[1:]
+			trailing = nil
+		}
+	}
+	visitor.Visit(doc, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.CommentedNode)
+			if _, inList := p.Key.(int); !ok || !inList || node.GetLoc() == nil {
+				return visitor.ActionNoChange, nil
+			}
+			attachTrailing()
+			for len(comments) > 0 && comments[0].Loc.Start < node.GetLoc().Start {
+				trivia(node).Leading = append(trivia(node).Leading, comments[0])
+				comments = comments[1:]
+			}
+			return visitor.ActionNoChange, nil
+		},
+		Leave: func(p visitor.VisitFuncParams) (string, interface{}) {
+			node, ok := p.Node.(ast.CommentedNode)
+			if !ok || node.GetLoc() == nil {
+				return visitor.ActionNoChange, nil
+			}
+			end := node.GetLoc().End
+			if _, inList := p.Key.(int); inList && len(comments) > 0 && followsOnSameLine(parser, end, comments[0]) {
+				trailing = node
+				return visitor.ActionNoChange, nil
+			}
+			attachTrailing()
+			for len(comments) > 0 && comments[0].Loc.Start < end {
+				trivia(node).Inner = append(trivia(node).Inner, comments[0])
+				comments = comments[1:]
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}, nil)
+	attachTrailing()
+	if len(comments) > 0 {
+		trivia(doc).Inner = append(trivia(doc).Inner, comments...)
+	}
+}
+
+// followsOnSameLine determines if comment follows end with nothing but spaces
+// and commas in between.
+func followsOnSameLine(parser *Parser, end int, comment *ast.Comment) bool {
+	if comment.Loc.Start < end {
+		return false
+	}
+	for _, c := range parser.Source.Body[end:comment.Loc.Start] {
+		if c != ' ' && c != '\t' && c != ',' {
+			return false
+		}
+	}
+	return true
+}
+
+// enterNesting accounts for a nested selection set, value or type starting at
+// the current token. The depth is counted rather than derived from the
+// recursion, so that the document is rejected before the stack grows.
+func enterNesting(parser *Parser) error {
+	parser.depth++
+	max := parser.Options.MaxDepth
+	if max <= 0 {
+		max = DefaultMaxDepth
+	}
+	if parser.depth > max {
+		descp := fmt.Sprintf("Document is nested deeper than %d levels. Parsing aborted.", max)
+		parser.aborted = gqlerrors.NewSyntaxError(parser.Source, parser.Token.Start, descp)
+		return parser.aborted
+	}
 	return nil
 }
 
+func leaveNesting(parser *Parser) {
+	parser.depth--
+}
+
 // lookahead retrieves the next token
 func lookahead(parser *Parser) (lexer.Token, error) {
 	return parser.LexToken(parser.Token.End)
@@ -1562,7 +1934,7 @@ func unexpectedEmpty(parser *Parser, beginLoc int, openKind, closeKind lexer.Tok
 	return gqlerrors.NewSyntaxError(parser.Source, beginLoc, description)
 }
 
-//  Returns list of parse nodes, determined by
+// Returns list of parse nodes, determined by
 // the parseFn. This list begins with a lex token of openKind
 // and ends with a lex token of closeKind. Advances the parser
 // to the next lex token after the closing token.
@@ -1590,3 +1962,156 @@ func reverse(parser *Parser, openKind lexer.TokenKind, parseFn parseFn, closeKin
 	}
 	return nodes, nil
 }
+
+// Returns the list of parse nodes like reverse. When parsing tolerantly, an
+// item that fails to parse is recorded as an error, its tokens are skipped up
+// to the next token for which isItem is true or to a closing token, and it is
+// replaced by the node placeholder returns for its start, if any. A list
+// closed by another closing token or by the end of the document is recorded
+// as an error and ends there.
+func many(parser *Parser, openKind lexer.TokenKind, parseFn parseFn, closeKind lexer.TokenKind, zinteger bool,
+	isItem func(parser *Parser) bool, placeholder func(start int) interface{}) ([]interface{}, error) {
+	if !parser.Options.Tolerant {
+		return reverse(parser, openKind, parseFn, closeKind, zinteger)
+	}
+	token, err := expect(parser, openKind)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []interface{}
+	// failed records that an item failed to parse or that the list is not
+	// closed, which explains an empty list better than unexpectedEmpty
+	failed := false
+	for {
+		if peek(parser, closeKind) {
+			if err := skipToken(parser); err != nil {
+				return nil, err
+			}
+			break
+		}
+		if peek(parser, lexer.EOF) || peekClosing(parser) {
+			_, err := expect(parser, closeKind)
+			addError(parser, err)
+			failed = true
+			break
+		}
+		start := parser.Token.Start
+		node, err := parseFn(parser)
+		if err != nil {
+			if parser.aborted != nil {
+				return nil, parser.aborted
+			}
+			addError(parser, err)
+			failed = true
+			if err := skipItem(parser, start, isItem); err != nil {
+				return nil, err
+			}
+			if placeholder == nil {
+				continue
+			}
+			node = placeholder(start)
+		}
+		nodes = append(nodes, node)
+	}
+	if zinteger && len(nodes) == 0 && !failed {
+		addError(parser, unexpectedEmpty(parser, token.Start, openKind, closeKind))
+	}
+	return nodes, nil
+}
+
+// skipItem skips the tokens of a list item that failed to parse, along with
+// the brackets they open, up to a closing token or to the next token for
+// which isItem is true. The token the item started with is always skipped so
+// that parsing moves on.
+func skipItem(parser *Parser, start int, isItem func(parser *Parser) bool) error {
+	depth := 0
+	for !peek(parser, lexer.EOF) {
+		if depth == 0 && (peekClosing(parser) || parser.Token.Start != start && isItem(parser)) {
+			return nil
+		}
+		if peekOpening(parser) {
+			depth++
+		} else if peekClosing(parser) {
+			depth--
+		}
+		if err := skipToken(parser); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipDefinition skips the tokens of a definition that failed to parse, up to
+// and including the brace closing it or up to the keyword of the next
+// definition.
+func skipDefinition(parser *Parser, start int) error {
+	depth := 0
+	for !peek(parser, lexer.EOF) {
+		if depth == 0 && parser.Token.Start != start && peekDefinition(parser) {
+			return nil
+		}
+		closesDefinition := false
+		if peekOpening(parser) {
+			depth++
+		} else if peekClosing(parser) {
+			closesDefinition = depth <= 1 && peek(parser, lexer.BRACE_R)
+			if depth > 0 {
+				depth--
+			}
+		}
+		if err := skipToken(parser); err != nil {
+			return err
+		}
+		if closesDefinition {
+			return nil
+		}
+	}
+	return nil
+}
+
+// peekDefinition determines if the next token may start a type system,
+// operation or fragment definition other than a query shorthand
+func peekDefinition(parser *Parser) bool {
+	if peek(parser, lexer.NAME) {
+		_, ok := tokenDefinitionFn[parser.Token.Value]
+		return ok
+	}
+	return peekDescription(parser)
+}
+
+func peekOpening(parser *Parser) bool {
+	return peek(parser, lexer.BRACE_L) || peek(parser, lexer.PAREN_L) || peek(parser, lexer.BRACKET_L)
+}
+
+func peekClosing(parser *Parser) bool {
+	return peek(parser, lexer.BRACE_R) || peek(parser, lexer.PAREN_R) || peek(parser, lexer.BRACKET_R)
+}
+
+// skipToken moves to the next token while recovering from a syntax error.
+// Characters that cannot be lexed are recorded as an error and skipped.
+func skipToken(parser *Parser) error {
+	end := parser.Token.End
+	for {
+		err := advance(parser)
+		if err == nil || parser.aborted != nil {
+			return err
+		}
+		addError(parser, err)
+		end++
+		parser.Token.End = end
+	}
+}
+
+// addError records a syntax error met while parsing tolerantly. An error
+// repeating the previous one, as lexing again past a bad character does, is
+// recorded once.
+func addError(parser *Parser, err error) {
+	syntaxErr, ok := err.(*gqlerrors.Error)
+	if !ok {
+		return
+	}
+	if n := len(parser.errors); n > 0 && parser.errors[n-1].Message == syntaxErr.Message {
+		return
+	}
+	parser.errors = append(parser.errors, syntaxErr)
+}
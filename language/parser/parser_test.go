@@ -165,6 +165,16 @@ func TestParsesConstantDefaultValues(t *testing.T) {
 	testErrorMessage(t, test)
 }
 
+func TestDoesNotAcceptMissingTypes(t *testing.T) {
+	for _, test := range []errorMessageTest{
+		{`query Foo($x: ) { field }`, `Syntax Error GraphQL (1:15) Unexpected )`, false},
+		{`query Foo($x: [Int) { field }`, `Syntax Error GraphQL (1:19) Expected ], found )`, false},
+		{`query Foo($x: ]) { field }`, `Syntax Error GraphQL (1:15) Unexpected ]`, false},
+	} {
+		testErrorMessage(t, test)
+	}
+}
+
 func TestDoesNotAcceptFragmentsNameOn(t *testing.T) {
 	test := errorMessageTest{
 		`fragment on on on { on }`,
@@ -745,6 +755,225 @@ func TestDoesNotAcceptStringAsDefinition(t *testing.T) {
 	testErrorMessage(t, test)
 }
 
+func TestParseLimitsTokenCount(t *testing.T) {
+	opts := ParseOptions{MaxTokens: 5}
+	if _, err := Parse(ParseParams{Source: `{ a b c }`, Options: opts}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := Parse(ParseParams{Source: `{ a b c d }`, Options: opts})
+	checkError(t, err, &gqlerrors.Error{
+		Message:   "Syntax Error GraphQL (1:11) Document contains more than 5 tokens. Parsing aborted.\n\n1: { a b c d }\n             ^\n",
+		Positions: []int{10},
+	})
+}
+
+func TestParseLimitsNestingDepth(t *testing.T) {
+	tests := []struct {
+		source   string
+		maxDepth int
+		column   int
+	}{
+		{`{ a { b { c } } }`, 2, 9},
+		{`{ a(v: [[[1]]]) }`, 3, 10},
+		{`{ a(v: {b: {c: 1}}) }`, 2, 12},
+		{`query ($v: [[Int]]) { a }`, 1, 13},
+	}
+	for _, test := range tests {
+		opts := ParseOptions{MaxDepth: test.maxDepth + 1}
+		if _, err := Parse(ParseParams{Source: test.source, Options: opts}); err != nil {
+			t.Fatalf("%v: unexpected error: %v", test.source, err)
+		}
+		opts.MaxDepth = test.maxDepth
+		_, err := Parse(ParseParams{Source: test.source, Options: opts})
+		expected := fmt.Sprintf("Syntax Error GraphQL (1:%d) Document is nested deeper than %d levels. Parsing aborted.", test.column, test.maxDepth)
+		checkErrorMessage(t, err, expected)
+	}
+}
+
+func TestParseLimitsNestingDepthByDefault(t *testing.T) {
+	depth := DefaultMaxDepth + 1
+	for _, source := range []string{
+		strings.Repeat("{ a ", depth) + strings.Repeat("}", depth),
+		"{ a(v: " + strings.Repeat("[", depth) + strings.Repeat("]", depth) + ") }",
+	} {
+		_, err := Parse(ParseParams{Source: source})
+		if err == nil || !strings.Contains(err.Error(), "Document is nested deeper than 10000 levels.") {
+			t.Fatalf("expected nesting error, got: %v", err)
+		}
+	}
+	if _, err := ParseValue(ParseParams{Source: strings.Repeat("[", depth)}); err == nil {
+		t.Fatalf("expected nesting error")
+	}
+}
+
+func TestParseAttachesCommentsWithTheCommentsOption(t *testing.T) {
+	source := "# first\n{\n  a # second\n  b(x: [1, # third\n 2],# fourth\n)\n  # fifth\n}\n#last"
+	doc, err := Parse(ParseParams{Source: source, Options: ParseOptions{Comments: true, NoSource: true}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	comment := func(value string, start, end int) *ast.Comment {
+		return ast.NewComment(&ast.Comment{Value: value, Loc: &ast.Location{Start: start, End: end}})
+	}
+	op := doc.Definitions[0].(*ast.OperationDefinition)
+	a := op.SelectionSet.Selections[0].(*ast.Field)
+	b := op.SelectionSet.Selections[1].(*ast.Field)
+	list := b.Arguments[0].Value.(*ast.ListValue)
+	tests := []struct {
+		node     ast.CommentedNode
+		expected *ast.Trivia
+	}{
+		{op, &ast.Trivia{Leading: []*ast.Comment{comment(" first", 0, 7)}}},
+		{a, &ast.Trivia{Trailing: comment(" second", 14, 22)}},
+		{list.Values[0].(*ast.IntValue), &ast.Trivia{Trailing: comment(" third", 34, 41)}},
+		{list.Values[1].(*ast.IntValue), nil},
+		{b.Arguments[0], &ast.Trivia{Trailing: comment(" fourth", 46, 54)}},
+		{b, nil},
+		{op.SelectionSet, &ast.Trivia{Inner: []*ast.Comment{comment(" fifth", 59, 66)}}},
+		{doc, &ast.Trivia{Inner: []*ast.Comment{comment("last", 69, 74)}}},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.node.GetComments(), test.expected) {
+			t.Fatalf("unexpected comments of %v, expected: %+v, got: %+v", test.node.GetKind(), test.expected, test.node.GetComments())
+		}
+	}
+
+	doc, err = Parse(ParseParams{Source: source})
+	if err != nil || doc.Comments != nil || doc.Definitions[0].(*ast.OperationDefinition).Comments != nil {
+		t.Fatalf("expected comments to be discarded, got: %v, %v", doc.Comments, err)
+	}
+}
+
+func parseTolerant(t *testing.T, source string) (*ast.Document, []string) {
+	doc, err := Parse(ParseParams{Source: source, Options: ParseOptions{Tolerant: true, NoSource: true}})
+	if doc == nil {
+		t.Fatalf("expected a partial document, got error: %v", err)
+	}
+	messages := []string{}
+	if err != nil {
+		errs, ok := err.(gqlerrors.SyntaxErrors)
+		if !ok {
+			t.Fatalf("expected syntax errors, got: %#v", err)
+		}
+		for _, err := range errs {
+			messages = append(messages, strings.SplitN(err.Message, "\n", 2)[0])
+		}
+	}
+	return doc, messages
+}
+
+func TestParseTolerantRecoversAtSelectionsArgumentsAndDefinitions(t *testing.T) {
+	doc, messages := parseTolerant(t, `query A {
+  a(x: 1, y: )
+  ... on { e }
+  c
+}
+query B($v) { d }
+fragment F on T { e }`)
+	expectedMessages := []string{
+		"Syntax Error GraphQL (2:14) Unexpected )",
+		"Syntax Error GraphQL (3:10) Expected Name, found {",
+		"Syntax Error GraphQL (6:11) Expected :, found )",
+	}
+	if !reflect.DeepEqual(messages, expectedMessages) {
+		t.Fatalf("unexpected errors, expected: %v, got: %v", expectedMessages, messages)
+	}
+	if len(doc.Definitions) != 3 {
+		t.Fatalf("expected 3 definitions, got: %v", len(doc.Definitions))
+	}
+	queryA, ok := doc.Definitions[0].(*ast.OperationDefinition)
+	if !ok {
+		t.Fatalf("expected query A, got: %#v", doc.Definitions[0])
+	}
+	selections := queryA.SelectionSet.Selections
+	if len(selections) != 3 {
+		t.Fatalf("expected 3 selections, got: %v", len(selections))
+	}
+	if field, ok := selections[0].(*ast.Field); !ok || len(field.Arguments) != 1 || field.Arguments[0].Name.Value != "x" {
+		t.Fatalf("expected field a with its valid argument, got: %#v", selections[0])
+	}
+	if invalid, ok := selections[1].(*ast.Invalid); !ok || invalid.Loc.Start != 27 || invalid.Loc.End != 39 {
+		t.Fatalf("expected the inline fragment to be invalid, got: %#v", selections[1])
+	}
+	if field, ok := selections[2].(*ast.Field); !ok || field.Name.Value != "c" {
+		t.Fatalf("expected field c, got: %#v", selections[2])
+	}
+	if invalid, ok := doc.Definitions[1].(*ast.Invalid); !ok || invalid.Loc.Start != 46 || invalid.Loc.End != 63 {
+		t.Fatalf("expected query B to be invalid, got: %#v", doc.Definitions[1])
+	}
+	if _, ok := doc.Definitions[2].(*ast.FragmentDefinition); !ok {
+		t.Fatalf("expected fragment F, got: %#v", doc.Definitions[2])
+	}
+}
+
+func TestParseTolerantRecordsUnclosedListsAndBadCharacters(t *testing.T) {
+	doc, messages := parseTolerant(t, `{ a(x: 1 } query Q { b % } { c {} }`)
+	expectedMessages := []string{
+		"Syntax Error GraphQL (1:10) Expected ), found }",
+		"Syntax Error GraphQL (1:24) Unexpected character \"%\".",
+		"Syntax Error GraphQL (1:32) Unexpected empty IN {}",
+	}
+	if !reflect.DeepEqual(messages, expectedMessages) {
+		t.Fatalf("unexpected errors, expected: %v, got: %v", expectedMessages, messages)
+	}
+	if len(doc.Definitions) != 3 {
+		t.Fatalf("expected 3 definitions, got: %v", len(doc.Definitions))
+	}
+	for _, def := range doc.Definitions {
+		if _, ok := def.(*ast.OperationDefinition); !ok {
+			t.Fatalf("expected operations, got: %#v", def)
+		}
+	}
+	if _, ok := doc.Definitions[1].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Invalid); !ok {
+		t.Fatalf("expected the selection of Q to be invalid")
+	}
+}
+
+func TestParseTolerantReportsBrokenItemsOfEmptyListsOnce(t *testing.T) {
+	_, messages := parseTolerant(t, `{ a(x: [1, ) }`)
+	expectedMessages := []string{
+		"Syntax Error GraphQL (1:12) Unexpected )",
+	}
+	if !reflect.DeepEqual(messages, expectedMessages) {
+		t.Fatalf("unexpected errors, expected: %v, got: %v", expectedMessages, messages)
+	}
+}
+
+func TestParseTolerantReportsUnclosedEmptyListsOnce(t *testing.T) {
+	tests := map[string]string{
+		"mutation { ":   "Syntax Error GraphQL (1:12) Expected }, found EOF",
+		"{ a(x: 1) { )": "Syntax Error GraphQL (1:13) Expected }, found )",
+	}
+	for source, expected := range tests {
+		_, messages := parseTolerant(t, source)
+		if len(messages) == 0 || messages[0] != expected {
+			t.Fatalf("%q: unexpected errors, expected: %v, got: %v", source, expected, messages)
+		}
+		for _, message := range messages {
+			if strings.Contains(message, "Unexpected empty") {
+				t.Fatalf("%q: unexpected errors: %v", source, messages)
+			}
+		}
+	}
+}
+
+func TestParseTolerantReturnsNoErrorForValidDocuments(t *testing.T) {
+	doc, messages := parseTolerant(t, `{ a(x: 1) { b } }`)
+	if len(messages) != 0 || len(doc.Definitions) != 1 {
+		t.Fatalf("unexpected result: %v, %v", doc, messages)
+	}
+}
+
+func TestParseTolerantStillAbortsOverLimits(t *testing.T) {
+	doc, err := Parse(ParseParams{
+		Source:  `{ a b c d }`,
+		Options: ParseOptions{Tolerant: true, MaxTokens: 3},
+	})
+	if doc != nil || err == nil || !strings.Contains(err.Error(), "Document contains more than 3 tokens.") {
+		t.Fatalf("expected parsing to be aborted, got: %v, %v", doc, err)
+	}
+}
+
 type errorMessageTest struct {
 	source          interface{}
 	expectedMessage string
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/graphql-go/graphql/gqlerrors"
@@ -829,3 +830,122 @@ input Hello {
 		t.Fatalf("unexpected document, expected: %v, got: %v", expectedError, err)
 	}
 }
+
+func TestSchemaParser_EnumExtension(t *testing.T) {
+
+	body := `
+extend enum Hello {
+  WORLD
+}`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(1, 30),
+		Definitions: []ast.Node{
+			ast.NewEnumExtensionDefinition(&ast.EnumExtensionDefinition{
+				Loc: testLoc(1, 30),
+				Definition: ast.NewEnumDefinition(&ast.EnumDefinition{
+					Loc: testLoc(8, 30),
+					Name: ast.NewName(&ast.Name{
+						Value: "Hello",
+						Loc:   testLoc(13, 18),
+					}),
+					Directives: []*ast.Directive{},
+					Values: []*ast.EnumValueDefinition{
+						ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+							Loc: testLoc(23, 28),
+							Name: ast.NewName(&ast.Name{
+								Value: "WORLD",
+								Loc:   testLoc(23, 28),
+							}),
+							Directives: []*ast.Directive{},
+						}),
+					},
+				}),
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_SchemaExtensionWithoutOperationTypes(t *testing.T) {
+
+	body := `extend schema @onSchema`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 23),
+		Definitions: []ast.Node{
+			ast.NewSchemaExtensionDefinition(&ast.SchemaExtensionDefinition{
+				Loc: testLoc(0, 23),
+				Definition: ast.NewSchemaDefinition(&ast.SchemaDefinition{
+					Loc: testLoc(7, 23),
+					Directives: []*ast.Directive{
+						ast.NewDirective(&ast.Directive{
+							Loc: testLoc(14, 23),
+							Name: ast.NewName(&ast.Name{
+								Value: "onSchema",
+								Loc:   testLoc(15, 23),
+							}),
+							Arguments: []*ast.Argument{},
+						}),
+					},
+					OperationTypes: []*ast.OperationTypeDefinition{},
+				}),
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_AllTypeSystemExtensions(t *testing.T) {
+
+	body := `
+extend schema { mutation: Mutation }
+extend scalar Hello @onScalar
+extend type Hello implements World
+extend interface Hello { world: String }
+extend union Hello = World
+extend enum Hello @onEnum
+extend input Hello { world: String }
+`
+	astDoc := parse(t, body)
+	expectedKinds := []string{
+		"SchemaExtensionDefinition",
+		"ScalarExtensionDefinition",
+		"TypeExtensionDefinition",
+		"InterfaceExtensionDefinition",
+		"UnionExtensionDefinition",
+		"EnumExtensionDefinition",
+		"InputObjectExtensionDefinition",
+	}
+	kinds := []string{}
+	for _, definition := range astDoc.Definitions {
+		kinds = append(kinds, definition.GetKind())
+	}
+	if !reflect.DeepEqual(kinds, expectedKinds) {
+		t.Fatalf("unexpected definitions, expected: %v, got: %v", expectedKinds, kinds)
+	}
+}
+
+func TestSchemaParser_EmptyExtensionsShouldFail(t *testing.T) {
+	tests := map[string]string{
+		`extend schema`:             `Syntax Error GraphQL (1:14) Unexpected EOF`,
+		`extend scalar Hello`:       `Syntax Error GraphQL (1:20) Unexpected EOF`,
+		`extend type Hello {}`:      `Syntax Error GraphQL (1:21) Unexpected EOF`,
+		`extend union Hello`:        `Syntax Error GraphQL (1:19) Unexpected EOF`,
+		`extend directive @hello`:   `Syntax Error GraphQL (1:8) Unexpected Name "directive"`,
+		`"Hello" extend type Hello`: `Syntax Error GraphQL (1:1) Expected "extend", found String "Hello"`,
+	}
+	for body, expected := range tests {
+		_, err := Parse(ParseParams{Source: body})
+		if err == nil {
+			t.Fatalf("%v: expected error", body)
+		}
+		if message := err.(*gqlerrors.Error).Message; !strings.HasPrefix(message, expected) {
+			t.Fatalf("%v: unexpected error, expected: %v, got: %v", body, expected, message)
+		}
+	}
+}
@@ -0,0 +1,651 @@
+package printer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DescriptionStyle selects how Config prints descriptions.
+type DescriptionStyle int
+
+const (
+	// BlockDescriptions prints descriptions as block strings, like Print.
+	BlockDescriptions DescriptionStyle = iota
+
+	// StringDescriptions prints descriptions fitting on a single line as
+	// strings and the other ones as block strings.
+	StringDescriptions
+
+	// PreserveDescriptions prints descriptions as strings or block strings
+	// the way they are written in the source of the document.
+	PreserveDescriptions
+)
+
+// Config prints documents, operations as well as type system definitions,
+// with a configurable layout.
+//
+// Unlike Print, it keeps the comments attached to the nodes of documents
+// parsed with the Comments parse option: the leading comments of a node are
+// printed on their own lines before it, its trailing comment after it on the
+// same line, and its inner comments on their own lines after its last item.
+// The blank lines separating the items of a selection set or of a type
+// definition are kept as well.
+type Config struct {
+	// Indent is the number of spaces of each indentation level, 2 when zero.
+	Indent int
+
+	// MaxLineWidth is the width past which the arguments of a field, the
+	// variable definitions of an operation and the arguments of field and
+	// directive definitions are printed one per line. Zero means no limit.
+	MaxLineWidth int
+
+	// Descriptions is the style descriptions are printed with.
+	Descriptions DescriptionStyle
+}
+
+// Print returns the text of node laid out according to the configuration.
+func (c Config) Print(node ast.Node) string {
+	indent := c.Indent
+	if indent <= 0 {
+		indent = 2
+	}
+	p := &configPrinter{
+		Config: c,
+		indent: strings.Repeat(" ", indent),
+		placed: map[ast.Node]bool{},
+	}
+	if !isNil(node) && node.GetLoc() != nil && node.GetLoc().Source != nil {
+		p.body = node.GetLoc().Source.Body
+	}
+	if _, ok := node.(*ast.Document); ok {
+		return p.print(node)
+	}
+	return p.item(node)
+}
+
+// configPrinter holds the state of Config.Print: the indentation level of the
+// line being printed, the nodes whose inner comments are printed and the inner
+// comments left to print after the item being printed.
+type configPrinter struct {
+	Config
+	indent string
+	depth  int
+	placed map[ast.Node]bool
+	stray  []*ast.Comment
+	body   []byte
+}
+
+// print prints node, leaving the inner comments it has no place for to the
+// item it belongs to.
+func (p *configPrinter) print(node ast.Node) string {
+	if isNil(node) {
+		return ""
+	}
+	str := p.printNode(node)
+	if comments := commentsOf(node); comments != nil && len(comments.Inner) > 0 && !p.placed[node] {
+		p.stray = append(p.stray, comments.Inner...)
+	}
+	return str
+}
+
+func (p *configPrinter) printNode(node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.Document:
+		return p.document(node)
+	case *ast.Name:
+		return node.Value
+	case *ast.Invalid:
+		if node.Loc != nil && node.Loc.Source != nil {
+			return string(node.Loc.Source.Body[node.Loc.Start:node.Loc.End])
+		}
+		return ""
+
+	// Operations
+	case *ast.OperationDefinition:
+		name := ""
+		if node.Name != nil {
+			name = node.Name.Value
+		}
+		directives := p.directives(node.Directives)
+		if name == "" && directives == "" && len(node.VariableDefinitions) == 0 && node.Operation == ast.OperationTypeQuery {
+			return p.print(node.SelectionSet)
+		}
+		head := join([]string{node.Operation, name}, " ")
+		vars := p.list(head, variableDefinitionNodes(node.VariableDefinitions), p.inner(node, len(node.VariableDefinitions) > 0), directives+" {", false)
+		return head + vars + directives + " " + p.print(node.SelectionSet)
+	case *ast.VariableDefinition:
+		return p.print(node.Variable) + ": " + p.print(node.Type) + wrap(" = ", p.print(node.DefaultValue), "")
+	case *ast.SelectionSet:
+		return p.block(selectionNodes(node.Selections), p.inner(node, true), true)
+	case *ast.Field:
+		head := ""
+		if node.Alias != nil {
+			head = node.Alias.Value + ": "
+		}
+		head += p.print(node.Name)
+		directives := p.directives(node.Directives)
+		tail := directives
+		if node.SelectionSet != nil {
+			tail += " {"
+		}
+		str := head + p.list(head, argumentNodes(node.Arguments), p.inner(node, len(node.Arguments) > 0), tail, false) + directives
+		if node.SelectionSet != nil {
+			str += " " + p.print(node.SelectionSet)
+		}
+		return str
+	case *ast.Argument:
+		return p.print(node.Name) + ": " + p.print(node.Value)
+
+	// Fragments
+	case *ast.FragmentSpread:
+		return "..." + p.print(node.Name) + p.directives(node.Directives)
+	case *ast.InlineFragment:
+		return join([]string{
+			"...",
+			wrap("on ", p.print(node.TypeCondition), ""),
+			strings.TrimPrefix(p.directives(node.Directives), " "),
+			p.print(node.SelectionSet),
+		}, " ")
+	case *ast.FragmentDefinition:
+		return "fragment " + p.print(node.Name) + " on " + p.print(node.TypeCondition) +
+			p.directives(node.Directives) + " " + p.print(node.SelectionSet)
+
+	// Values
+	case *ast.Variable:
+		return "$" + p.print(node.Name)
+	case *ast.IntValue:
+		return node.Value
+	case *ast.FloatValue:
+		return node.Value
+	case *ast.StringValue:
+		return quote(node.Value)
+	case *ast.BooleanValue:
+		return fmt.Sprintf("%v", node.Value)
+	case *ast.NullValue:
+		return "null"
+	case *ast.EnumValue:
+		return node.Value
+	case *ast.ListValue:
+		return p.group("[", "]", valueNodes(node.Values), p.inner(node, true))
+	case *ast.ObjectValue:
+		return p.group("{", "}", objectFieldNodes(node.Fields), p.inner(node, true))
+	case *ast.ObjectField:
+		return p.print(node.Name) + ": " + p.print(node.Value)
+
+	// Directive
+	case *ast.Directive:
+		args := ""
+		if len(node.Arguments) > 0 {
+			args = p.group("(", ")", argumentNodes(node.Arguments), nil)
+		}
+		return "@" + p.print(node.Name) + args
+
+	// Type
+	case *ast.Named:
+		return p.print(node.Name)
+	case *ast.List:
+		return "[" + p.print(node.Type) + "]"
+	case *ast.NonNull:
+		return p.print(node.Type) + "!"
+
+	// Type System Definitions
+	case *ast.SchemaDefinition:
+		return "schema" + p.directives(node.Directives) +
+			wrap(" ", p.block(operationTypeNodes(node.OperationTypes), p.inner(node, true), false), "")
+	case *ast.OperationTypeDefinition:
+		return node.Operation + ": " + p.print(node.Type)
+	case *ast.ScalarDefinition:
+		return p.describe(node.Description, "scalar "+p.print(node.Name)+p.directives(node.Directives))
+	case *ast.ObjectDefinition:
+		interfaces := []string{}
+		for _, iface := range node.Interfaces {
+			interfaces = append(interfaces, p.print(iface))
+		}
+		return p.describe(node.Description, "type "+p.print(node.Name)+
+			wrap(" implements ", strings.Join(interfaces, " & "), "")+
+			p.directives(node.Directives)+
+			wrap(" ", p.block(fieldDefinitionNodes(node.Fields), p.inner(node, true), false), ""))
+	case *ast.FieldDefinition:
+		head := p.print(node.Name)
+		directives := p.directives(node.Directives)
+		ttype := ": " + p.print(node.Type)
+		str := head + p.list(head, inputValueNodes(node.Arguments), p.inner(node, len(node.Arguments) > 0), ttype+directives, hasDescription(node.Arguments)) + ttype + directives
+		return p.describe(node.Description, str)
+	case *ast.InputValueDefinition:
+		return p.describe(node.Description, p.print(node.Name)+": "+p.print(node.Type)+
+			wrap(" = ", p.print(node.DefaultValue), "")+p.directives(node.Directives))
+	case *ast.InterfaceDefinition:
+		return p.describe(node.Description, "interface "+p.print(node.Name)+p.directives(node.Directives)+
+			wrap(" ", p.block(fieldDefinitionNodes(node.Fields), p.inner(node, true), false), ""))
+	case *ast.UnionDefinition:
+		types := []string{}
+		for _, ttype := range node.Types {
+			types = append(types, p.print(ttype))
+		}
+		return p.describe(node.Description, "union "+p.print(node.Name)+p.directives(node.Directives)+
+			wrap(" = ", strings.Join(types, " | "), ""))
+	case *ast.EnumDefinition:
+		return p.describe(node.Description, "enum "+p.print(node.Name)+p.directives(node.Directives)+
+			wrap(" ", p.block(enumValueNodes(node.Values), p.inner(node, true), false), ""))
+	case *ast.EnumValueDefinition:
+		return p.describe(node.Description, p.print(node.Name)+p.directives(node.Directives))
+	case *ast.InputObjectDefinition:
+		return p.describe(node.Description, "input "+p.print(node.Name)+p.directives(node.Directives)+
+			wrap(" ", p.block(inputValueNodes(node.Fields), p.inner(node, true), false), ""))
+	case *ast.SchemaExtensionDefinition:
+		return "extend " + p.print(node.Definition)
+	case *ast.ScalarExtensionDefinition:
+		return "extend " + p.print(node.Definition)
+	case *ast.TypeExtensionDefinition:
+		return "extend " + p.print(node.Definition)
+	case *ast.InterfaceExtensionDefinition:
+		return "extend " + p.print(node.Definition)
+	case *ast.UnionExtensionDefinition:
+		return "extend " + p.print(node.Definition)
+	case *ast.EnumExtensionDefinition:
+		return "extend " + p.print(node.Definition)
+	case *ast.InputObjectExtensionDefinition:
+		return "extend " + p.print(node.Definition)
+	case *ast.DirectiveDefinition:
+		head := "directive @" + p.print(node.Name)
+		locations := []string{}
+		for _, location := range node.Locations {
+			locations = append(locations, p.print(location))
+		}
+		tail := " on " + strings.Join(locations, " | ")
+		args := p.list(head, inputValueNodes(node.Arguments), p.inner(node, len(node.Arguments) > 0), tail, hasDescription(node.Arguments))
+		return p.describe(node.Description, head+args+tail)
+	}
+	return ""
+}
+
+func (p *configPrinter) document(doc *ast.Document) string {
+	definitions := []string{}
+	for _, def := range doc.Definitions {
+		definitions = append(definitions, p.item(def))
+	}
+	if comments := trimBlankLines(p.commentLines(p.inner(doc, true), maxPosition)); len(comments) > 0 {
+		definitions = append(definitions, strings.Join(comments, "\n"))
+	}
+	return strings.Join(definitions, "\n\n") + "\n"
+}
+
+// item prints a node standing on its own lines, along with its leading and
+// trailing comments, followed by the inner comments of the node and of its
+// children printed nowhere else.
+func (p *configPrinter) item(node ast.Node) string {
+	stray := p.stray
+	p.stray = nil
+	defer func() { p.stray = stray }()
+	lines := []string{}
+	trailing := ""
+	if comments := commentsOf(node); comments != nil {
+		lines = p.commentLines(comments.Leading, startOf(node))
+		if comments.Trailing != nil {
+			trailing = " #" + comments.Trailing.Value
+		}
+	}
+	lines = append(lines, p.print(node)+trailing)
+	for _, comment := range p.stray {
+		lines = append(lines, "#"+comment.Value)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// block prints nodes one per line in an indented "{ }" block, followed by the
+// inner comments. An empty block is printed as "{}" when always is set and as
+// nothing otherwise.
+func (p *configPrinter) block(nodes []ast.Node, inner []*ast.Comment, always bool) string {
+	lines := p.lines(nodes, inner)
+	if len(lines) == 0 {
+		if always {
+			return "{}"
+		}
+		return ""
+	}
+	return "{\n" + lines + "\n}"
+}
+
+// list prints nodes as a list in parentheses, inline after head and before
+// tail when it fits in MaxLineWidth and has no comment, one per line otherwise
+// or when multiline is set.
+func (p *configPrinter) list(head string, nodes []ast.Node, inner []*ast.Comment, tail string, multiline bool) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+	if !multiline {
+		if items, ok := p.inline(nodes, inner); ok {
+			inline := "(" + strings.Join(items, ", ") + ")"
+			width := p.depth*len(p.indent) + len(head) + len(inline) + len(tail)
+			if p.MaxLineWidth <= 0 || width <= p.MaxLineWidth {
+				return inline
+			}
+		}
+	}
+	return "(\n" + p.lines(nodes, inner) + "\n)"
+}
+
+// group prints nodes between open and close, inline when they have no
+// comment and one per line otherwise.
+func (p *configPrinter) group(open, close string, nodes []ast.Node, inner []*ast.Comment) string {
+	if items, ok := p.inline(nodes, inner); ok {
+		return open + strings.Join(items, ", ") + close
+	}
+	return open + "\n" + p.lines(nodes, inner) + "\n" + close
+}
+
+// inline prints nodes to be laid out on a single line, which is not possible
+// when they, their children or the list itself have comments.
+func (p *configPrinter) inline(nodes []ast.Node, inner []*ast.Comment) ([]string, bool) {
+	if len(inner) > 0 {
+		return nil, false
+	}
+	stray := len(p.stray)
+	items := []string{}
+	for _, node := range nodes {
+		if comments := commentsOf(node); comments != nil && (len(comments.Leading) > 0 || comments.Trailing != nil) {
+			return nil, false
+		}
+		item := p.print(node)
+		if len(p.stray) > stray || strings.Contains(item, "\n") {
+			p.stray = p.stray[:stray]
+			return nil, false
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
+
+// lines prints nodes as items one level deeper, separated by a blank line
+// where the source has one and before described nodes, followed by the inner
+// comments of the list.
+func (p *configPrinter) lines(nodes []ast.Node, inner []*ast.Comment) string {
+	p.depth++
+	defer func() { p.depth-- }()
+	lines := []string{}
+	prevEnd := -1
+	for i, node := range nodes {
+		if i > 0 && (p.blankLineBetween(prevEnd, firstStart(node)) || hasDescription(node)) {
+			lines = append(lines, "")
+		}
+		lines = append(lines, p.item(node))
+		prevEnd = endOf(node)
+	}
+	if len(inner) > 0 {
+		if len(lines) > 0 && p.blankLineBetween(prevEnd, commentStart(inner[0])) {
+			lines = append(lines, "")
+		}
+		lines = append(lines, trimBlankLines(p.commentLines(inner, maxPosition))...)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return p.indentLines(strings.Join(lines, "\n"))
+}
+
+// describe prints the description of a definition on the lines before it.
+func (p *configPrinter) describe(description *ast.StringValue, str string) string {
+	if description == nil {
+		return str
+	}
+	block := true
+	switch p.Descriptions {
+	case StringDescriptions:
+		block = strings.ContainsAny(description.Value, "\n\r")
+	case PreserveDescriptions:
+		if loc := description.Loc; loc != nil && loc.Source != nil && loc.End <= len(loc.Source.Body) {
+			block = strings.HasPrefix(string(loc.Source.Body[loc.Start:loc.End]), `"""`)
+		}
+	}
+	if !block {
+		return quote(description.Value) + "\n" + str
+	}
+	return blockString(description.Value) + "\n" + str
+}
+
+func (p *configPrinter) directives(directives []*ast.Directive) string {
+	str := ""
+	for _, directive := range directives {
+		str += " " + p.print(directive)
+	}
+	return str
+}
+
+// maxPosition is past the end of any document.
+const maxPosition = int(^uint(0) >> 1)
+
+// commentLines prints comments one per line, keeping single blank lines
+// between them and before pos.
+func (p *configPrinter) commentLines(comments []*ast.Comment, pos int) []string {
+	lines := []string{}
+	prevEnd := -1
+	for _, comment := range comments {
+		if len(lines) > 0 && p.blankLineBetween(prevEnd, commentStart(comment)) {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "#"+comment.Value)
+		if comment.Loc != nil {
+			prevEnd = comment.Loc.End
+		}
+	}
+	if len(lines) > 0 && p.blankLineBetween(prevEnd, pos) {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// inner returns the inner comments of node when the printer has a place for
+// them, that is when ok is set.
+func (p *configPrinter) inner(node ast.Node, ok bool) []*ast.Comment {
+	comments := commentsOf(node)
+	if !ok || comments == nil {
+		return nil
+	}
+	p.placed[node] = true
+	return comments.Inner
+}
+
+// commentsOf returns the comments attached to node, if any.
+func commentsOf(node ast.Node) *ast.Trivia {
+	if node, ok := node.(ast.CommentedNode); ok && !isNil(node) {
+		return node.GetComments()
+	}
+	return nil
+}
+
+// firstStart returns where node begins in the source: at its first leading
+// comment or at its own start.
+func firstStart(node ast.Node) int {
+	if comments := commentsOf(node); comments != nil && len(comments.Leading) > 0 {
+		return commentStart(comments.Leading[0])
+	}
+	return startOf(node)
+}
+
+// blankLineBetween determines if the source has an empty line between two
+// positions, which is only known when the source of the document is kept.
+func (p *configPrinter) blankLineBetween(start, end int) bool {
+	if p.body == nil || start < 0 || end < start || end > len(p.body) {
+		return false
+	}
+	return strings.Count(strings.Replace(string(p.body[start:end]), "\r\n", "\n", -1), "\n") > 1
+}
+
+func (p *configPrinter) indentLines(str string) string {
+	lines := strings.Split(str, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = p.indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func commentStart(comment *ast.Comment) int {
+	if comment.Loc == nil {
+		return -1
+	}
+	return comment.Loc.Start
+}
+
+// startOf returns the start of node, or -1 when it has no location.
+func startOf(node ast.Node) int {
+	if isNil(node) || node.GetLoc() == nil {
+		return -1
+	}
+	return node.GetLoc().Start
+}
+
+// endOf returns the end of node, or -1 when it has no location.
+func endOf(node ast.Node) int {
+	if isNil(node) || node.GetLoc() == nil {
+		return -1
+	}
+	return node.GetLoc().End
+}
+
+func isNil(node ast.Node) bool {
+	return node == nil || reflect.ValueOf(node).IsNil()
+}
+
+func hasDescription(node interface{}) bool {
+	switch node := node.(type) {
+	case ast.DescribableNode:
+		return node.GetDescription() != nil
+	case []*ast.InputValueDefinition:
+		for _, arg := range node {
+			if arg.Description != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// quote prints str as a GraphQL string value.
+func quote(str string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// blockString prints str as a block string, on its own lines when it spans
+// several lines or ends with a quote.
+func blockString(str string) string {
+	escaped := strings.Replace(str, `"""`, `\"""`, -1)
+	if strings.ContainsAny(str, "\n\r") || strings.HasSuffix(str, `"`) {
+		return `"""` + "\n" + escaped + "\n" + `"""`
+	}
+	return `"""` + escaped + `"""`
+}
+
+func variableDefinitionNodes(defs []*ast.VariableDefinition) []ast.Node {
+	nodes := []ast.Node{}
+	for _, def := range defs {
+		nodes = append(nodes, def)
+	}
+	return nodes
+}
+
+func selectionNodes(selections []ast.Selection) []ast.Node {
+	nodes := []ast.Node{}
+	for _, selection := range selections {
+		if node, ok := selection.(ast.Node); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func argumentNodes(args []*ast.Argument) []ast.Node {
+	nodes := []ast.Node{}
+	for _, arg := range args {
+		nodes = append(nodes, arg)
+	}
+	return nodes
+}
+
+func valueNodes(values []ast.Value) []ast.Node {
+	nodes := []ast.Node{}
+	for _, value := range values {
+		if node, ok := value.(ast.Node); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func objectFieldNodes(fields []*ast.ObjectField) []ast.Node {
+	nodes := []ast.Node{}
+	for _, field := range fields {
+		nodes = append(nodes, field)
+	}
+	return nodes
+}
+
+func operationTypeNodes(defs []*ast.OperationTypeDefinition) []ast.Node {
+	nodes := []ast.Node{}
+	for _, def := range defs {
+		nodes = append(nodes, def)
+	}
+	return nodes
+}
+
+func fieldDefinitionNodes(defs []*ast.FieldDefinition) []ast.Node {
+	nodes := []ast.Node{}
+	for _, def := range defs {
+		nodes = append(nodes, def)
+	}
+	return nodes
+}
+
+func inputValueNodes(defs []*ast.InputValueDefinition) []ast.Node {
+	nodes := []ast.Node{}
+	for _, def := range defs {
+		nodes = append(nodes, def)
+	}
+	return nodes
+}
+
+func enumValueNodes(defs []*ast.EnumValueDefinition) []ast.Node {
+	nodes := []ast.Node{}
+	for _, def := range defs {
+		nodes = append(nodes, def)
+	}
+	return nodes
+}
@@ -0,0 +1,225 @@
+package printer_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/printer"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func parseWithComments(t *testing.T, query string) *ast.Document {
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source: query,
+		Options: parser.ParseOptions{
+			Comments: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	return astDoc
+}
+
+func TestConfig_PrintsKitchenSinkLikePrint(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	astDoc := parse(t, string(b))
+	expected := printer.Print(astDoc)
+	results := printer.Config{}.Print(astDoc)
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestConfig_KeepsComments(t *testing.T) {
+	astDoc := parseWithComments(t, `# header
+
+# about Q
+query Q($a: Int, # first
+  $b: String) {
+  a # after a
+  # before b
+
+  b {
+    c
+    # closing b
+  }
+}
+type Foo {
+  a(x: Int): String # after a
+
+  # before b
+  b: Int
+}
+# end
+`)
+	expected := `# header
+
+# about Q
+query Q(
+  $a: Int # first
+  $b: String
+) {
+  a # after a
+  # before b
+
+  b {
+    c
+    # closing b
+  }
+}
+
+type Foo {
+  a(x: Int): String # after a
+
+  # before b
+  b: Int
+}
+
+# end
+`
+	results := printer.Config{}.Print(astDoc)
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestConfig_KeepsCommentsInsideValues(t *testing.T) {
+	astDoc := parseWithComments(t, `{
+  f(a: [1, # one
+    2], b: # before one
+    1, c: {x: 1 # x
+  }) @dir(x: 1 # x
+  )
+}
+`)
+	expected := `{
+  f(
+    a: [
+      1 # one
+      2
+    ]
+    b: 1
+    # before one
+    c: {
+      x: 1 # x
+    }
+  ) @dir(
+    x: 1 # x
+  )
+}
+`
+	results := printer.Config{}.Print(astDoc)
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+	reparsed := printer.Config{}.Print(parseWithComments(t, results))
+	if !reflect.DeepEqual(expected, reparsed) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, reparsed))
+	}
+}
+
+func TestConfig_WrapsArgumentsPastMaxLineWidth(t *testing.T) {
+	astDoc := parseWithComments(t, `
+query Q($first: Int, $after: String) {
+  users(first: $first, after: $after) { name(format: SHORT) }
+}
+type Query { users(first: Int, after: String): [User] }
+`)
+	expected := `query Q(
+    $first: Int
+    $after: String
+) {
+    users(
+        first: $first
+        after: $after
+    ) {
+        name(format: SHORT)
+    }
+}
+
+type Query {
+    users(
+        first: Int
+        after: String
+    ): [User]
+}
+`
+	results := printer.Config{Indent: 4, MaxLineWidth: 30}.Print(astDoc)
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestConfig_PrintsDescriptionsInTheConfiguredStyle(t *testing.T) {
+	query := `"""Single"""
+scalar A
+
+"Also single"
+scalar B
+
+"""
+Multiple
+lines
+"""
+scalar C
+`
+	tests := map[printer.DescriptionStyle]string{
+		printer.BlockDescriptions: `"""Single"""
+scalar A
+
+"""Also single"""
+scalar B
+
+"""
+Multiple
+lines
+"""
+scalar C
+`,
+		printer.StringDescriptions: `"Single"
+scalar A
+
+"Also single"
+scalar B
+
+"""
+Multiple
+lines
+"""
+scalar C
+`,
+		printer.PreserveDescriptions: query,
+	}
+	for style, expected := range tests {
+		results := printer.Config{Descriptions: style}.Print(parseWithComments(t, query))
+		if !reflect.DeepEqual(expected, results) {
+			t.Fatalf("Unexpected result for style %v, Diff: %v", style, testutil.Diff(expected, results))
+		}
+	}
+}
+
+func TestConfig_IsIdempotent(t *testing.T) {
+	for _, path := range []string{
+		"../../kitchen-sink.graphql",
+		"../../schema-kitchen-sink.graphql",
+		"../../schema-all-descriptions.graphql",
+	} {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unable to load %v", path)
+		}
+		config := printer.Config{MaxLineWidth: 40}
+		once := config.Print(parseWithComments(t, string(b)))
+		twice := config.Print(parseWithComments(t, once))
+		if !reflect.DeepEqual(once, twice) {
+			t.Fatalf("Unexpected result for %v, Diff: %v", path, testutil.Diff(once, twice))
+		}
+	}
+}
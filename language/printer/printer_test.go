@@ -59,6 +59,25 @@ func TestPrinter_PrintsMinimalAST(t *testing.T) {
 	}
 }
 
+func TestPrinter_PrintsTheSourceOfInvalidNodes(t *testing.T) {
+	astDoc, _ := parser.Parse(parser.ParseParams{
+		Source:  `{ a ... on { b } c } query Q( { d }`,
+		Options: parser.ParseOptions{Tolerant: true},
+	})
+	results := printer.Print(astDoc)
+	expected := `{
+  a
+  ... on { b }
+  c
+}
+
+query Q( { d }
+`
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
 // TestPrinter_ProducesHelpfulErrorMessages
 // Skipped, can't figure out how to pass in an invalid astDoc, which is already strongly-typed
 
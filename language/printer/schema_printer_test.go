@@ -113,6 +113,22 @@ extend type Foo {
 
 extend type Foo @onType {}
 
+extend schema @onSchema {}
+
+extend scalar CustomScalar @onScalar
+
+extend interface Bar @onInterface {}
+
+extend union Feed = Photo | Video
+
+extend enum Site {
+  VR
+}
+
+extend input InputType {
+  other: Float = 1.23e4
+}
+
 type NoFields {}
 
 directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
@@ -20,6 +20,8 @@ type KeyMap map[string][]string
 var QueryDocumentKeys = KeyMap{
 	"Name":     []string{},
 	"Document": []string{"Definitions"},
+	"Invalid":  []string{},
+	"Comment":  []string{},
 	"OperationDefinition": []string{
 		"Name",
 		"VariableDefinitions",
@@ -65,6 +67,7 @@ var QueryDocumentKeys = KeyMap{
 	"FloatValue":   []string{},
 	"StringValue":  []string{},
 	"BooleanValue": []string{},
+	"NullValue":    []string{},
 	"EnumValue":    []string{},
 	"ListValue":    []string{"Values"},
 	"ObjectValue":  []string{"Fields"},
@@ -135,7 +138,13 @@ var QueryDocumentKeys = KeyMap{
 		"Fields",
 	},
 
-	"TypeExtensionDefinition": []string{"Definition"},
+	"SchemaExtensionDefinition":      []string{"Definition"},
+	"ScalarExtensionDefinition":      []string{"Definition"},
+	"TypeExtensionDefinition":        []string{"Definition"},
+	"InterfaceExtensionDefinition":   []string{"Definition"},
+	"UnionExtensionDefinition":       []string{"Definition"},
+	"EnumExtensionDefinition":        []string{"Definition"},
+	"InputObjectExtensionDefinition": []string{"Definition"},
 
 	"DirectiveDefinition": []string{"Name", "Arguments", "Locations"},
 }
@@ -546,7 +555,15 @@ func updateNodeField(src interface{}, targetName string, target interface{}) int
 		return src
 	}
 	srcFieldValue := srcVal.FieldByName(targetName)
-	if !srcFieldValue.IsValid() || srcFieldValue.Kind() != targetVal.Kind() {
+	if !srcFieldValue.IsValid() {
+		return src
+	}
+	// fields of interface types, such as values, hold any node implementing them
+	if srcFieldValue.Kind() == reflect.Interface {
+		if !targetVal.IsValid() || !targetVal.Type().AssignableTo(srcFieldValue.Type()) {
+			return src
+		}
+	} else if srcFieldValue.Kind() != targetVal.Kind() {
 		return src
 	}
 
@@ -246,6 +246,38 @@ func TestVisitor_VisitsEditedNode(t *testing.T) {
 		t.Fatalf("Unexpected result, expected didVisitAddedField == true")
 	}
 }
+func TestVisitor_AllowsEditingValuesAndTypes(t *testing.T) {
+
+	query := `query Q($a: [Int] = 1) { a(x: 1, y: [2]) }`
+	astDoc := parse(t, query)
+
+	expectedQuery := `query Q($a: [String] = 0) { a(x: 0, y: [0]) }`
+	expectedAST := parse(t, expectedQuery)
+	v := &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.IntValue:
+				return visitor.ActionUpdate, ast.NewIntValue(&ast.IntValue{Value: "0"})
+			case *ast.ListValue:
+				if _, ok := p.Parent.(*ast.Argument); !ok {
+					return visitor.ActionNoChange, nil
+				}
+				return visitor.ActionUpdate, ast.NewListValue(&ast.ListValue{Values: node.Values})
+			case *ast.Named:
+				return visitor.ActionUpdate, ast.NewNamed(&ast.Named{
+					Name: ast.NewName(&ast.Name{Value: "String"}),
+				})
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+
+	editedAst := visitor.Visit(astDoc, v, nil)
+	if !reflect.DeepEqual(expectedAST, editedAst) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedAST, editedAst))
+	}
+}
+
 func TestVisitor_AllowsSkippingASubTree(t *testing.T) {
 
 	query := `{ a, b { x }, c }`
@@ -0,0 +1,86 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql"
+)
+
+var completionKinds = map[graphql.SuggestionKind]CompletionItemKind{
+	graphql.SuggestionKeyword:    CompletionKindKeyword,
+	graphql.SuggestionField:      CompletionKindField,
+	graphql.SuggestionArgument:   CompletionKindVariable,
+	graphql.SuggestionInputField: CompletionKindField,
+	graphql.SuggestionEnumValue:  CompletionKindEnumMember,
+	graphql.SuggestionValue:      CompletionKindValue,
+	graphql.SuggestionVariable:   CompletionKindVariable,
+	graphql.SuggestionDirective:  CompletionKindFunction,
+	graphql.SuggestionFragment:   CompletionKindReference,
+}
+
+// completion returns the suggestions of graphql.GetAutocompleteSuggestions,
+// in their order.
+func (s *Server) completion(params textDocumentPositionParams) CompletionList {
+	list := CompletionList{Items: []CompletionItem{}}
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return list
+	}
+	// the suggestions API counts characters in runes rather than UTF-16 units
+	offset := doc.offset(params.Position)
+	cursor := graphql.Position{Line: params.Position.Line}
+	if cursor.Line < len(doc.lines) {
+		cursor.Character = utf8.RuneCountInString(doc.text[doc.lines[cursor.Line]:offset])
+	}
+	for i, suggestion := range graphql.GetAutocompleteSuggestions(s.schema, doc.text, cursor) {
+		kind, ok := completionKinds[suggestion.Kind]
+		if !ok {
+			kind = typeKind(s.schema.Type(suggestion.Label))
+		}
+		list.Items = append(list.Items, CompletionItem{
+			Label:         suggestion.Label,
+			Kind:          kind,
+			Detail:        suggestion.Type,
+			Documentation: documentation(suggestion.Description, suggestion.DeprecationReason),
+			Deprecated:    suggestion.IsDeprecated,
+			Tags:          deprecatedTags(suggestion.DeprecationReason),
+			SortText:      fmt.Sprintf("%04d", i),
+		})
+	}
+	return list
+}
+
+func documentation(description, deprecationReason string) *MarkupContent {
+	if deprecationReason != "" {
+		description = strings.TrimSpace(description + "\n\nDeprecated: " + deprecationReason)
+	}
+	if description == "" {
+		return nil
+	}
+	return &MarkupContent{Kind: "markdown", Value: description}
+}
+
+func deprecatedTags(deprecationReason string) []CompletionItemTag {
+	if deprecationReason == "" {
+		return nil
+	}
+	return []CompletionItemTag{CompletionTagDeprecated}
+}
+
+func typeKind(ttype graphql.Type) CompletionItemKind {
+	switch ttype.(type) {
+	case *graphql.Object:
+		return CompletionKindClass
+	case *graphql.Interface:
+		return CompletionKindInterface
+	case *graphql.Union:
+		return CompletionKindStruct
+	case *graphql.Enum:
+		return CompletionKindEnum
+	case *graphql.InputObject:
+		return CompletionKindStruct
+	}
+	return CompletionKindValue
+}
@@ -0,0 +1,222 @@
+package lsp
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+const diagnosticSource = "graphql"
+
+// documentRules are the rules documents are validated with: all the specified
+// rules but NoUnusedFragmentsRule, since fragments may be defined in a
+// document and used in another one.
+var documentRules = func() []graphql.ValidationRuleFn {
+	rules := []graphql.ValidationRuleFn{}
+	unused := reflect.ValueOf(graphql.NoUnusedFragmentsRule).Pointer()
+	for _, rule := range graphql.SpecifiedRules {
+		if reflect.ValueOf(rule).Pointer() != unused {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}()
+
+// diagnostics returns the syntax errors of doc, its validation errors and the
+// uses of deprecated fields and enum values.
+func (s *Server) diagnostics(doc *document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	switch err := doc.err.(type) {
+	case nil:
+	case gqlerrors.SyntaxErrors:
+		for _, syntaxErr := range err {
+			diagnostics = append(diagnostics, doc.syntaxDiagnostic(syntaxErr))
+		}
+	case *gqlerrors.Error:
+		diagnostics = append(diagnostics, doc.syntaxDiagnostic(err))
+	default:
+		diagnostics = append(diagnostics, Diagnostic{Severity: SeverityError, Source: diagnosticSource, Message: err.Error()})
+	}
+	if doc.ast == nil {
+		return diagnostics
+	}
+
+	if !isExecutable(doc.ast) {
+		for _, err := range graphql.ValidateSDL(doc.ast, nil).Errors {
+			if diagnostic, ok := doc.validationDiagnostic(err); ok {
+				diagnostics = append(diagnostics, diagnostic)
+			}
+		}
+		return diagnostics
+	}
+	for _, err := range graphql.ValidateDocument(s.schema, s.withFragments(doc), documentRules).Errors {
+		if diagnostic, ok := doc.validationDiagnostic(err); ok {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return append(diagnostics, s.deprecations(doc)...)
+}
+
+// isExecutable reports whether doc holds operations or fragments, rather
+// than type system definitions only.
+func isExecutable(doc *ast.Document) bool {
+	if len(doc.Definitions) == 0 {
+		return true
+	}
+	for _, def := range doc.Definitions {
+		switch def.(type) {
+		case *ast.OperationDefinition, *ast.FragmentDefinition:
+			return true
+		}
+	}
+	return false
+}
+
+// withFragments returns the AST of doc followed by the fragments of the other
+// open documents that doc does not define.
+func (s *Server) withFragments(doc *document) *ast.Document {
+	defined := map[string]bool{}
+	for _, def := range doc.ast.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name != nil {
+			defined[fragment.Name.Value] = true
+		}
+	}
+	definitions := append([]ast.Node{}, doc.ast.Definitions...)
+	for _, uri := range s.uris() {
+		other := s.documents[uri]
+		if other == doc || other.ast == nil {
+			continue
+		}
+		for _, def := range other.ast.Definitions {
+			if fragment, ok := def.(*ast.FragmentDefinition); ok && fragment.Name != nil && !defined[fragment.Name.Value] {
+				defined[fragment.Name.Value] = true
+				definitions = append(definitions, fragment)
+			}
+		}
+	}
+	return ast.NewDocument(&ast.Document{Loc: doc.ast.Loc, Definitions: definitions})
+}
+
+// syntaxLocation matches the location of syntax error messages, followed by
+// the description of the error and the lines around the error.
+var syntaxLocation = regexp.MustCompile(`^Syntax Error .* \(\d+:\d+\) `)
+
+func (doc *document) syntaxDiagnostic(err *gqlerrors.Error) Diagnostic {
+	message := strings.SplitN(err.Message, "\n", 2)[0]
+	message = syntaxLocation.ReplaceAllString(message, "Syntax Error: ")
+	diagnostic := Diagnostic{Severity: SeverityError, Source: diagnosticSource, Message: message}
+	if len(err.Positions) > 0 {
+		start := err.Positions[0]
+		end := start
+		if end < len(doc.text) {
+			end++
+		}
+		diagnostic.Range = doc.rangeOf(start, end)
+	}
+	return diagnostic
+}
+
+// validationDiagnostic returns the diagnostic of a validation error, located
+// at the first of its nodes parsed from doc. Errors located in other
+// documents only are left out.
+func (doc *document) validationDiagnostic(err gqlerrors.FormattedError) (Diagnostic, bool) {
+	diagnostic := Diagnostic{Severity: SeverityError, Source: diagnosticSource, Message: err.Message}
+	original, ok := err.OriginalError().(*gqlerrors.Error)
+	if !ok || len(original.Nodes) == 0 {
+		return diagnostic, true
+	}
+	for _, node := range original.Nodes {
+		if loc := node.GetLoc(); loc != nil && loc.Source == doc.source {
+			diagnostic.Range = doc.nodeRange(node)
+			return diagnostic, true
+		}
+	}
+	return diagnostic, false
+}
+
+// nodeRange returns the range of the name of node when it has one, the range
+// of the node otherwise.
+func (doc *document) nodeRange(node ast.Node) Range {
+	var name *ast.Name
+	switch node := node.(type) {
+	case *ast.Field:
+		name = node.Name
+	case *ast.Argument:
+		name = node.Name
+	case *ast.Directive:
+		name = node.Name
+	case *ast.FragmentSpread:
+		name = node.Name
+	case *ast.FragmentDefinition:
+		name = node.Name
+	case *ast.OperationDefinition:
+		name = node.Name
+	case *ast.ObjectField:
+		name = node.Name
+	case *ast.Named:
+		name = node.Name
+	case *ast.VariableDefinition:
+		if node.Variable != nil {
+			return doc.nodeRange(node.Variable)
+		}
+	}
+	if name != nil && name.Loc != nil {
+		node = name
+	}
+	loc := node.GetLoc()
+	return doc.rangeOf(loc.Start, loc.End)
+}
+
+// deprecations returns a warning for each use of a deprecated field or enum
+// value in doc.
+func (s *Server) deprecations(doc *document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	warn := func(node ast.Node, message string) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    doc.nodeRange(node),
+			Severity: SeverityWarning,
+			Source:   diagnosticSource,
+			Message:  message,
+			Tags:     []DiagnosticTag{TagDeprecated},
+		})
+	}
+	typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{Schema: s.schema})
+	visitor.Visit(doc.ast, visitor.VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					fieldDef := typeInfo.FieldDef()
+					if node, ok := p.Node.(*ast.Field); ok && fieldDef != nil && fieldDef.DeprecationReason != "" {
+						if parentType := typeInfo.ParentType(); parentType != nil {
+							warn(node, fmt.Sprintf(`The field %v.%v is deprecated. %v`, parentType.Name(), fieldDef.Name, fieldDef.DeprecationReason))
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.EnumValue: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.EnumValue)
+					enum, isEnum := graphql.GetNamed(typeInfo.InputType()).(*graphql.Enum)
+					if !ok || !isEnum {
+						return visitor.ActionNoChange, nil
+					}
+					for _, value := range enum.Values() {
+						if value.Name == node.Value && value.DeprecationReason != "" {
+							warn(node, fmt.Sprintf(`The enum value %v.%v is deprecated. %v`, enum.Name(), value.Name, value.DeprecationReason))
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}), nil)
+	return diagnostics
+}
@@ -0,0 +1,101 @@
+package lsp
+
+import (
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// document is an open text document, parsed tolerantly so that the
+// definitions around a syntax error are still checked.
+type document struct {
+	uri     string
+	version int
+	text    string
+	source  *source.Source
+	ast     *ast.Document
+	err     error
+
+	// lines holds the byte offset of the start of each line
+	lines []int
+}
+
+func newDocument(uri string, version int, text string) *document {
+	doc := &document{
+		uri:     uri,
+		version: version,
+		text:    text,
+		source:  source.NewSource(&source.Source{Body: []byte(text), Name: uri}),
+		lines:   []int{0},
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			doc.lines = append(doc.lines, i+1)
+		}
+	}
+	doc.ast, doc.err = parser.Parse(parser.ParseParams{
+		Source:  doc.source,
+		Options: parser.ParseOptions{Tolerant: true},
+	})
+	return doc
+}
+
+// offset returns the byte offset of pos, clamped to the text.
+func (doc *document) offset(pos Position) int {
+	if pos.Line < 0 {
+		return 0
+	}
+	if pos.Line >= len(doc.lines) {
+		return len(doc.text)
+	}
+	offset := doc.lines[pos.Line]
+	for units := 0; units < pos.Character && offset < len(doc.text) && doc.text[offset] != '\n'; {
+		r, size := utf8.DecodeRuneInString(doc.text[offset:])
+		units += utf16Len(r)
+		offset += size
+	}
+	return offset
+}
+
+// position returns the position of the byte offset, clamped to the text.
+func (doc *document) position(offset int) Position {
+	if offset > len(doc.text) {
+		offset = len(doc.text)
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	line := 0
+	for line+1 < len(doc.lines) && doc.lines[line+1] <= offset {
+		line++
+	}
+	character := 0
+	for _, r := range doc.text[doc.lines[line]:offset] {
+		character += utf16Len(r)
+	}
+	return Position{Line: line, Character: character}
+}
+
+func (doc *document) rangeOf(start, end int) Range {
+	return Range{Start: doc.position(start), End: doc.position(end)}
+}
+
+// contains reports whether node was parsed from doc and spans offset, the
+// end of the node included so that a cursor right after a name is within it.
+func (doc *document) contains(node ast.Node, offset int) bool {
+	if node == nil {
+		return false
+	}
+	loc := node.GetLoc()
+	return loc != nil && loc.Source == doc.source && loc.Start <= offset && offset <= loc.End
+}
+
+func utf16Len(r rune) int {
+	// runes outside of the basic multilingual plane take a surrogate pair
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,202 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/kinds"
+	"github.com/graphql-go/graphql/language/visitor"
+)
+
+// hover describes the field, argument, directive, type, enum value or
+// fragment named at the cursor.
+func (s *Server) hover(params textDocumentPositionParams) *Hover {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok || doc.ast == nil {
+		return nil
+	}
+	offset := doc.offset(params.Position)
+
+	var hover *Hover
+	found := func(node ast.Node, contents MarkupContent) {
+		nodeRange := doc.nodeRange(node)
+		hover = &Hover{Contents: contents, Range: &nodeRange}
+	}
+	// nameAt reports whether the name of a node is at the cursor
+	nameAt := func(name *ast.Name) bool {
+		return name != nil && doc.contains(name, offset)
+	}
+	// inputObject is the type of the innermost object value at the cursor
+	var inputObject *graphql.InputObject
+	typeInfo := graphql.NewTypeInfo(&graphql.TypeInfoConfig{Schema: s.schema})
+	visitor.Visit(doc.ast, visitor.VisitWithTypeInfo(typeInfo, &visitor.VisitorOptions{
+		Enter: func(p visitor.VisitFuncParams) (string, interface{}) {
+			// nodes are not skipped, TypeInfo would not leave them
+			node, ok := p.Node.(ast.Node)
+			if !ok || !doc.contains(node, offset) {
+				return visitor.ActionNoChange, nil
+			}
+			switch node := node.(type) {
+			case *ast.Field:
+				fieldDef := typeInfo.FieldDef()
+				parentType := typeInfo.ParentType()
+				if nameAt(node.Name) && fieldDef != nil && parentType != nil {
+					signature := parentType.Name() + "." + fieldDef.Name + argumentsSignature(fieldDef.Args) + ": " + fieldDef.Type.String()
+					found(node, markdown(signature, fieldDef.Description, deprecation(fieldDef.DeprecationReason)))
+				}
+			case *ast.Argument:
+				if arg := typeInfo.Argument(); nameAt(node.Name) && arg != nil {
+					found(node, markdown(arg.Name()+": "+arg.Type.String(), arg.Description()))
+				}
+			case *ast.ObjectValue:
+				inputObject, _ = graphql.GetNamed(typeInfo.InputType()).(*graphql.InputObject)
+			case *ast.ObjectField:
+				if !nameAt(node.Name) || inputObject == nil {
+					break
+				}
+				if field, ok := inputObject.Fields()[node.Name.Value]; ok {
+					found(node, markdown(inputObject.Name()+"."+field.Name()+": "+field.Type.String(), field.Description()))
+				}
+			case *ast.Directive:
+				if directive := typeInfo.Directive(); nameAt(node.Name) && directive != nil {
+					found(node, markdown(directiveSignature(directive), directive.Description))
+				}
+			case *ast.Named:
+				if node.Name == nil {
+					break
+				}
+				if ttype := s.schema.Type(node.Name.Value); ttype != nil {
+					found(node, markdown(typeKeyword(ttype)+" "+ttype.Name(), ttype.Description()))
+				}
+			case *ast.EnumValue:
+				enum, ok := graphql.GetNamed(typeInfo.InputType()).(*graphql.Enum)
+				if !ok {
+					break
+				}
+				for _, value := range enum.Values() {
+					if value.Name == node.Value {
+						found(node, markdown(enum.Name()+"."+value.Name, value.Description, deprecation(value.DeprecationReason)))
+					}
+				}
+			case *ast.FragmentSpread:
+				if !nameAt(node.Name) {
+					break
+				}
+				if _, def := s.fragmentDefinition(doc, node.Name.Value); def != nil && def.TypeCondition != nil {
+					found(node, markdown("fragment "+node.Name.Value+" on "+def.TypeCondition.Name.Value))
+				}
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}), nil)
+	return hover
+}
+
+func deprecation(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return "Deprecated: " + reason
+}
+
+func argumentsSignature(args []*graphql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	// the arguments of fields are defined by maps, sorting them keeps the
+	// signature stable
+	args = append([]*graphql.Argument{}, args...)
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].Name() < args[j].Name()
+	})
+	signature := "("
+	for i, arg := range args {
+		if i > 0 {
+			signature += ", "
+		}
+		signature += arg.Name() + ": " + arg.Type.String()
+	}
+	return signature + ")"
+}
+
+func typeKeyword(ttype graphql.Type) string {
+	switch ttype.(type) {
+	case *graphql.Object:
+		return "type"
+	case *graphql.Interface:
+		return "interface"
+	case *graphql.Union:
+		return "union"
+	case *graphql.Enum:
+		return "enum"
+	case *graphql.InputObject:
+		return "input"
+	}
+	return "scalar"
+}
+
+func directiveSignature(directive *graphql.Directive) string {
+	args := []string{}
+	for _, arg := range directive.Args {
+		args = append(args, arg.Name()+": "+arg.Type.String())
+	}
+	if len(args) == 0 {
+		return "@" + directive.Name
+	}
+	return "@" + directive.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// definition returns the location of the definition of the fragment spread
+// at the cursor.
+func (s *Server) definition(params textDocumentPositionParams) *Location {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok || doc.ast == nil {
+		return nil
+	}
+	offset := doc.offset(params.Position)
+
+	var location *Location
+	visitor.Visit(doc.ast, &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.FragmentSpread: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.FragmentSpread)
+					if !ok || node.Name == nil || !doc.contains(node, offset) {
+						return visitor.ActionNoChange, nil
+					}
+					if other, def := s.fragmentDefinition(doc, node.Name.Value); def != nil {
+						location = &Location{URI: other.uri, Range: other.rangeOf(def.Loc.Start, def.Loc.End)}
+						return visitor.ActionBreak, nil
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}, nil)
+	return location
+}
+
+// fragmentDefinition returns the definition of the fragment name and the
+// document defining it, looking into doc first, then into the other open
+// documents.
+func (s *Server) fragmentDefinition(doc *document, name string) (*document, *ast.FragmentDefinition) {
+	docs := []*document{doc}
+	for _, uri := range s.uris() {
+		if s.documents[uri] != doc {
+			docs = append(docs, s.documents[uri])
+		}
+	}
+	for _, doc := range docs {
+		if doc.ast == nil {
+			continue
+		}
+		for _, def := range doc.ast.Definitions {
+			if def, ok := def.(*ast.FragmentDefinition); ok && def.Name != nil && def.Name.Value == name && def.Loc != nil {
+				return doc, def
+			}
+		}
+	}
+	return nil, nil
+}
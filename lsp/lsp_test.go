@@ -0,0 +1,525 @@
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/lsp"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+const testSDL = `
+"The root query"
+type Query {
+  "Looks a user up by id."
+  user(id: ID!, "Filters on the role." role: Role): User
+  users(filter: UserFilter, first: Int): [User]
+  me: User @deprecated(reason: "Use user.")
+  search(term: String): [SearchResult]
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node {
+  id: ID!
+  name: String
+  role: Role
+  friends: [User]
+}
+
+type Post {
+  title: String
+}
+
+union SearchResult = User | Post
+
+enum Role {
+  ADMIN
+  MEMBER
+  GUEST @deprecated(reason: "Guests are gone.")
+}
+
+"Filters users."
+input UserFilter {
+  "The role of the users."
+  role: Role
+  name: String
+}
+`
+
+func testSchema(t *testing.T) *graphql.Schema {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, testSDL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &schema
+}
+
+// session is a scripted JSON-RPC session with a server.
+type session struct {
+	t      *testing.T
+	input  bytes.Buffer
+	nextID int
+}
+
+func newSession(t *testing.T) *session {
+	s := &session{t: t}
+	s.request("initialize", map[string]interface{}{"capabilities": map[string]interface{}{}})
+	s.notify("initialized", map[string]interface{}{})
+	return s
+}
+
+func (s *session) send(msg map[string]interface{}) {
+	msg["jsonrpc"] = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		s.t.Fatalf("unexpected error: %v", err)
+	}
+	fmt.Fprintf(&s.input, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// request sends a request and returns its id.
+func (s *session) request(method string, params interface{}) int {
+	s.nextID++
+	s.send(map[string]interface{}{"id": s.nextID, "method": method, "params": params})
+	return s.nextID
+}
+
+func (s *session) notify(method string, params interface{}) {
+	s.send(map[string]interface{}{"method": method, "params": params})
+}
+
+func (s *session) open(uri, text string) {
+	s.notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri, "languageId": "graphql", "version": 1, "text": text},
+	})
+}
+
+func (s *session) change(uri string, version int, text string) {
+	s.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": version},
+		"contentChanges": []interface{}{map[string]interface{}{"text": text}},
+	})
+}
+
+// at sends a request on the position marked by | in text, which is opened
+// as uri first.
+func (s *session) at(method, uri, text string) int {
+	offset := strings.Index(text, "|")
+	text = text[:offset] + text[offset+1:]
+	s.open(uri, text)
+	line := strings.Count(text[:offset], "\n")
+	character := offset - strings.LastIndex(text[:offset], "\n") - 1
+	return s.request(method, map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"position":     map[string]interface{}{"line": line, "character": character},
+	})
+}
+
+// run serves the session, ending it with a shutdown and exit, and returns the
+// messages written by the server.
+func (s *session) run(schema *graphql.Schema) []map[string]interface{} {
+	s.request("shutdown", nil)
+	s.notify("exit", nil)
+	output := bytes.Buffer{}
+	if err := lsp.NewServer(schema).Serve(&s.input, &output); err != nil {
+		s.t.Fatalf("unexpected error: %v", err)
+	}
+	return readMessages(s.t, &output)
+}
+
+func readMessages(t *testing.T, r io.Reader) []map[string]interface{} {
+	messages := []map[string]interface{}{}
+	reader := textproto.NewReader(bufio.NewReader(r))
+	for {
+		header, err := reader.ReadMIMEHeader()
+		if err == io.EOF {
+			return messages
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		length, _ := strconv.Atoi(header.Get("Content-Length"))
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader.R, body); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		msg := map[string]interface{}{}
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		messages = append(messages, msg)
+	}
+}
+
+func response(t *testing.T, messages []map[string]interface{}, id int) map[string]interface{} {
+	for _, msg := range messages {
+		if msgID, ok := msg["id"].(float64); ok && int(msgID) == id {
+			return msg
+		}
+	}
+	t.Fatalf("no response to request %v", id)
+	return nil
+}
+
+// diagnostics returns the last diagnostics published for uri, as
+// "line:character-line:character severity message" strings.
+func diagnostics(t *testing.T, messages []map[string]interface{}, uri string) []string {
+	var result []string
+	for _, msg := range messages {
+		params, ok := msg["params"].(map[string]interface{})
+		if msg["method"] != "textDocument/publishDiagnostics" || !ok || params["uri"] != uri {
+			continue
+		}
+		result = []string{}
+		for _, diagnostic := range params["diagnostics"].([]interface{}) {
+			diagnostic := diagnostic.(map[string]interface{})
+			r := diagnostic["range"].(map[string]interface{})
+			start := r["start"].(map[string]interface{})
+			end := r["end"].(map[string]interface{})
+			result = append(result, fmt.Sprintf("%v:%v-%v:%v %v %v",
+				start["line"], start["character"], end["line"], end["character"], diagnostic["severity"], diagnostic["message"]))
+		}
+	}
+	if result == nil {
+		t.Fatalf("no diagnostics published for %v", uri)
+	}
+	return result
+}
+
+func TestServer_InitializesAndShutsDown(t *testing.T) {
+	s := &session{t: t}
+	initialize := s.request("initialize", map[string]interface{}{})
+	unknown := s.request("workspace/symbol", map[string]interface{}{})
+	messages := s.run(testSchema(t))
+
+	capabilities := response(t, messages, initialize)["result"].(map[string]interface{})["capabilities"].(map[string]interface{})
+	if capabilities["hoverProvider"] != true || capabilities["definitionProvider"] != true ||
+		capabilities["completionProvider"] == nil || capabilities["textDocumentSync"] != float64(1) {
+		t.Fatalf("unexpected capabilities: %v", capabilities)
+	}
+	if code := response(t, messages, unknown)["error"].(map[string]interface{})["code"]; code != float64(-32601) {
+		t.Fatalf("expected a method not found error, got %v", code)
+	}
+	if result, ok := response(t, messages, s.nextID)["result"]; !ok || result != nil {
+		t.Fatalf("expected a null result to shutdown, got %v", result)
+	}
+}
+
+func TestServer_FailsOnExitWithoutShutdown(t *testing.T) {
+	s := &session{t: t}
+	s.notify("exit", nil)
+	if err := lsp.NewServer(testSchema(t)).Serve(&s.input, &bytes.Buffer{}); err != lsp.ErrExitWithoutShutdown {
+		t.Fatalf("expected ErrExitWithoutShutdown, got %v", err)
+	}
+}
+
+func TestServer_RejectsNegativeContentLengths(t *testing.T) {
+	input := strings.NewReader("Content-Length: -1\r\n\r\n{}")
+	err := lsp.NewServer(testSchema(t)).Serve(input, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "invalid Content-Length header") {
+		t.Fatalf("expected an invalid Content-Length error, got %v", err)
+	}
+}
+
+func TestServer_PublishesSyntaxAndValidationErrorsAndDeprecations(t *testing.T) {
+	s := newSession(t)
+	s.open("file:///valid.graphql", "{\n  user(id: 1) {\n    name\n  }\n}\n")
+	s.open("file:///invalid.graphql", "{\n  me { name }\n  user(id: 1) { nam }\n  users(filter: {role: GUEST}) { id }\n}\n")
+	s.open("file:///syntax.graphql", "{\n  user(id: 1) { name ^ }\n  users { nam }\n}\n")
+	messages := s.run(testSchema(t))
+
+	if diags := diagnostics(t, messages, "file:///valid.graphql"); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", diags)
+	}
+	expected := []string{
+		`2:16-2:19 1 Cannot query field "nam" on type "User". Did you mean "name"?`,
+		`1:2-1:4 2 The field Query.me is deprecated. Use user.`,
+		`3:23-3:28 2 The enum value Role.GUEST is deprecated. Guests are gone.`,
+	}
+	if diags := diagnostics(t, messages, "file:///invalid.graphql"); !reflect.DeepEqual(expected, diags) {
+		t.Fatalf("unexpected diagnostics:\n%v", strings.Join(diags, "\n"))
+	}
+	expected = []string{
+		`1:21-1:22 1 Syntax Error: Unexpected character "^".`,
+		`2:10-2:13 1 Cannot query field "nam" on type "User". Did you mean "name"?`,
+	}
+	if diags := diagnostics(t, messages, "file:///syntax.graphql"); !reflect.DeepEqual(expected, diags) {
+		t.Fatalf("unexpected diagnostics:\n%v", strings.Join(diags, "\n"))
+	}
+}
+
+func TestServer_ReportsMissingVariableTypes(t *testing.T) {
+	s := newSession(t)
+	s.open("file:///typing.graphql", "query Q($a: ) { user(id: 1) { name } }")
+	messages := s.run(testSchema(t))
+
+	expected := []string{
+		`0:12-0:13 1 Syntax Error: Unexpected )`,
+	}
+	if diags := diagnostics(t, messages, "file:///typing.graphql"); !reflect.DeepEqual(expected, diags) {
+		t.Fatalf("unexpected diagnostics:\n%v", strings.Join(diags, "\n"))
+	}
+}
+
+func TestServer_RecoversFromPanics(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: testSchema(t).QueryType(),
+		Visibility: func(ctx context.Context, parentType graphql.Type, fieldDef *graphql.FieldDefinition) bool {
+			panic(errors.New("visibility failed"))
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s := newSession(t)
+	s.open("file:///panic.graphql", "{ me { name } }")
+	hover := s.at("textDocument/hover", "file:///panic.graphql", "{ m|e { name } }")
+	messages := s.run(&schema)
+
+	logged := 0
+	for _, msg := range messages {
+		if msg["method"] == "window/logMessage" {
+			logged++
+		}
+	}
+	if logged == 0 {
+		t.Fatal("expected the panics to be logged")
+	}
+	if response(t, messages, hover) == nil {
+		t.Fatal("expected a response to the hover request")
+	}
+	if _, ok := response(t, messages, s.nextID)["result"]; !ok {
+		t.Fatal("expected the server to keep serving")
+	}
+}
+
+func TestServer_ValidatesFragmentsAcrossDocumentsAndUpdatesOnChanges(t *testing.T) {
+	s := newSession(t)
+	s.open("file:///query.graphql", "{\n  user(id: 1) {\n    ...UserFields\n  }\n}\n")
+	first := bytes.Buffer{}
+	first.Write(s.input.Bytes())
+	s.open("file:///fragment.graphql", "fragment UserFields on User {\n  nam\n}\n")
+	s.change("file:///fragment.graphql", 2, "fragment UserFields on User {\n  name\n}\n")
+	definition := s.at("textDocument/definition", "file:///query.graphql", "{\n  user(id: 1) {\n    ...User|Fields\n  }\n}\n")
+	messages := s.run(testSchema(t))
+
+	unknown := []string{`2:7-2:17 1 Unknown fragment "UserFields".`}
+	firstMessages := readMessages(t, bytes.NewReader(serve(t, first.Bytes())))
+	if diags := diagnostics(t, firstMessages, "file:///query.graphql"); !reflect.DeepEqual(unknown, diags) {
+		t.Fatalf("unexpected diagnostics:\n%v", strings.Join(diags, "\n"))
+	}
+	if diags := diagnostics(t, messages, "file:///query.graphql"); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", diags)
+	}
+	if diags := diagnostics(t, messages, "file:///fragment.graphql"); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics after the change, got %v", diags)
+	}
+
+	location := response(t, messages, definition)["result"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"uri": "file:///fragment.graphql",
+		"range": map[string]interface{}{
+			"start": map[string]interface{}{"line": float64(0), "character": float64(0)},
+			"end":   map[string]interface{}{"line": float64(2), "character": float64(1)},
+		},
+	}
+	if !reflect.DeepEqual(expected, location) {
+		t.Fatalf("unexpected definition: %v", location)
+	}
+}
+
+func serve(t *testing.T, input []byte) []byte {
+	output := bytes.Buffer{}
+	if err := lsp.NewServer(testSchema(t)).Serve(bytes.NewReader(input), &output); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return output.Bytes()
+}
+
+func TestServer_CompletesAtTheCursor(t *testing.T) {
+	tests := map[string]struct {
+		text   string
+		labels []string
+	}{
+		"operations": {
+			text:   "|",
+			labels: []string{"query", "mutation", "subscription", "fragment"},
+		},
+		"root fields": {
+			text:   "{ |",
+			labels: []string{"search", "user", "users", "__typename", "__schema", "__type", "me"},
+		},
+		"fields by prefix": {
+			text:   "query Q {\n  us| }",
+			labels: []string{"user", "users"},
+		},
+		"nested fields": {
+			text:   "{ user(id: 1) { name fr| } }",
+			labels: []string{"friends"},
+		},
+		"fields after alias": {
+			text:   "{ u: user(id: 1) { n: | } }",
+			labels: []string{"friends", "id", "name", "role", "__typename"},
+		},
+		"union fields": {
+			text:   "{ search(term: \"a\") { | } }",
+			labels: []string{"__typename"},
+		},
+		"arguments": {
+			text:   "{ user(id: 1, |) }",
+			labels: []string{"role"},
+		},
+		"enum values": {
+			text:   "{ user(id: 1, role: |) }",
+			labels: []string{"ADMIN", "MEMBER", "GUEST"},
+		},
+		"input object fields": {
+			text:   "{ users(filter: {role: ADMIN, |}) { id } }",
+			labels: []string{"name"},
+		},
+		"enum values in input objects": {
+			text:   "{ users(filter: {role: M|}) { id } }",
+			labels: []string{"MEMBER", "ADMIN"},
+		},
+		"directives": {
+			text:   "{ me @|",
+			labels: []string{"include", "skip"},
+		},
+		"directive arguments": {
+			text:   "{ me @include(|) }",
+			labels: []string{"if"},
+		},
+		"boolean values": {
+			text:   "{ me @include(if: |) }",
+			labels: []string{"true", "false"},
+		},
+		"type conditions": {
+			text:   "{ search(term: \"a\") { ... on | } }",
+			labels: []string{"Node", "Post", "SearchResult", "User"},
+		},
+		"inline fragment fields": {
+			text:   "{ search(term: \"a\") { ... on Post { | } } }",
+			labels: []string{"title", "__typename"},
+		},
+		"fragment spreads": {
+			text:   "{ me { ...| } }\nfragment F on User { id }\nfragment P on Post { title }",
+			labels: []string{"F", "on"},
+		},
+		"variable types": {
+			text:   "query Q($role: |",
+			labels: []string{"Boolean", "ID", "Int", "Role", "String", "UserFilter"},
+		},
+		"variables": {
+			text:   "query Q($role: Role, $id: ID!) { user(id: $|",
+			labels: []string{"role", "id"},
+		},
+		"within comments": {
+			text:   "{ # us|",
+			labels: []string{},
+		},
+		"within strings": {
+			text:   "{ search(term: \"a|\") }",
+			labels: []string{},
+		},
+		"type definitions": {
+			text:   "type A {\n  |\n}",
+			labels: []string{},
+		},
+	}
+	for name, test := range tests {
+		s := newSession(t)
+		id := s.at("textDocument/completion", "file:///query.graphql", test.text)
+		messages := s.run(testSchema(t))
+		result := response(t, messages, id)["result"].(map[string]interface{})
+		labels := []string{}
+		for _, item := range result["items"].([]interface{}) {
+			labels = append(labels, item.(map[string]interface{})["label"].(string))
+		}
+		if !reflect.DeepEqual(test.labels, labels) {
+			t.Fatalf("%v: expected %v, got %v", name, test.labels, labels)
+		}
+	}
+}
+
+func TestServer_DescribesCompletionItems(t *testing.T) {
+	s := newSession(t)
+	id := s.at("textDocument/completion", "file:///query.graphql", "{ user(id: 1, role: G|) }")
+	messages := s.run(testSchema(t))
+	items := response(t, messages, id)["result"].(map[string]interface{})["items"].([]interface{})
+	expected := []interface{}{
+		map[string]interface{}{
+			"label":         "GUEST",
+			"kind":          float64(lsp.CompletionKindEnumMember),
+			"detail":        "Role",
+			"documentation": map[string]interface{}{"kind": "markdown", "value": "Deprecated: Guests are gone."},
+			"deprecated":    true,
+			"tags":          []interface{}{float64(lsp.CompletionTagDeprecated)},
+			"sortText":      "0000",
+		},
+	}
+	if !reflect.DeepEqual(expected, items) {
+		t.Fatalf("unexpected items: %v", items)
+	}
+}
+
+func TestServer_Hovers(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		contents string
+	}{
+		"fields": {
+			text:     "{ us|er(id: 1) { name } }",
+			contents: "```graphql\nQuery.user(id: ID!, role: Role): User\n```\n\nLooks a user up by id.",
+		},
+		"deprecated fields": {
+			text:     "{ me| { name } }",
+			contents: "```graphql\nQuery.me: User\n```\n\nDeprecated: Use user.",
+		},
+		"arguments": {
+			text:     "{ user(id: 1, r|ole: ADMIN) { name } }",
+			contents: "```graphql\nrole: Role\n```\n\nFilters on the role.",
+		},
+		"enum values": {
+			text:     "{ user(id: 1, role: GU|EST) { name } }",
+			contents: "```graphql\nRole.GUEST\n```\n\nDeprecated: Guests are gone.",
+		},
+		"input object fields": {
+			text:     "{ users(filter: {ro|le: ADMIN}) { name } }",
+			contents: "```graphql\nUserFilter.role: Role\n```\n\nThe role of the users.",
+		},
+		"types": {
+			text:     "query { me { ... on Us|er { name } } }",
+			contents: "```graphql\ntype User\n```",
+		},
+		"directives": {
+			text:     "{ me @sk|ip(if: true) { name } }",
+			contents: "```graphql\n@skip(if: Boolean!)\n```\n\nDirects the executor to skip this field or fragment when the `if` argument is true.",
+		},
+	}
+	for name, test := range tests {
+		s := newSession(t)
+		id := s.at("textDocument/hover", "file:///query.graphql", test.text)
+		messages := s.run(testSchema(t))
+		result, ok := response(t, messages, id)["result"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("%v: expected a hover", name)
+		}
+		if contents := result["contents"].(map[string]interface{})["value"]; contents != test.contents {
+			t.Fatalf("%v: unexpected contents:\n%v", name, contents)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package lsp
+
+import "encoding/json"
+
+// The subset of the Language Server Protocol types used by the server, see
+// https://microsoft.github.io/language-server-protocol/specification.
+
+// Position is a zero-based line and character offset, in UTF-16 code units,
+// in a text document.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range is a range of a text document, End being exclusive.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Location is a range of the document named by URI.
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// DiagnosticSeverity is the severity of a Diagnostic.
+type DiagnosticSeverity int
+
+const (
+	SeverityError       DiagnosticSeverity = 1
+	SeverityWarning     DiagnosticSeverity = 2
+	SeverityInformation DiagnosticSeverity = 3
+	SeverityHint        DiagnosticSeverity = 4
+)
+
+// DiagnosticTag adds information on how a Diagnostic is displayed.
+type DiagnosticTag int
+
+const (
+	TagUnnecessary DiagnosticTag = 1
+	TagDeprecated  DiagnosticTag = 2
+)
+
+// Diagnostic is a syntax error, a validation error or a warning of a document.
+type Diagnostic struct {
+	Range    Range              `json:"range"`
+	Severity DiagnosticSeverity `json:"severity"`
+	Source   string             `json:"source"`
+	Message  string             `json:"message"`
+	Tags     []DiagnosticTag    `json:"tags,omitempty"`
+}
+
+// PublishDiagnosticsParams are sent with the textDocument/publishDiagnostics
+// notification.
+type PublishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Version     int          `json:"version,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// CompletionItemKind is the kind of a CompletionItem.
+type CompletionItemKind int
+
+const (
+	CompletionKindText       CompletionItemKind = 1
+	CompletionKindFunction   CompletionItemKind = 3
+	CompletionKindField      CompletionItemKind = 5
+	CompletionKindVariable   CompletionItemKind = 6
+	CompletionKindClass      CompletionItemKind = 7
+	CompletionKindInterface  CompletionItemKind = 8
+	CompletionKindProperty   CompletionItemKind = 10
+	CompletionKindValue      CompletionItemKind = 12
+	CompletionKindEnum       CompletionItemKind = 13
+	CompletionKindKeyword    CompletionItemKind = 14
+	CompletionKindReference  CompletionItemKind = 18
+	CompletionKindEnumMember CompletionItemKind = 20
+	CompletionKindStruct     CompletionItemKind = 22
+)
+
+// CompletionItemTag adds information on how a CompletionItem is displayed.
+type CompletionItemTag int
+
+const CompletionTagDeprecated CompletionItemTag = 1
+
+// CompletionItem is a candidate returned by textDocument/completion.
+type CompletionItem struct {
+	Label         string              `json:"label"`
+	Kind          CompletionItemKind  `json:"kind,omitempty"`
+	Detail        string              `json:"detail,omitempty"`
+	Documentation *MarkupContent      `json:"documentation,omitempty"`
+	Deprecated    bool                `json:"deprecated,omitempty"`
+	Tags          []CompletionItemTag `json:"tags,omitempty"`
+	SortText      string              `json:"sortText,omitempty"`
+}
+
+// CompletionList is the result of textDocument/completion.
+type CompletionList struct {
+	IsIncomplete bool             `json:"isIncomplete"`
+	Items        []CompletionItem `json:"items"`
+}
+
+// MarkupContent is a documentation string, in markdown or plain text.
+type MarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// Hover is the result of textDocument/hover.
+type Hover struct {
+	Contents MarkupContent `json:"contents"`
+	Range    *Range        `json:"range,omitempty"`
+}
+
+type textDocumentItem struct {
+	URI     string `json:"uri"`
+	Version int    `json:"version"`
+	Text    string `json:"text"`
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type didOpenParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+type didChangeParams struct {
+	TextDocument struct {
+		URI     string `json:"uri"`
+		Version int    `json:"version"`
+	} `json:"textDocument"`
+	ContentChanges []struct {
+		Range *Range `json:"range"`
+		Text  string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type didCloseParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+type textDocumentPositionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Position     Position               `json:"position"`
+}
+
+// message is a JSON-RPC 2.0 request, response or notification.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *responseError   `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
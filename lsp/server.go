@@ -0,0 +1,290 @@
+// Package lsp implements a Language Server Protocol server for GraphQL
+// documents validated against a schema. It reports syntax errors, validation
+// errors and the use of deprecated fields and enum values as diagnostics, and
+// answers completion, hover and go-to-definition requests.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// ErrExitWithoutShutdown is returned by Serve when the client sends the exit
+// notification without requesting a shutdown first.
+var ErrExitWithoutShutdown = errors.New("lsp: exit without shutdown")
+
+// Server is a language server for the documents of a schema. It serves a
+// single client, see Serve.
+type Server struct {
+	schema    *graphql.Schema
+	documents map[string]*document
+	out       io.Writer
+	shutdown  bool
+}
+
+// NewServer returns a server validating the documents against schema.
+func NewServer(schema *graphql.Schema) *Server {
+	return &Server{
+		schema:    schema,
+		documents: map[string]*document{},
+	}
+}
+
+// Serve reads the JSON-RPC messages of the client from r and writes the
+// responses and notifications to w, both framed with Content-Length headers,
+// until the client sends the exit notification or r is exhausted.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.out = w
+	reader := textproto.NewReader(bufio.NewReader(r))
+	for {
+		header, err := reader.ReadMIMEHeader()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		length, err := strconv.Atoi(header.Get("Content-Length"))
+		if err != nil {
+			return fmt.Errorf("lsp: invalid Content-Length header: %v", err)
+		}
+		if length < 0 {
+			return fmt.Errorf("lsp: invalid Content-Length header: %d", length)
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader.R, body); err != nil {
+			return err
+		}
+
+		msg := &message{}
+		if err := json.Unmarshal(body, msg); err != nil {
+			if err := s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return ErrExitWithoutShutdown
+			}
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches msg and replies to it if it is a request. Only errors
+// writing to the client are returned: a panic handling msg, e.g. validating a
+// document being typed, is reported to the client instead of ending Serve.
+func (s *Server) handle(msg *message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = s.recovered(msg, r)
+		}
+	}()
+	return s.dispatch(msg)
+}
+
+func (s *Server) dispatch(msg *message) error {
+	if msg.ID == nil {
+		return s.notify(msg)
+	}
+	if s.shutdown {
+		return s.reply(msg.ID, nil, &responseError{Code: codeInvalidRequest, Message: "server is shut down"})
+	}
+	var result interface{}
+	var err error
+	switch msg.Method {
+	case "initialize":
+		result = map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": 1,
+				"completionProvider": map[string]interface{}{
+					"triggerCharacters": []string{"{", "(", ":", "@", "$", "."},
+				},
+				"hoverProvider":      true,
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]interface{}{
+				"name": "graphql-lsp",
+			},
+		}
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/completion":
+		params := textDocumentPositionParams{}
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			result = s.completion(params)
+		}
+	case "textDocument/hover":
+		params := textDocumentPositionParams{}
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			if hover := s.hover(params); hover != nil {
+				result = hover
+			}
+		}
+	case "textDocument/definition":
+		params := textDocumentPositionParams{}
+		if err = json.Unmarshal(msg.Params, &params); err == nil {
+			if location := s.definition(params); location != nil {
+				result = location
+			}
+		}
+	default:
+		return s.reply(msg.ID, nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method})
+	}
+	if err != nil {
+		return s.reply(msg.ID, nil, &responseError{Code: codeInvalidParams, Message: err.Error()})
+	}
+	return s.reply(msg.ID, result, nil)
+}
+
+// recovered reports the panic r handling msg: requests get an internal error
+// response, notifications an error message in the log of the client.
+func (s *Server) recovered(msg *message, r interface{}) error {
+	text := fmt.Sprintf("lsp: %v: %v", msg.Method, r)
+	if msg.ID != nil {
+		return s.reply(msg.ID, nil, &responseError{Code: codeInternalError, Message: text})
+	}
+	return s.send(&message{
+		Method: "window/logMessage",
+		Params: mustMarshal(map[string]interface{}{"type": 1, "message": text}),
+	})
+}
+
+// notify handles the notifications of the client, unknown ones are ignored.
+func (s *Server) notify(msg *message) error {
+	switch msg.Method {
+	case "textDocument/didOpen":
+		params := didOpenParams{}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		s.documents[params.TextDocument.URI] = newDocument(params.TextDocument.URI, params.TextDocument.Version, params.TextDocument.Text)
+		return s.publishDiagnostics()
+	case "textDocument/didChange":
+		params := didChangeParams{}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		doc, ok := s.documents[params.TextDocument.URI]
+		if !ok {
+			return nil
+		}
+		text := doc.text
+		for _, change := range params.ContentChanges {
+			if change.Range == nil {
+				text = change.Text
+				continue
+			}
+			// ranges are accepted even though full synchronization is requested
+			current := newDocument(doc.uri, 0, text)
+			text = text[:current.offset(change.Range.Start)] + change.Text + text[current.offset(change.Range.End):]
+		}
+		s.documents[doc.uri] = newDocument(doc.uri, params.TextDocument.Version, text)
+		return s.publishDiagnostics()
+	case "textDocument/didClose":
+		params := didCloseParams{}
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		if _, ok := s.documents[params.TextDocument.URI]; !ok {
+			return nil
+		}
+		delete(s.documents, params.TextDocument.URI)
+		err := s.send(&message{
+			Method: "textDocument/publishDiagnostics",
+			Params: mustMarshal(PublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}}),
+		})
+		if err != nil {
+			return err
+		}
+		return s.publishDiagnostics()
+	}
+	return nil
+}
+
+// publishDiagnostics sends the diagnostics of every open document, in the
+// order of their URIs, since fragments may be defined in other documents.
+func (s *Server) publishDiagnostics() error {
+	for _, uri := range s.uris() {
+		doc := s.documents[uri]
+		err := s.send(&message{
+			Method: "textDocument/publishDiagnostics",
+			Params: mustMarshal(PublishDiagnosticsParams{
+				URI:         uri,
+				Version:     doc.version,
+				Diagnostics: s.diagnostics(doc),
+			}),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) uris() []string {
+	uris := []string{}
+	for uri := range s.documents {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}, respErr *responseError) error {
+	msg := &message{ID: id, Error: respErr}
+	if id == nil {
+		null := json.RawMessage("null")
+		msg.ID = &null
+	}
+	if respErr == nil {
+		msg.Result = mustMarshal(result)
+	}
+	return s.send(msg)
+}
+
+func (s *Server) send(msg *message) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = s.out.Write(body)
+	return err
+}
+
+func mustMarshal(v interface{}) json.RawMessage {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return body
+}
+
+// markdown returns a hover or documentation content made of a GraphQL
+// signature followed by the given paragraphs.
+func markdown(signature string, paragraphs ...string) MarkupContent {
+	parts := []string{"```graphql\n" + signature + "\n```"}
+	for _, paragraph := range paragraphs {
+		if paragraph != "" {
+			parts = append(parts, paragraph)
+		}
+	}
+	return MarkupContent{Kind: "markdown", Value: strings.Join(parts, "\n\n")}
+}
@@ -0,0 +1,658 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// MergeConflict describes a type or root field defined by more than one of
+// the schemas given to MergeSchemas.
+type MergeConflict struct {
+	// Schema is the index of the schema redefining the type or field.
+	Schema int
+
+	// TypeName is the conflicting type, or the root type holding the
+	// conflicting field.
+	TypeName string
+
+	// FieldName is the conflicting root field, empty for type conflicts.
+	FieldName string
+}
+
+// MergeOptions configures how MergeSchemas resolves conflicts. Without
+// options any conflict is reported as an error.
+type MergeOptions struct {
+	// Prefixes holds a prefix per schema, by index. A conflicting type or
+	// root field of a schema with a prefix is renamed to prefix+name.
+	Prefixes []string
+
+	// Rename returns the new name of a conflicting type or root field. It
+	// takes precedence over Prefixes; returning "" reports the conflict.
+	Rename func(conflict MergeConflict) string
+}
+
+func (opts MergeOptions) rename(conflict MergeConflict) string {
+	name := conflict.TypeName
+	if conflict.FieldName != "" {
+		name = conflict.FieldName
+	}
+	if opts.Rename != nil {
+		if renamed := opts.Rename(conflict); renamed != "" {
+			return renamed
+		}
+	}
+	if conflict.Schema < len(opts.Prefixes) && opts.Prefixes[conflict.Schema] != "" {
+		return opts.Prefixes[conflict.Schema] + name
+	}
+	return ""
+}
+
+// MergeSchemas combines the root fields, types and directives of several
+// schemas into a new one, leaving the given schemas untouched.
+//
+// Types with the same name must have the same definition, in which case the
+// definition of the first schema is kept; root fields must be unique. Other
+// conflicts are resolved with opts or reported as errors. Resolvers are kept
+// as is: the fields of a type defined by several schemas are resolved by the
+// resolvers of the schema defining the root field their value comes from.
+// The extensions of every schema are added to the merged schema, and fields
+// are visible if the Visibility functions of all schemas accept them.
+func MergeSchemas(opts MergeOptions, schemas ...Schema) (Schema, error) {
+	if len(schemas) == 0 {
+		return Schema{}, fmt.Errorf("Must provide at least one schema to merge.")
+	}
+	m := &schemaMerger{
+		opts:    opts,
+		schemas: schemas,
+		renames: make([]map[string]string, len(schemas)),
+		types:   TypeMap{},
+		roots:   map[string]map[string]int{},
+	}
+	for name, ttype := range specifiedScalarTypes {
+		m.types[name] = ttype
+	}
+	for i, schema := range schemas {
+		m.renames[i] = map[string]string{}
+		for _, root := range []struct {
+			object *Object
+			name   string
+		}{
+			{schema.QueryType(), "Query"},
+			{schema.MutationType(), "Mutation"},
+			{schema.SubscriptionType(), "Subscription"},
+		} {
+			if root.object != nil {
+				m.renames[i][root.object.Name()] = root.name
+			}
+		}
+	}
+
+	if err := m.resolveTypeNames(); err != nil {
+		return Schema{}, err
+	}
+	m.buildTypes()
+
+	query, err := m.mergeRoot("Query", (*Schema).QueryType)
+	if err != nil {
+		return Schema{}, err
+	}
+	mutation, err := m.mergeRoot("Mutation", (*Schema).MutationType)
+	if err != nil {
+		return Schema{}, err
+	}
+	subscription, err := m.mergeRoot("Subscription", (*Schema).SubscriptionType)
+	if err != nil {
+		return Schema{}, err
+	}
+	directives, err := m.mergeDirectives()
+	if err != nil {
+		return Schema{}, err
+	}
+
+	names := []string{}
+	for name := range m.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	types := []Type{}
+	for _, name := range names {
+		types = append(types, m.types[name])
+	}
+	merged, err := NewSchema(SchemaConfig{
+		Query:        query,
+		Mutation:     mutation,
+		Subscription: subscription,
+		Types:        types,
+		Directives:   directives,
+		Visibility:   mergeVisibility(schemas),
+	})
+	if err != nil {
+		return merged, err
+	}
+
+	seen := map[string]bool{}
+	for _, schema := range schemas {
+		for _, extension := range schema.Extensions() {
+			if !seen[extension.Name()] {
+				seen[extension.Name()] = true
+				merged.AddExtensions(extension)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// mergeVisibility returns a Visibility function accepting the fields accepted
+// by the Visibility functions of all schemas.
+func mergeVisibility(schemas []Schema) SchemaVisibility {
+	visibilities := []SchemaVisibility{}
+	for _, schema := range schemas {
+		if visibility := schema.Visibility(); visibility != nil {
+			visibilities = append(visibilities, visibility)
+		}
+	}
+	switch len(visibilities) {
+	case 0:
+		return nil
+	case 1:
+		return visibilities[0]
+	}
+	return func(ctx context.Context, parentType Type, fieldDef *FieldDefinition) bool {
+		for _, visibility := range visibilities {
+			if !visibility(ctx, parentType, fieldDef) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+type mergeSource struct {
+	schema int
+	ttype  Type
+}
+
+type schemaMerger struct {
+	opts    MergeOptions
+	schemas []Schema
+
+	// renames maps the type names of each schema to their merged names,
+	// for the root types and the renamed types only
+	renames []map[string]string
+
+	// sources of each merged named type
+	sources map[string][]mergeSource
+
+	types TypeMap
+
+	// roots maps the fields of the merged root types, by root type name, to
+	// the schema defining them
+	roots map[string]map[string]int
+}
+
+func (m *schemaMerger) lookup(schema int, name string) string {
+	if renamed, ok := m.renames[schema][name]; ok {
+		return renamed
+	}
+	return name
+}
+
+func (m *schemaMerger) isRoot(schema int, name string) bool {
+	s := m.schemas[schema]
+	for _, root := range []*Object{s.QueryType(), s.MutationType(), s.SubscriptionType()} {
+		if root != nil && root.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveTypeNames groups the types of all schemas by merged name. Renaming a
+// type changes the definitions referencing it, so conflicts are looked for
+// until no more types get renamed.
+func (m *schemaMerger) resolveTypeNames() error {
+	for changed := true; changed; {
+		changed = false
+		m.sources = map[string][]mergeSource{}
+		for i, schema := range m.schemas {
+			typeMap := schema.TypeMap()
+			names := []string{}
+			for name := range typeMap {
+				if strings.HasPrefix(name, "__") || isSpecifiedScalarName(name) || m.isRoot(i, name) {
+					continue
+				}
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				ttype := typeMap[name]
+				target := m.lookup(i, name)
+				if existing, ok := m.sources[target]; ok && m.conflicts(existing[0], mergeSource{i, ttype}) {
+					if target != name {
+						return fmt.Errorf(`Cannot rename type "%v" of schema %v to "%v", the name is already taken.`, name, i, target)
+					}
+					renamed := m.opts.rename(MergeConflict{Schema: i, TypeName: name})
+					if renamed == "" {
+						return fmt.Errorf(`Type "%v" is defined differently by schemas %v and %v.`, name, existing[0].schema, i)
+					}
+					m.renames[i][name] = renamed
+					changed = true
+					break
+				}
+				m.sources[target] = append(m.sources[target], mergeSource{i, ttype})
+			}
+			if changed {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (m *schemaMerger) conflicts(a, b mergeSource) bool {
+	if a.ttype == b.ttype {
+		return false
+	}
+	return m.signature(a) != m.signature(b)
+}
+
+// signature prints the definition of a type as it would appear in the merged
+// schema.
+func (m *schemaMerger) signature(source mergeSource) string {
+	rename := func(named *ast.Named) {
+		named.Name.Value = m.lookup(source.schema, named.Name.Value)
+	}
+	def := typeDefinitionAST(source.ttype)
+	switch def := def.(type) {
+	case *ast.ObjectDefinition:
+		for _, iface := range def.Interfaces {
+			rename(iface)
+		}
+		for _, field := range def.Fields {
+			renameTypeAST(field.Type, rename)
+			for _, arg := range field.Arguments {
+				renameTypeAST(arg.Type, rename)
+			}
+		}
+	case *ast.InterfaceDefinition:
+		for _, field := range def.Fields {
+			renameTypeAST(field.Type, rename)
+			for _, arg := range field.Arguments {
+				renameTypeAST(arg.Type, rename)
+			}
+		}
+	case *ast.UnionDefinition:
+		for _, object := range def.Types {
+			rename(object)
+		}
+	case *ast.InputObjectDefinition:
+		for _, field := range def.Fields {
+			renameTypeAST(field.Type, rename)
+		}
+	}
+	printed, _ := printer.Print(def).(string)
+	return printed
+}
+
+func renameTypeAST(ttype ast.Type, rename func(*ast.Named)) {
+	switch ttype := ttype.(type) {
+	case *ast.List:
+		renameTypeAST(ttype.Type, rename)
+	case *ast.NonNull:
+		renameTypeAST(ttype.Type, rename)
+	case *ast.Named:
+		rename(ttype)
+	}
+}
+
+// buildTypes creates the merged named types. Fields are thunks, so types can
+// refer to each other regardless of the order they are built in.
+func (m *schemaMerger) buildTypes() {
+	for name, sources := range m.sources {
+		m.types[name] = m.buildType(name, sources)
+	}
+}
+
+func (m *schemaMerger) buildType(name string, sources []mergeSource) Type {
+	first := sources[0]
+	switch ttype := first.ttype.(type) {
+	case *Scalar:
+		if ttype.Name() == name {
+			return ttype
+		}
+		config := ttype.scalarConfig
+		config.Name = name
+		return NewScalar(config)
+	case *Enum:
+		if ttype.Name() == name {
+			return ttype
+		}
+		config := ttype.enumConfig
+		config.Name = name
+		return NewEnum(config)
+	case *Object:
+		return NewObject(ObjectConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			IsTypeOf:    m.isTypeOf(sources),
+			Interfaces: InterfacesThunk(func() []*Interface {
+				interfaces := []*Interface{}
+				for _, iface := range ttype.Interfaces() {
+					interfaces = append(interfaces, m.types[m.lookup(first.schema, iface.Name())].(*Interface))
+				}
+				return interfaces
+			}),
+			Fields: FieldsThunk(func() Fields {
+				fields := m.fields(first.schema, ttype.Fields())
+				if len(sources) > 1 {
+					for fieldName, field := range fields {
+						field.Resolve = m.resolveField(sources, fieldName)
+					}
+				}
+				return fields
+			}),
+		})
+	case *Interface:
+		return NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			ResolveType: m.resolveType(sources),
+			Fields: FieldsThunk(func() Fields {
+				return m.fields(first.schema, ttype.Fields())
+			}),
+		})
+	case *Union:
+		return NewUnion(UnionConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			ResolveType: m.resolveType(sources),
+			Types: UnionTypesThunk(func() []*Object {
+				objects := []*Object{}
+				for _, object := range ttype.Types() {
+					objects = append(objects, m.types[m.lookup(first.schema, object.Name())].(*Object))
+				}
+				return objects
+			}),
+		})
+	case *InputObject:
+		return NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: ttype.Description(),
+			Fields: InputObjectConfigFieldMapThunk(func() InputObjectConfigFieldMap {
+				fields := InputObjectConfigFieldMap{}
+				for fieldName, field := range ttype.Fields() {
+					fields[fieldName] = &InputObjectFieldConfig{
+						Type:         m.typeRef(first.schema, field.Type).(Input),
+						DefaultValue: field.DefaultValue,
+						Description:  field.Description(),
+					}
+				}
+				return fields
+			}),
+		})
+	}
+	return first.ttype
+}
+
+// isTypeOf accepts the values accepted by any of the merged definitions.
+func (m *schemaMerger) isTypeOf(sources []mergeSource) IsTypeOfFn {
+	fns := []IsTypeOfFn{}
+	for _, source := range sources {
+		if object, ok := source.ttype.(*Object); ok && object.IsTypeOf != nil {
+			fns = append(fns, object.IsTypeOf)
+		}
+	}
+	if len(fns) == 0 {
+		return nil
+	}
+	return func(p IsTypeOfParams) bool {
+		for _, fn := range fns {
+			if fn(p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// resolveType asks each merged definition in turn, mapping the object it
+// returns to the merged object of the same schema.
+func (m *schemaMerger) resolveType(sources []mergeSource) ResolveTypeFn {
+	type resolver struct {
+		schema int
+		fn     ResolveTypeFn
+	}
+	resolvers := []resolver{}
+	for _, source := range sources {
+		var fn ResolveTypeFn
+		switch ttype := source.ttype.(type) {
+		case *Interface:
+			fn = ttype.ResolveType
+		case *Union:
+			fn = ttype.ResolveType
+		}
+		if fn != nil {
+			resolvers = append(resolvers, resolver{source.schema, fn})
+		}
+	}
+	if len(resolvers) == 0 {
+		return nil
+	}
+	return func(p ResolveTypeParams) *Object {
+		for _, resolver := range resolvers {
+			object := resolver.fn(p)
+			if object == nil {
+				continue
+			}
+			if m.types[object.Name()] == object {
+				return object
+			}
+			if merged, ok := m.types[m.lookup(resolver.schema, object.Name())].(*Object); ok {
+				return merged
+			}
+		}
+		return nil
+	}
+}
+
+// resolveField returns the resolver of the field of an object defined by
+// several schemas, calling the resolver of the schema defining the root field
+// the value comes from, or of the first schema if it does not define the
+// object.
+func (m *schemaMerger) resolveField(sources []mergeSource, fieldName string) FieldResolveFn {
+	resolvers := map[int]FieldResolveFn{}
+	for _, source := range sources {
+		resolve := DefaultResolveFn
+		if def := source.ttype.(*Object).Fields()[fieldName]; def != nil && def.Resolve != nil {
+			resolve = def.Resolve
+		}
+		resolvers[source.schema] = resolve
+	}
+	first := resolvers[sources[0].schema]
+	return func(p ResolveParams) (interface{}, error) {
+		if schema, ok := m.rootSchema(p.Info); ok {
+			if resolve, ok := resolvers[schema]; ok {
+				return resolve(p)
+			}
+		}
+		return first(p)
+	}
+}
+
+// rootSchema returns the schema defining the root field of the operation of
+// info the resolved field is nested in.
+func (m *schemaMerger) rootSchema(info ResolveInfo) (int, bool) {
+	path := info.Path
+	if path == nil {
+		return 0, false
+	}
+	for path.Prev != nil {
+		path = path.Prev
+	}
+	responseName, ok := path.Key.(string)
+	operation, isOperation := info.Operation.(*ast.OperationDefinition)
+	if !ok || !isOperation {
+		return 0, false
+	}
+	rootName := map[string]string{
+		ast.OperationTypeQuery:        "Query",
+		ast.OperationTypeMutation:     "Mutation",
+		ast.OperationTypeSubscription: "Subscription",
+	}[operation.Operation]
+	fieldName, ok := rootFieldName(operation.SelectionSet, info.Fragments, responseName, map[string]bool{})
+	if !ok {
+		return 0, false
+	}
+	schema, ok := m.roots[rootName][fieldName]
+	return schema, ok
+}
+
+// rootFieldName returns the name of the field of selectionSet with the given
+// response name, looking into its fragments.
+func rootFieldName(selectionSet *ast.SelectionSet, fragments map[string]ast.Definition, responseName string, visited map[string]bool) (string, bool) {
+	if selectionSet == nil {
+		return "", false
+	}
+	for _, selection := range selectionSet.Selections {
+		var nested *ast.SelectionSet
+		switch selection := selection.(type) {
+		case *ast.Field:
+			name := fieldASTName(selection)
+			if selection.Alias != nil && selection.Alias.Value == responseName || selection.Alias == nil && name == responseName {
+				return name, true
+			}
+		case *ast.InlineFragment:
+			nested = selection.SelectionSet
+		case *ast.FragmentSpread:
+			if selection.Name == nil || visited[selection.Name.Value] {
+				continue
+			}
+			visited[selection.Name.Value] = true
+			if fragment, ok := fragments[selection.Name.Value].(*ast.FragmentDefinition); ok {
+				nested = fragment.SelectionSet
+			}
+		}
+		if name, ok := rootFieldName(nested, fragments, responseName, visited); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (m *schemaMerger) fields(schema int, fieldMap FieldDefinitionMap) Fields {
+	fields := Fields{}
+	for name, def := range fieldMap {
+		fields[name] = m.field(schema, def)
+	}
+	return fields
+}
+
+func (m *schemaMerger) field(schema int, def *FieldDefinition) *Field {
+	field := def.ToFieldConfig()
+	field.Type = m.typeRef(schema, def.Type).(Output)
+	for _, arg := range field.Args {
+		arg.Type = m.typeRef(schema, arg.Type).(Input)
+	}
+	return field
+}
+
+// typeRef returns the merged type standing for ttype of the given schema.
+func (m *schemaMerger) typeRef(schema int, ttype Type) Type {
+	switch ttype := ttype.(type) {
+	case *List:
+		return NewList(m.typeRef(schema, ttype.OfType))
+	case *NonNull:
+		return NewNonNull(m.typeRef(schema, ttype.OfType))
+	}
+	if strings.HasPrefix(ttype.Name(), "__") {
+		return ttype
+	}
+	if merged, ok := m.types[m.lookup(schema, ttype.Name())]; ok {
+		return merged
+	}
+	return ttype
+}
+
+func (m *schemaMerger) mergeRoot(name string, root func(*Schema) *Object) (*Object, error) {
+	fields := Fields{}
+	var description string
+	found := false
+	for i := range m.schemas {
+		object := root(&m.schemas[i])
+		if object == nil {
+			continue
+		}
+		if !found {
+			description = object.Description()
+			found = true
+		}
+		fieldMap := object.Fields()
+		fieldNames := []string{}
+		for fieldName := range fieldMap {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			target := fieldName
+			if _, ok := fields[target]; ok {
+				target = m.opts.rename(MergeConflict{Schema: i, TypeName: name, FieldName: fieldName})
+				if target == "" {
+					return nil, fmt.Errorf(`Field "%v.%v" is defined by more than one schema.`, name, fieldName)
+				}
+				if _, ok := fields[target]; ok {
+					return nil, fmt.Errorf(`Cannot rename field "%v.%v" of schema %v to "%v", the name is already taken.`, name, fieldName, i, target)
+				}
+			}
+			field := m.field(i, fieldMap[fieldName])
+			field.Name = target
+			fields[target] = field
+			if m.roots[name] == nil {
+				m.roots[name] = map[string]int{}
+			}
+			m.roots[name][target] = i
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+	object := NewObject(ObjectConfig{
+		Name:        name,
+		Description: description,
+		Fields:      fields,
+	})
+	m.types[name] = object
+	return object, nil
+}
+
+func (m *schemaMerger) mergeDirectives() ([]*Directive, error) {
+	directives := append([]*Directive{}, SpecifiedDirectives...)
+	byName := map[string]*Directive{}
+	for _, schema := range m.schemas {
+		for _, directive := range schema.Directives() {
+			if isSpecifiedDirective(directive) {
+				continue
+			}
+			existing, ok := byName[directive.Name]
+			if !ok {
+				byName[directive.Name] = directive
+				directives = append(directives, directive)
+				continue
+			}
+			if existing == directive {
+				continue
+			}
+			a, _ := printer.Print(directiveDefinitionAST(existing)).(string)
+			b, _ := printer.Print(directiveDefinitionAST(directive)).(string)
+			if a != b {
+				return nil, fmt.Errorf(`Directive "@%v" is defined differently by more than one schema.`, directive.Name)
+			}
+		}
+	}
+	return directives, nil
+}
@@ -0,0 +1,341 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+var mergeMoneyType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Money",
+	Fields: graphql.Fields{
+		"amount": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+func mergeUsersSchema(t *testing.T) graphql.Schema {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":      &graphql.Field{Type: graphql.ID},
+			"name":    &graphql.Field{Type: graphql.String},
+			"balance": &graphql.Field{Type: mergeMoneyType},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "UsersQuery",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{
+							"id":      "1",
+							"name":    "Ada",
+							"balance": map[string]interface{}{"amount": 10},
+						}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func mergeProductsSchema(t *testing.T) graphql.Schema {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.ID},
+		},
+	})
+	productType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.Fields{
+			"name":   &graphql.Field{Type: graphql.String},
+			"price":  &graphql.Field{Type: mergeMoneyType},
+			"seller": &graphql.Field{Type: userType},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"product": &graphql.Field{
+					Type: productType,
+					Args: graphql.FieldConfigArgument{
+						"name": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{
+							"name":   p.Args["name"],
+							"price":  map[string]interface{}{"amount": 5},
+							"seller": map[string]interface{}{"id": "2"},
+						}, nil
+					},
+				},
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"id": "3"}, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestMergeSchemas_ReportsConflicts(t *testing.T) {
+	_, err := graphql.MergeSchemas(graphql.MergeOptions{}, mergeUsersSchema(t), mergeProductsSchema(t))
+	expected := `Type "User" is defined differently by schemas 0 and 1.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
+
+func TestMergeSchemas_ReportsDuplicateRootFields(t *testing.T) {
+	_, err := graphql.MergeSchemas(graphql.MergeOptions{
+		Rename: func(conflict graphql.MergeConflict) string {
+			if conflict.FieldName == "" {
+				return "Seller"
+			}
+			return ""
+		},
+	}, mergeUsersSchema(t), mergeProductsSchema(t))
+	expected := `Field "Query.user" is defined by more than one schema.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("expected error %q, got %v", expected, err)
+	}
+}
+
+func TestMergeSchemas_ResolvesConflictsWithPrefixes(t *testing.T) {
+	schema, err := graphql.MergeSchemas(graphql.MergeOptions{
+		Prefixes: []string{"", "Products"},
+	}, mergeUsersSchema(t), mergeProductsSchema(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedSDL := `type Money {
+  amount: Int
+}
+
+type Product {
+  name: String
+  price: Money
+  seller: ProductsUser
+}
+
+type ProductsUser {
+  id: ID
+}
+
+type Query {
+  Productsuser: ProductsUser
+  product(name: String): Product
+  user: User
+}
+
+type User {
+  balance: Money
+  id: ID
+  name: String
+}
+`
+	if sdl := graphql.PrintSchema(schema); sdl != expectedSDL {
+		t.Fatalf("unexpected schema, got:\n%v\nwant:\n%v", sdl, expectedSDL)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			user { name balance { amount } }
+			Productsuser { __typename id }
+			product(name: "Lamp") { name price { amount } seller { __typename id } }
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{
+				"name":    "Ada",
+				"balance": map[string]interface{}{"amount": 10},
+			},
+			"Productsuser": map[string]interface{}{
+				"__typename": "ProductsUser",
+				"id":         "3",
+			},
+			"product": map[string]interface{}{
+				"name":   "Lamp",
+				"price":  map[string]interface{}{"amount": 5},
+				"seller": map[string]interface{}{"__typename": "ProductsUser", "id": "2"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMergeSchemas_MapsResolvedTypesOfRenamedTypes(t *testing.T) {
+	newSchema := func(resultName string) graphql.Schema {
+		var resultType *graphql.Object
+		searchResult := graphql.NewUnion(graphql.UnionConfig{
+			Name: "SearchResult",
+			Types: graphql.UnionTypesThunk(func() []*graphql.Object {
+				return []*graphql.Object{resultType}
+			}),
+			ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+				return resultType
+			},
+		})
+		resultType = graphql.NewObject(graphql.ObjectConfig{
+			Name: "Result",
+			Fields: graphql.Fields{
+				resultName: &graphql.Field{Type: graphql.String},
+			},
+		})
+		schema, err := graphql.NewSchema(graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					resultName + "Search": &graphql.Field{
+						Type: searchResult,
+						Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+							return map[string]interface{}{resultName: "found"}, nil
+						},
+					},
+				},
+			}),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return schema
+	}
+
+	schema, err := graphql.MergeSchemas(graphql.MergeOptions{
+		Rename: func(conflict graphql.MergeConflict) string {
+			return "Book" + conflict.TypeName
+		},
+	}, newSchema("title"), newSchema("isbn"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			titleSearch { __typename ... on Result { title } }
+			isbnSearch { __typename ... on BookResult { isbn } }
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"titleSearch": map[string]interface{}{"__typename": "Result", "title": "found"},
+			"isbnSearch":  map[string]interface{}{"__typename": "BookResult", "isbn": "found"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMergeSchemas_KeepsExtensions(t *testing.T) {
+	users := mergeUsersSchema(t)
+	users.AddExtensions(newtestExt("users"))
+	products := mergeProductsSchema(t)
+	products.AddExtensions(newtestExt("products"), newtestExt("users"))
+
+	schema, err := graphql.MergeSchemas(graphql.MergeOptions{
+		Prefixes: []string{"", "Products"},
+	}, users, products)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := []string{}
+	for _, extension := range schema.Extensions() {
+		names = append(names, extension.Name())
+	}
+	if !reflect.DeepEqual(names, []string{"users", "products"}) {
+		t.Fatalf("unexpected extensions: %v", names)
+	}
+}
+
+func mergeMoneySchema(t *testing.T, rootField, amount string, visible func(fieldName string) bool) graphql.Schema {
+	moneyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Money",
+		Fields: graphql.Fields{
+			"amount": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return amount, nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				rootField: &graphql.Field{
+					Type: moneyType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return struct{}{}, nil
+					},
+				},
+			},
+		}),
+		Visibility: func(ctx context.Context, parentType graphql.Type, fieldDef *graphql.FieldDefinition) bool {
+			return visible(fieldDef.Name)
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestMergeSchemas_KeepsTheResolversOfEachSchema(t *testing.T) {
+	all := func(string) bool { return true }
+	schema, err := graphql.MergeSchemas(graphql.MergeOptions{},
+		mergeMoneySchema(t, "a", "from-a", all),
+		mergeMoneySchema(t, "b", "from-b", all),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ a { amount } x: b { amount } ...F } fragment F on Query { ... on Query { y: b { amount } } }`,
+	})
+	expected := &graphql.Result{Data: map[string]interface{}{
+		"a": map[string]interface{}{"amount": "from-a"},
+		"x": map[string]interface{}{"amount": "from-b"},
+		"y": map[string]interface{}{"amount": "from-b"},
+	}}
+	if !testutil.EqualResults(expected, result) {
+		t.Fatalf("unexpected result: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestMergeSchemas_KeepsVisibility(t *testing.T) {
+	schema, err := graphql.MergeSchemas(graphql.MergeOptions{},
+		mergeMoneySchema(t, "a", "from-a", func(name string) bool { return name != "b" }),
+		mergeMoneySchema(t, "b", "from-b", func(name string) bool { return true }),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ b { amount } }`})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Cannot query field "b" on type "Query". Did you mean "a"?` {
+		t.Fatalf("expected b to be hidden, got %v", result)
+	}
+}
@@ -0,0 +1,296 @@
+// Package mock fills a schema with resolvers returning fake values, for
+// developing and testing clients before the actual resolvers exist.
+//
+// AddMocksToSchema gives a resolver to the fields of a schema which have
+// none. The values of the fields are read from their parent values when
+// these provide them, as graphql.DefaultResolveFn does, and mocked
+// otherwise: scalars get plausible random values, enums one of their values,
+// lists a configurable number of mocked items, and interfaces and unions one
+// of their possible types. The mocked values can be overridden per type or
+// per field, and are deterministic for a given seed.
+package mock
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// DefaultListLength is the length of mocked lists if Mocks.ListLength is not
+// positive.
+const DefaultListLength = 2
+
+// typenameKey is the key of mocked object values naming their type, which
+// selects the concrete type of the values of interfaces and unions.
+const typenameKey = "__typename"
+
+// MockFn returns the mocked value of the field resolved by p, or of the
+// items of its list.
+type MockFn func(p graphql.ResolveParams) interface{}
+
+// Mocks configures AddMocksToSchema.
+type Mocks struct {
+	// Types maps type names to the functions mocking their values. The
+	// values of objects, interfaces and unions are the parent values of
+	// their fields: maps of the field values, the fields they leave out
+	// being mocked in turn. The "__typename" key of the values of
+	// interfaces and unions names their concrete type, one of their
+	// possible types being picked if it is left out.
+	Types map[string]MockFn
+
+	// Fields maps "Type.field" names to the functions mocking the values of
+	// fields, which take precedence over Types.
+	Fields map[string]MockFn
+
+	// ListLength is the length of mocked lists, DefaultListLength if not
+	// positive.
+	ListLength int
+
+	// Seed seeds the random values: the fields of schemas mocked with the
+	// same seed get the same values at the same paths of the results.
+	Seed int64
+}
+
+// AddMocksToSchema sets a resolver mocking the values of the fields which
+// have none to the object types of schema. The resolvers of the other fields
+// are preserved; the mocked values of the objects they return are passed to
+// them as is, so they must cope with map[string]interface{} parent values.
+//
+// The types are modified in place, like the other types sharing them. An
+// error is returned if mocks refers to unknown types or fields, or to fields
+// with a resolver.
+func AddMocksToSchema(schema *graphql.Schema, mocks Mocks) error {
+	if schema == nil {
+		return fmt.Errorf("mock: schema is nil")
+	}
+	for name := range mocks.Types {
+		if schema.Type(name) == nil {
+			return fmt.Errorf("mock: unknown type %q", name)
+		}
+	}
+	for name := range mocks.Fields {
+		parts := strings.SplitN(name, ".", 2)
+		object, ok := schema.Type(parts[0]).(*graphql.Object)
+		if !ok || len(parts) != 2 || object.Fields()[parts[1]] == nil {
+			return fmt.Errorf("mock: unknown field %q", name)
+		}
+		if object.Fields()[parts[1]].Resolve != nil {
+			return fmt.Errorf("mock: field %q has a resolver", name)
+		}
+	}
+
+	m := &mocker{mocks: mocks, schema: schema}
+	for name, ttype := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		switch ttype := ttype.(type) {
+		case *graphql.Object:
+			for _, field := range ttype.Fields() {
+				if field.Resolve == nil {
+					field.Resolve = m.resolveField(ttype, field)
+				}
+			}
+			if ttype.IsTypeOf != nil {
+				ttype.IsTypeOf = isTypeOf(ttype, ttype.IsTypeOf)
+			}
+		case *graphql.Interface:
+			ttype.ResolveType = m.resolveType(ttype, ttype.ResolveType)
+		case *graphql.Union:
+			ttype.ResolveType = m.resolveType(ttype, ttype.ResolveType)
+		}
+	}
+	return nil
+}
+
+type mocker struct {
+	mocks  Mocks
+	schema *graphql.Schema
+}
+
+func (m *mocker) resolveField(object *graphql.Object, field *graphql.FieldDefinition) graphql.FieldResolveFn {
+	mockField := m.mocks.Fields[object.Name()+"."+field.Name]
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if p.Source != nil {
+			if value, err := graphql.DefaultResolveFn(p); err != nil || value != nil {
+				return value, err
+			}
+		}
+		if mockField != nil {
+			return mockField(p), nil
+		}
+		return m.value(m.rand(p.Info.Path), p, field.Type), nil
+	}
+}
+
+// rand returns the random source of the field at path, which only depends
+// on the seed and on path.
+func (m *mocker) rand(path *graphql.ResponsePath) *rand.Rand {
+	hash := fnv.New64a()
+	fmt.Fprint(hash, m.mocks.Seed, path.AsArray())
+	return rand.New(rand.NewSource(int64(hash.Sum64())))
+}
+
+func (m *mocker) value(rng *rand.Rand, p graphql.ResolveParams, ttype graphql.Type) interface{} {
+	switch ttype := ttype.(type) {
+	case *graphql.NonNull:
+		return m.value(rng, p, ttype.OfType)
+	case *graphql.List:
+		length := m.mocks.ListLength
+		if length <= 0 {
+			length = DefaultListLength
+		}
+		values := make([]interface{}, length)
+		for i := range values {
+			values[i] = m.value(rng, p, ttype.OfType)
+		}
+		return values
+	}
+
+	abstract, isAbstract := ttype.(graphql.Abstract)
+	if mockType, ok := m.mocks.Types[ttype.Name()]; ok {
+		value := mockType(p)
+		if fields, ok := value.(map[string]interface{}); ok && isAbstract && fields[typenameKey] == nil {
+			value = withTypename(fields, m.possibleType(rng, abstract))
+		}
+		return value
+	}
+
+	switch ttype := ttype.(type) {
+	case *graphql.Scalar:
+		return scalarValue(rng, ttype.Name())
+	case *graphql.Enum:
+		values := append([]*graphql.EnumValueDefinition{}, ttype.Values()...)
+		if len(values) == 0 {
+			return nil
+		}
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].Name < values[j].Name
+		})
+		return values[rng.Intn(len(values))].Value
+	case *graphql.Object:
+		return map[string]interface{}{typenameKey: ttype.Name()}
+	}
+	if isAbstract {
+		return map[string]interface{}{typenameKey: m.possibleType(rng, abstract)}
+	}
+	return nil
+}
+
+// possibleType returns the name of one of the possible types of abstract.
+func (m *mocker) possibleType(rng *rand.Rand, abstract graphql.Abstract) string {
+	names := []string{}
+	for _, object := range m.schema.PossibleTypes(abstract) {
+		names = append(names, object.Name())
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[rng.Intn(len(names))]
+}
+
+func withTypename(fields map[string]interface{}, typename string) map[string]interface{} {
+	copied := map[string]interface{}{typenameKey: typename}
+	for name, value := range fields {
+		if name != typenameKey {
+			copied[name] = value
+		}
+	}
+	return copied
+}
+
+// mockTypename returns the type named by a mocked object value.
+func mockTypename(value interface{}) (string, bool) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	typename, ok := fields[typenameKey].(string)
+	return typename, ok
+}
+
+// resolveType returns the ResolveType function of abstract, resolving the
+// mocked values to the type they name, and the other values with resolve or,
+// if it is nil, with the IsTypeOf functions of the possible types.
+func (m *mocker) resolveType(abstract graphql.Abstract, resolve graphql.ResolveTypeFn) graphql.ResolveTypeFn {
+	return func(p graphql.ResolveTypeParams) *graphql.Object {
+		if typename, ok := mockTypename(p.Value); ok {
+			if object, ok := m.schema.Type(typename).(*graphql.Object); ok {
+				return object
+			}
+		}
+		if resolve != nil {
+			return resolve(p)
+		}
+		for _, object := range m.schema.PossibleTypes(abstract) {
+			if object.IsTypeOf != nil && object.IsTypeOf(graphql.IsTypeOfParams{
+				Value:   p.Value,
+				Info:    p.Info,
+				Context: p.Context,
+			}) {
+				return object
+			}
+		}
+		return nil
+	}
+}
+
+// isTypeOf returns the IsTypeOf function of object, accepting the mocked
+// values of object.
+func isTypeOf(object *graphql.Object, isTypeOf graphql.IsTypeOfFn) graphql.IsTypeOfFn {
+	return func(p graphql.IsTypeOfParams) bool {
+		if typename, ok := mockTypename(p.Value); ok {
+			return typename == object.Name()
+		}
+		return isTypeOf(p)
+	}
+}
+
+var words = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing",
+	"elit", "sed", "do", "eiusmod", "tempor", "incididunt", "labore",
+	"dolore", "magna", "aliqua", "enim", "minim", "veniam", "quis",
+	"nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip",
+	"commodo", "consequat",
+}
+
+func word(rng *rand.Rand) string {
+	return words[rng.Intn(len(words))]
+}
+
+// scalarValue returns a random value of the scalar named name, valid for
+// the built-in scalars and the scalars of the scalars package. The values of
+// the other scalars are strings.
+func scalarValue(rng *rand.Rand, name string) interface{} {
+	switch name {
+	case "Int", "Int64", "BigInt":
+		return rng.Intn(1000)
+	case "Float":
+		return float64(rng.Intn(100000)) / 100
+	case "Decimal":
+		return fmt.Sprintf("%d.%02d", rng.Intn(1000), rng.Intn(100))
+	case "Boolean":
+		return rng.Intn(2) == 1
+	case "ID", "UUID":
+		return fmt.Sprintf("%08x-%04x-4%03x-%04x-%012x",
+			rng.Uint32(), rng.Intn(0x10000), rng.Intn(0x1000), 0x8000|rng.Intn(0x4000), rng.Int63n(1<<48))
+	case "DateTime", "Date", "Time":
+		start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start.Add(time.Duration(rng.Int63n(int64(5 * 365 * 24 * time.Hour)))).Truncate(time.Second)
+	case "Duration":
+		return time.Duration(rng.Intn(24*60)) * time.Minute
+	case "URL":
+		return "https://example.com/" + word(rng)
+	case "Email":
+		return word(rng) + "@example.com"
+	case "JSON":
+		return map[string]interface{}{word(rng): word(rng)}
+	}
+	return word(rng) + " " + word(rng)
+}
@@ -0,0 +1,261 @@
+package mock_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/mock"
+	"github.com/graphql-go/graphql/scalars"
+)
+
+const testSDL = `
+	enum Role { ADMIN MEMBER GUEST }
+
+	interface Node { id: ID! }
+
+	type User implements Node {
+		id: ID!
+		name: String!
+		age: Int
+		score: Float
+		active: Boolean
+		role: Role
+		friends: [User!]!
+	}
+
+	type Post implements Node {
+		id: ID!
+		title: String
+		author: User
+	}
+
+	union SearchResult = User | Post
+
+	type Query {
+		me: User
+		users: [User]
+		node(id: ID!): Node
+		search(text: String!): [SearchResult!]!
+	}
+`
+
+func testSchema(t *testing.T) *graphql.Schema {
+	doc, err := parser.Parse(parser.ParseParams{Source: testSDL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema, err := graphql.BuildASTSchema(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &schema
+}
+
+func mockedSchema(t *testing.T, mocks mock.Mocks) *graphql.Schema {
+	schema := testSchema(t)
+	if err := mock.AddMocksToSchema(schema, mocks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+// do executes query against schema, returning the result data as JSON.
+func do(t *testing.T, schema *graphql.Schema, query string) string {
+	result := graphql.Do(graphql.Params{Schema: *schema, RequestString: query})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	b, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(b)
+}
+
+func TestAddMocksToSchema_MocksValuesOfEveryType(t *testing.T) {
+	schema := mockedSchema(t, mock.Mocks{ListLength: 3})
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ users { id name age score active role friends { name } } }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	users := result.Data.(map[string]interface{})["users"].([]interface{})
+	if len(users) != 3 {
+		t.Fatalf("expected 3 users, got %v", users)
+	}
+	for _, user := range users {
+		user := user.(map[string]interface{})
+		if id, ok := user["id"].(string); !ok || len(id) != 36 {
+			t.Fatalf("unexpected id: %v", user["id"])
+		}
+		if name, ok := user["name"].(string); !ok || name == "" {
+			t.Fatalf("unexpected name: %v", user["name"])
+		}
+		if _, ok := user["age"].(int); !ok {
+			t.Fatalf("unexpected age: %v", user["age"])
+		}
+		if _, ok := user["score"].(float64); !ok {
+			t.Fatalf("unexpected score: %v", user["score"])
+		}
+		if _, ok := user["active"].(bool); !ok {
+			t.Fatalf("unexpected active: %v", user["active"])
+		}
+		if role := user["role"]; role != "ADMIN" && role != "MEMBER" && role != "GUEST" {
+			t.Fatalf("unexpected role: %v", role)
+		}
+		if friends := user["friends"].([]interface{}); len(friends) != 3 {
+			t.Fatalf("expected 3 friends, got %v", friends)
+		}
+	}
+}
+
+func TestAddMocksToSchema_MocksValidValuesOfEveryScalar(t *testing.T) {
+	fields := graphql.Fields{}
+	for _, scalar := range []*graphql.Scalar{
+		graphql.Int, graphql.Float, graphql.String, graphql.Boolean, graphql.ID, graphql.DateTime,
+		scalars.JSON, scalars.Int64, scalars.BigInt, scalars.Decimal, scalars.UUID,
+		scalars.Date, scalars.Time, scalars.Duration, scalars.URL, scalars.Email,
+	} {
+		fields[scalar.Name()] = &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(scalar)))}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.AddMocksToSchema(&schema, mock.Mocks{ListLength: 50}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ Int Float String Boolean ID DateTime JSON Int64 BigInt Decimal UUID Date Time Duration URL Email }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	for name, values := range result.Data.(map[string]interface{}) {
+		if len(values.([]interface{})) != 50 {
+			t.Fatalf("expected 50 values of %v, got %v", name, values)
+		}
+	}
+}
+
+func TestAddMocksToSchema_IsDeterministicForASeed(t *testing.T) {
+	query := `{ me { id name age friends { name role } } search(text: "a") { __typename } }`
+	first := do(t, mockedSchema(t, mock.Mocks{Seed: 42}), query)
+	if second := do(t, mockedSchema(t, mock.Mocks{Seed: 42}), query); second != first {
+		t.Fatalf("expected the same results, got %v and %v", first, second)
+	}
+	if other := do(t, mockedSchema(t, mock.Mocks{Seed: 7}), query); other == first {
+		t.Fatalf("expected different results for different seeds, got %v", other)
+	}
+}
+
+func TestAddMocksToSchema_OverridesTypesAndFields(t *testing.T) {
+	schema := mockedSchema(t, mock.Mocks{
+		Types: map[string]mock.MockFn{
+			"String": func(p graphql.ResolveParams) interface{} { return "text" },
+			"User": func(p graphql.ResolveParams) interface{} {
+				return map[string]interface{}{"age": 42, "friends": []interface{}{}}
+			},
+			"Node": func(p graphql.ResolveParams) interface{} {
+				return map[string]interface{}{"__typename": "Post"}
+			},
+		},
+		Fields: map[string]mock.MockFn{
+			"User.name": func(p graphql.ResolveParams) interface{} { return "Luke" },
+		},
+	})
+	data := do(t, schema, `{ me { name age friends { name } } node(id: "1") { ... on Post { title author { name } } } }`)
+	expected := `{"me":{"age":42,"friends":[],"name":"Luke"},"node":{"author":{"name":"Luke"},"title":"text"}}`
+	if data != expected {
+		t.Fatalf("expected %v, got %v", expected, data)
+	}
+}
+
+func TestAddMocksToSchema_PicksConcreteTypesOfInterfacesAndUnions(t *testing.T) {
+	schema := mockedSchema(t, mock.Mocks{ListLength: 20})
+	result := graphql.Do(graphql.Params{
+		Schema:        *schema,
+		RequestString: `{ search(text: "a") { __typename ... on User { name } ... on Post { title } } }`,
+	})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	typenames := map[string]bool{}
+	for _, item := range result.Data.(map[string]interface{})["search"].([]interface{}) {
+		item := item.(map[string]interface{})
+		typename := item["__typename"].(string)
+		typenames[typename] = true
+		if _, ok := map[string]string{"User": "name", "Post": "title"}[typename]; !ok {
+			t.Fatalf("unexpected item: %v", item)
+		}
+		if len(item) != 2 {
+			t.Fatalf("expected the fields of %v only, got %v", typename, item)
+		}
+	}
+	if !typenames["User"] || !typenames["Post"] {
+		t.Fatalf("expected users and posts, got %v", typenames)
+	}
+
+	schema = mockedSchema(t, mock.Mocks{
+		Types: map[string]mock.MockFn{
+			"Node": func(p graphql.ResolveParams) interface{} {
+				return map[string]interface{}{"__typename": "Post", "title": "Hello"}
+			},
+		},
+	})
+	if data, expected := do(t, schema, `{ node(id: "1") { __typename ... on Post { title } } }`), `{"node":{"__typename":"Post","title":"Hello"}}`; data != expected {
+		t.Fatalf("expected %v, got %v", expected, data)
+	}
+}
+
+type user struct {
+	Name string `json:"name"`
+}
+
+func TestAddMocksToSchema_PreservesResolvers(t *testing.T) {
+	schema := testSchema(t)
+	schema.QueryType().Fields()["me"].Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		return &user{Name: "Leia"}, nil
+	}
+	if err := mock.AddMocksToSchema(schema, mock.Mocks{
+		Types: map[string]mock.MockFn{
+			"Int": func(p graphql.ResolveParams) interface{} { return 7 },
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, expected := do(t, schema, `{ me { name age } }`), `{"me":{"age":7,"name":"Leia"}}`; data != expected {
+		t.Fatalf("expected %v, got %v", expected, data)
+	}
+}
+
+func TestAddMocksToSchema_RejectsUnknownTypesAndFields(t *testing.T) {
+	schema := testSchema(t)
+	schema.QueryType().Fields()["me"].Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		return nil, nil
+	}
+	tests := map[string]mock.Mocks{
+		`mock: unknown type "Comment"`:          {Types: map[string]mock.MockFn{"Comment": nil}},
+		`mock: unknown field "User.bio"`:        {Fields: map[string]mock.MockFn{"User.bio": nil}},
+		`mock: unknown field "User"`:            {Fields: map[string]mock.MockFn{"User": nil}},
+		`mock: field "Query.me" has a resolver`: {Fields: map[string]mock.MockFn{"Query.me": nil}},
+	}
+	for message, mocks := range tests {
+		if err := mock.AddMocksToSchema(schema, mocks); err == nil || err.Error() != message {
+			t.Fatalf("expected %q, got %v", message, err)
+		}
+	}
+	if err := mock.AddMocksToSchema(nil, mock.Mocks{}); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if schema.QueryType().Fields()["users"].Resolve != nil {
+		t.Fatalf("expected the schema to be left untouched")
+	}
+}
@@ -0,0 +1,316 @@
+package graphql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/astutil"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// NormalizeOptions configures NormalizeWithOptions.
+type NormalizeOptions struct {
+	// RemoveAliases drops the aliases of fields, so that operations
+	// differing only by their aliases have the same signature.
+	RemoveAliases bool
+
+	// KeepLiterals keeps the literal values, so that operations differing by
+	// their arguments have different signatures, as cache keys need. The
+	// fields of the input objects are sorted by name.
+	KeepLiterals bool
+
+	// KeepSelectionOrder keeps the order of the selections, so that
+	// operations differing by the order of their fields have different
+	// signatures, as the cache keys of results with ordered fields need.
+	KeepSelectionOrder bool
+}
+
+// Normalize returns the signature of an operation of doc, for grouping the
+// metrics or caching the results of equivalent operations: operations
+// differing only by formatting, comments, literal values or the order of
+// their fields and arguments have the same signature.
+//
+// The operation named operationName is kept, or the only operation of doc if
+// operationName is empty, along with the fragments it uses, sorted by name.
+// Integers and floats are replaced by 0, strings by "", lists by [] and
+// input objects by {}, while enum values, booleans, nulls and variables are
+// kept. Selections, arguments, directives and variable definitions are
+// sorted, the selections of the same field or fragment by their printed
+// form, then the document is printed with language/printer and stripped of
+// its ignored characters with astutil.StripIgnoredCharacters.
+func Normalize(doc *ast.Document, operationName string) (string, error) {
+	return NormalizeWithOptions(doc, operationName, NormalizeOptions{})
+}
+
+// NormalizeWithOptions is like Normalize, configured by opts.
+func NormalizeWithOptions(doc *ast.Document, operationName string, opts NormalizeOptions) (string, error) {
+	if doc == nil {
+		return "", errors.New("Must provide document.")
+	}
+	var operation *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" && operation != nil {
+				return "", errors.New("Must provide operation name if query contains multiple operations.")
+			}
+			if operationName == "" || def.Name != nil && def.Name.Value == operationName {
+				operation = def
+			}
+		case *ast.FragmentDefinition:
+			if def.Name != nil {
+				fragments[def.Name.Value] = def
+			}
+		}
+	}
+	if operation == nil {
+		if operationName != "" {
+			return "", fmt.Errorf(`Unknown operation named "%v".`, operationName)
+		}
+		return "", errors.New("Must provide an operation.")
+	}
+
+	n := &normalizer{
+		opts:      opts,
+		fragments: fragments,
+		used:      map[string]bool{},
+	}
+	definitions := []ast.Node{ast.NewOperationDefinition(&ast.OperationDefinition{
+		Operation:           operation.Operation,
+		Name:                operation.Name,
+		VariableDefinitions: n.variableDefinitions(operation.VariableDefinitions),
+		Directives:          n.directives(operation.Directives),
+		SelectionSet:        n.selectionSet(operation.SelectionSet),
+	})}
+	// the fragments used by fragments are found while normalizing them
+	normalized := map[string]*ast.FragmentDefinition{}
+	for len(normalized) < len(n.used) {
+		for name := range n.used {
+			if _, ok := normalized[name]; ok {
+				continue
+			}
+			fragment := fragments[name]
+			normalized[name] = ast.NewFragmentDefinition(&ast.FragmentDefinition{
+				Name:                fragment.Name,
+				VariableDefinitions: n.variableDefinitions(fragment.VariableDefinitions),
+				TypeCondition:       fragment.TypeCondition,
+				Directives:          n.directives(fragment.Directives),
+				SelectionSet:        n.selectionSet(fragment.SelectionSet),
+			})
+		}
+	}
+	names := []string{}
+	for name := range normalized {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		definitions = append(definitions, normalized[name])
+	}
+
+	printed, _ := printer.Print(ast.NewDocument(&ast.Document{Definitions: definitions})).(string)
+	return astutil.StripIgnoredCharacters(printed)
+}
+
+// SignatureHash returns the hexadecimal SHA-256 hash of an operation
+// signature returned by Normalize, as a compact caching or metrics key.
+func SignatureHash(signature string) string {
+	sum := sha256.Sum256([]byte(signature))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizer copies the nodes of an operation, normalized, recording the
+// fragments it uses.
+type normalizer struct {
+	opts      NormalizeOptions
+	fragments map[string]*ast.FragmentDefinition
+	used      map[string]bool
+}
+
+func (n *normalizer) selectionSet(selectionSet *ast.SelectionSet) *ast.SelectionSet {
+	if selectionSet == nil {
+		return nil
+	}
+	selections := []ast.Selection{}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			field := ast.NewField(&ast.Field{
+				Alias:        selection.Alias,
+				Name:         selection.Name,
+				Arguments:    n.arguments(selection.Arguments),
+				Directives:   n.directives(selection.Directives),
+				SelectionSet: n.selectionSet(selection.SelectionSet),
+			})
+			if n.opts.RemoveAliases {
+				field.Alias = nil
+			}
+			selections = append(selections, field)
+		case *ast.FragmentSpread:
+			if selection.Name != nil && n.fragments[selection.Name.Value] != nil {
+				n.used[selection.Name.Value] = true
+			}
+			selections = append(selections, ast.NewFragmentSpread(&ast.FragmentSpread{
+				Name:       selection.Name,
+				Directives: n.directives(selection.Directives),
+			}))
+		case *ast.InlineFragment:
+			selections = append(selections, ast.NewInlineFragment(&ast.InlineFragment{
+				TypeCondition: selection.TypeCondition,
+				Directives:    n.directives(selection.Directives),
+				SelectionSet:  n.selectionSet(selection.SelectionSet),
+			}))
+		}
+	}
+	if n.opts.KeepSelectionOrder {
+		return ast.NewSelectionSet(&ast.SelectionSet{Selections: selections})
+	}
+	// selections of equal keys, e.g. fields differing only by their
+	// arguments, are ordered by their printed form
+	keys := make([]string, len(selections))
+	for i, selection := range selections {
+		keys[i] = selectionSortKey(selection)
+		if node, ok := selection.(ast.Node); ok {
+			printed, _ := printer.Print(node).(string)
+			keys[i] += "\x00" + printed
+		}
+	}
+	sort.Sort(selectionsByKey{selections, keys})
+	return ast.NewSelectionSet(&ast.SelectionSet{Selections: selections})
+}
+
+// selectionsByKey sorts selections by their keys.
+type selectionsByKey struct {
+	selections []ast.Selection
+	keys       []string
+}
+
+func (s selectionsByKey) Len() int           { return len(s.selections) }
+func (s selectionsByKey) Less(i, j int) bool { return s.keys[i] < s.keys[j] }
+func (s selectionsByKey) Swap(i, j int) {
+	s.selections[i], s.selections[j] = s.selections[j], s.selections[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// selectionSortKey orders fields by name and alias, then fragment spreads by
+// name, then inline fragments by type condition.
+func selectionSortKey(selection ast.Selection) string {
+	switch selection := selection.(type) {
+	case *ast.Field:
+		key := "0" + nameValue(selection.Name)
+		if selection.Alias != nil {
+			key += " " + selection.Alias.Value
+		}
+		return key
+	case *ast.FragmentSpread:
+		return "1" + nameValue(selection.Name)
+	case *ast.InlineFragment:
+		if selection.TypeCondition != nil {
+			return "2" + nameValue(selection.TypeCondition.Name)
+		}
+		return "2"
+	}
+	return "3"
+}
+
+func nameValue(name *ast.Name) string {
+	if name == nil {
+		return ""
+	}
+	return name.Value
+}
+
+func (n *normalizer) arguments(args []*ast.Argument) []*ast.Argument {
+	normalized := []*ast.Argument{}
+	for _, arg := range args {
+		normalized = append(normalized, ast.NewArgument(&ast.Argument{
+			Name:  arg.Name,
+			Value: n.value(arg.Value),
+		}))
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return nameValue(normalized[i].Name) < nameValue(normalized[j].Name)
+	})
+	return normalized
+}
+
+func (n *normalizer) directives(directives []*ast.Directive) []*ast.Directive {
+	normalized := []*ast.Directive{}
+	for _, directive := range directives {
+		normalized = append(normalized, ast.NewDirective(&ast.Directive{
+			Name:      directive.Name,
+			Arguments: n.arguments(directive.Arguments),
+		}))
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return nameValue(normalized[i].Name) < nameValue(normalized[j].Name)
+	})
+	return normalized
+}
+
+func (n *normalizer) variableDefinitions(defs []*ast.VariableDefinition) []*ast.VariableDefinition {
+	normalized := []*ast.VariableDefinition{}
+	for _, def := range defs {
+		normalized = append(normalized, ast.NewVariableDefinition(&ast.VariableDefinition{
+			Variable:     def.Variable,
+			Type:         def.Type,
+			DefaultValue: n.value(def.DefaultValue),
+		}))
+	}
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return nameValue(normalized[i].Variable.Name) < nameValue(normalized[j].Variable.Name)
+	})
+	return normalized
+}
+
+// value hides the literal values which may differ between equivalent
+// operations, or sorts the fields of the input objects if they are kept.
+func (n *normalizer) value(value ast.Value) ast.Value {
+	if n.opts.KeepLiterals {
+		return sortObjectFields(value)
+	}
+	switch value.(type) {
+	case *ast.IntValue:
+		return ast.NewIntValue(&ast.IntValue{Value: "0"})
+	case *ast.FloatValue:
+		return ast.NewFloatValue(&ast.FloatValue{Value: "0"})
+	case *ast.StringValue:
+		return ast.NewStringValue(&ast.StringValue{Value: ""})
+	case *ast.ListValue:
+		return ast.NewListValue(&ast.ListValue{Values: []ast.Value{}})
+	case *ast.ObjectValue:
+		return ast.NewObjectValue(&ast.ObjectValue{Fields: []*ast.ObjectField{}})
+	}
+	return value
+}
+
+// sortObjectFields copies value with the fields of its input objects sorted
+// by name.
+func sortObjectFields(value ast.Value) ast.Value {
+	switch value := value.(type) {
+	case *ast.ListValue:
+		values := []ast.Value{}
+		for _, item := range value.Values {
+			values = append(values, sortObjectFields(item))
+		}
+		return ast.NewListValue(&ast.ListValue{Values: values})
+	case *ast.ObjectValue:
+		fields := []*ast.ObjectField{}
+		for _, field := range value.Fields {
+			fields = append(fields, ast.NewObjectField(&ast.ObjectField{
+				Name:  field.Name,
+				Value: sortObjectFields(field.Value),
+			}))
+		}
+		sort.SliceStable(fields, func(i, j int) bool {
+			return nameValue(fields[i].Name) < nameValue(fields[j].Name)
+		})
+		return ast.NewObjectValue(&ast.ObjectValue{Fields: fields})
+	}
+	return value
+}
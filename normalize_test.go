@@ -0,0 +1,198 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestNormalize_HidesLiteralsAndSortsSelections(t *testing.T) {
+	doc := testutil.TestParse(t, `
+		query Heroes($episode: Episode = JEDI, $first: Int = 10) {
+			# heroes of the episode
+			hero(first: 3, episode: $episode) {
+				name
+				... on Droid @include(if: true) { primaryFunction }
+				...HeroFriends
+				id
+				appearsIn
+			}
+			search(text: "luke", filter: {name: "luke", limit: 2}, ids: [1, 2], ratio: 0.5, block: """text""") {
+				__typename
+			}
+		}
+
+		query Unused {
+			hero { ...Unused }
+		}
+
+		fragment HeroFriends on Character {
+			friends(orderBy: NAME) { ...Names }
+		}
+
+		fragment Names on Character {
+			name
+		}
+
+		fragment Unused on Character {
+			id
+		}
+	`)
+	signature, err := graphql.Normalize(doc, "Heroes")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `query Heroes($episode:Episode=JEDI$first:Int=0){hero(episode:$episode first:0){appearsIn id name...HeroFriends...on Droid@include(if:true){primaryFunction}}search(block:""filter:{}ids:[]ratio:0 text:""){__typename}}` +
+		`fragment HeroFriends on Character{friends(orderBy:NAME){...Names}}fragment Names on Character{name}`
+	if signature != expected {
+		t.Fatalf("unexpected signature:\n%v\nexpected:\n%v", signature, expected)
+	}
+	// the signature is a valid document
+	testutil.TestParse(t, signature)
+}
+
+func TestNormalize_IsStableAcrossFormattingAndOrder(t *testing.T) {
+	a := testutil.TestParse(t, `
+		query Q {
+			user(id: 1, name: "a") { id name }
+		}
+	`)
+	b := testutil.TestParse(t, `query Q{user(name:"b" id:2){
+		# comment
+		name,
+		id
+	}}`)
+	signatureA, err := graphql.Normalize(a, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signatureB, err := graphql.Normalize(b, "Q")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signatureA != signatureB || graphql.SignatureHash(signatureA) != graphql.SignatureHash(signatureB) {
+		t.Fatalf("expected equal signatures, got %v and %v", signatureA, signatureB)
+	}
+	if hash := graphql.SignatureHash(signatureA); len(hash) != 64 {
+		t.Fatalf("expected a hex SHA-256 hash, got %v", hash)
+	}
+}
+
+func TestNormalize_RemovesAliasesOptionally(t *testing.T) {
+	doc := testutil.TestParse(t, `{ b: user { id } a: user { id } }`)
+	signature, err := graphql.Normalize(doc, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{a:user{id}b:user{id}}`; signature != expected {
+		t.Fatalf("expected %v, got %v", expected, signature)
+	}
+	signature, err = graphql.NormalizeWithOptions(doc, "", graphql.NormalizeOptions{RemoveAliases: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{user{id}user{id}}`; signature != expected {
+		t.Fatalf("expected %v, got %v", expected, signature)
+	}
+}
+
+func TestNormalize_IsStableAcrossTheOrderOfEqualSelections(t *testing.T) {
+	tests := []struct {
+		a, b string
+		opts graphql.NormalizeOptions
+	}{
+		{
+			`{ x: hero(episode: EMPIRE) { name } y: hero(episode: JEDI) { id } }`,
+			`{ y: hero(episode: JEDI) { id } x: hero(episode: EMPIRE) { name } }`,
+			graphql.NormalizeOptions{RemoveAliases: true},
+		},
+		{
+			`{ ... @include(if: true) { a } ... @skip(if: true) { b } }`,
+			`{ ... @skip(if: true) { b } ... @include(if: true) { a } }`,
+			graphql.NormalizeOptions{},
+		},
+		{
+			`{ user(filter: {name: "luke", age: 1}) { id } }`,
+			`{ user(filter: {age: 1, name: "luke"}) { id } }`,
+			graphql.NormalizeOptions{KeepLiterals: true},
+		},
+	}
+	for _, test := range tests {
+		signatureA, err := graphql.NormalizeWithOptions(testutil.TestParse(t, test.a), "", test.opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		signatureB, err := graphql.NormalizeWithOptions(testutil.TestParse(t, test.b), "", test.opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if signatureA != signatureB {
+			t.Fatalf("expected equal signatures, got %v and %v", signatureA, signatureB)
+		}
+	}
+}
+
+func TestNormalize_KeepsLiteralsOptionally(t *testing.T) {
+	doc := testutil.TestParse(t, `{ user(name: "luke", ids: [1, 2]) { id } }`)
+	signature, err := graphql.NormalizeWithOptions(doc, "", graphql.NormalizeOptions{KeepLiterals: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{user(ids:[1 2]name:"luke"){id}}`; signature != expected {
+		t.Fatalf("expected %v, got %v", expected, signature)
+	}
+}
+
+func TestNormalize_KeepsSelectionOrderOptionally(t *testing.T) {
+	doc := testutil.TestParse(t, `{ user { name id } b a }`)
+	signature, err := graphql.NormalizeWithOptions(doc, "", graphql.NormalizeOptions{KeepSelectionOrder: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{user{name id}b a}`; signature != expected {
+		t.Fatalf("expected %v, got %v", expected, signature)
+	}
+}
+
+func TestNormalize_LeavesTheDocumentUntouched(t *testing.T) {
+	doc := testutil.TestParse(t, `{ b a: c(x: 1) }`)
+	if _, err := graphql.NormalizeWithOptions(doc, "", graphql.NormalizeOptions{RemoveAliases: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signature, err := graphql.Normalize(doc, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{b a:c(x:0)}`; signature != expected {
+		t.Fatalf("expected %v, got %v", expected, signature)
+	}
+}
+
+func TestNormalize_ReportsMissingOperations(t *testing.T) {
+	tests := map[string]struct {
+		query         string
+		operationName string
+		message       string
+	}{
+		"no operation": {
+			query:   `fragment F on T { a }`,
+			message: "Must provide an operation.",
+		},
+		"ambiguous operation": {
+			query:   `query A { a } query B { b }`,
+			message: "Must provide operation name if query contains multiple operations.",
+		},
+		"unknown operation": {
+			query:         `query A { a }`,
+			operationName: "B",
+			message:       `Unknown operation named "B".`,
+		},
+	}
+	for name, test := range tests {
+		_, err := graphql.Normalize(testutil.TestParse(t, test.query), test.operationName)
+		if err == nil || err.Error() != test.message {
+			t.Fatalf("%v: expected %q, got %v", name, test.message, err)
+		}
+	}
+}
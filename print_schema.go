@@ -0,0 +1,288 @@
+package graphql
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// PrintSchema prints the schema in the schema definition language. Specified
+// scalars and directives as well as introspection types are omitted.
+func PrintSchema(schema Schema) string {
+	printed, _ := printer.Print(ASTFromSchema(schema)).(string)
+	lines := strings.Split(strings.TrimSpace(printed), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// ASTFromSchema returns the type system definitions of the schema as a
+// document, the reverse of BuildASTSchema. Types and fields are sorted by
+// name, since a schema built in Go does not keep the order of definitions.
+// The same goes for arguments and enum values.
+func ASTFromSchema(schema Schema) *ast.Document {
+	doc := ast.NewDocument(&ast.Document{})
+
+	if schemaDef := schemaDefinitionAST(schema); schemaDef != nil {
+		doc.Definitions = append(doc.Definitions, schemaDef)
+	}
+	for _, directive := range schema.Directives() {
+		if isSpecifiedDirective(directive) {
+			continue
+		}
+		doc.Definitions = append(doc.Definitions, directiveDefinitionAST(directive))
+	}
+
+	typeMap := schema.TypeMap()
+	names := []string{}
+	for name := range typeMap {
+		if strings.HasPrefix(name, "__") || isSpecifiedScalarName(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if def := typeDefinitionAST(typeMap[name]); def != nil {
+			doc.Definitions = append(doc.Definitions, def)
+		}
+	}
+	return doc
+}
+
+func isSpecifiedDirective(directive *Directive) bool {
+	for _, specified := range SpecifiedDirectives {
+		if specified.Name == directive.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpecifiedScalarName(name string) bool {
+	_, ok := specifiedScalarTypes[name]
+	return ok
+}
+
+// schemaDefinitionAST returns nil when the root types use the conventional
+// names, in which case the schema definition can be omitted.
+func schemaDefinitionAST(schema Schema) *ast.SchemaDefinition {
+	conventional := true
+	operationTypes := []*ast.OperationTypeDefinition{}
+	for _, root := range []struct {
+		operation string
+		object    *Object
+		name      string
+	}{
+		{ast.OperationTypeQuery, schema.QueryType(), "Query"},
+		{ast.OperationTypeMutation, schema.MutationType(), "Mutation"},
+		{ast.OperationTypeSubscription, schema.SubscriptionType(), "Subscription"},
+	} {
+		if root.object == nil {
+			continue
+		}
+		if root.object.Name() != root.name {
+			conventional = false
+		}
+		operationTypes = append(operationTypes, ast.NewOperationTypeDefinition(&ast.OperationTypeDefinition{
+			Operation: root.operation,
+			Type:      namedAST(root.object.Name()),
+		}))
+	}
+	if conventional {
+		return nil
+	}
+	return ast.NewSchemaDefinition(&ast.SchemaDefinition{
+		OperationTypes: operationTypes,
+	})
+}
+
+func typeDefinitionAST(ttype Type) ast.Node {
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		return ast.NewScalarDefinition(&ast.ScalarDefinition{
+			Name:        nameAST(ttype.Name()),
+			Description: descriptionAST(ttype.Description()),
+			Directives:  specifiedByDirectivesAST(ttype.SpecifiedByURL()),
+		})
+	case *Object:
+		interfaces := []*ast.Named{}
+		for _, iface := range ttype.Interfaces() {
+			interfaces = append(interfaces, namedAST(iface.Name()))
+		}
+		return ast.NewObjectDefinition(&ast.ObjectDefinition{
+			Name:        nameAST(ttype.Name()),
+			Description: descriptionAST(ttype.Description()),
+			Interfaces:  interfaces,
+			Fields:      fieldDefinitionsAST(ttype.Fields()),
+		})
+	case *Interface:
+		return ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+			Name:        nameAST(ttype.Name()),
+			Description: descriptionAST(ttype.Description()),
+			Fields:      fieldDefinitionsAST(ttype.Fields()),
+		})
+	case *Union:
+		types := []*ast.Named{}
+		for _, object := range ttype.Types() {
+			types = append(types, namedAST(object.Name()))
+		}
+		return ast.NewUnionDefinition(&ast.UnionDefinition{
+			Name:        nameAST(ttype.Name()),
+			Description: descriptionAST(ttype.Description()),
+			Types:       types,
+		})
+	case *Enum:
+		enumValues := append([]*EnumValueDefinition{}, ttype.Values()...)
+		sort.Slice(enumValues, func(i, j int) bool { return enumValues[i].Name < enumValues[j].Name })
+		values := []*ast.EnumValueDefinition{}
+		for _, value := range enumValues {
+			values = append(values, ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+				Name:        nameAST(value.Name),
+				Description: descriptionAST(value.Description),
+				Directives:  deprecatedDirectivesAST(value.DeprecationReason),
+			}))
+		}
+		return ast.NewEnumDefinition(&ast.EnumDefinition{
+			Name:        nameAST(ttype.Name()),
+			Description: descriptionAST(ttype.Description()),
+			Values:      values,
+		})
+	case *InputObject:
+		fieldMap := ttype.Fields()
+		names := []string{}
+		for name := range fieldMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := []*ast.InputValueDefinition{}
+		for _, name := range names {
+			field := fieldMap[name]
+			fields = append(fields, inputValueDefinitionAST(field.Name(), field.Description(), field.Type, field.DefaultValue))
+		}
+		return ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
+			Name:        nameAST(ttype.Name()),
+			Description: descriptionAST(ttype.Description()),
+			Fields:      fields,
+		})
+	}
+	return nil
+}
+
+func fieldDefinitionsAST(fieldMap FieldDefinitionMap) []*ast.FieldDefinition {
+	names := []string{}
+	for name := range fieldMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fields := []*ast.FieldDefinition{}
+	for _, name := range names {
+		field := fieldMap[name]
+		fields = append(fields, ast.NewFieldDefinition(&ast.FieldDefinition{
+			Name:        nameAST(name),
+			Description: descriptionAST(field.Description),
+			Arguments:   argumentDefinitionsAST(field.Args),
+			Type:        typeAST(field.Type),
+			Directives:  deprecatedDirectivesAST(field.DeprecationReason),
+		}))
+	}
+	return fields
+}
+
+func argumentDefinitionsAST(args []*Argument) []*ast.InputValueDefinition {
+	args = append([]*Argument{}, args...)
+	sort.Slice(args, func(i, j int) bool { return args[i].Name() < args[j].Name() })
+	defs := []*ast.InputValueDefinition{}
+	for _, arg := range args {
+		defs = append(defs, inputValueDefinitionAST(arg.Name(), arg.Description(), arg.Type, arg.DefaultValue))
+	}
+	return defs
+}
+
+func inputValueDefinitionAST(name, description string, ttype Input, defaultValue interface{}) *ast.InputValueDefinition {
+	def := ast.NewInputValueDefinition(&ast.InputValueDefinition{
+		Name:        nameAST(name),
+		Description: descriptionAST(description),
+		Type:        typeAST(ttype),
+	})
+	if !isNullish(defaultValue) {
+		def.DefaultValue = astFromValue(defaultValue, ttype)
+	}
+	return def
+}
+
+func directiveDefinitionAST(directive *Directive) *ast.DirectiveDefinition {
+	locations := []*ast.Name{}
+	for _, location := range directive.Locations {
+		locations = append(locations, nameAST(location))
+	}
+	return ast.NewDirectiveDefinition(&ast.DirectiveDefinition{
+		Name:        nameAST(directive.Name),
+		Description: descriptionAST(directive.Description),
+		Arguments:   argumentDefinitionsAST(directive.Args),
+		Locations:   locations,
+	})
+}
+
+func deprecatedDirectivesAST(reason string) []*ast.Directive {
+	if reason == "" {
+		return []*ast.Directive{}
+	}
+	directive := ast.NewDirective(&ast.Directive{
+		Name: nameAST(DeprecatedDirective.Name),
+	})
+	if reason != DefaultDeprecationReason {
+		directive.Arguments = []*ast.Argument{
+			ast.NewArgument(&ast.Argument{
+				Name:  nameAST("reason"),
+				Value: ast.NewStringValue(&ast.StringValue{Value: reason}),
+			}),
+		}
+	}
+	return []*ast.Directive{directive}
+}
+
+func specifiedByDirectivesAST(url string) []*ast.Directive {
+	if url == "" {
+		return []*ast.Directive{}
+	}
+	return []*ast.Directive{
+		ast.NewDirective(&ast.Directive{
+			Name: nameAST(SpecifiedByDirective.Name),
+			Arguments: []*ast.Argument{
+				ast.NewArgument(&ast.Argument{
+					Name:  nameAST("url"),
+					Value: ast.NewStringValue(&ast.StringValue{Value: url}),
+				}),
+			},
+		}),
+	}
+}
+
+func typeAST(ttype Type) ast.Type {
+	switch ttype := ttype.(type) {
+	case *List:
+		return ast.NewList(&ast.List{Type: typeAST(ttype.OfType)})
+	case *NonNull:
+		return ast.NewNonNull(&ast.NonNull{Type: typeAST(ttype.OfType)})
+	}
+	return namedAST(ttype.Name())
+}
+
+func namedAST(name string) *ast.Named {
+	return ast.NewNamed(&ast.Named{Name: nameAST(name)})
+}
+
+func nameAST(name string) *ast.Name {
+	return ast.NewName(&ast.Name{Value: name})
+}
+
+func descriptionAST(description string) *ast.StringValue {
+	if description == "" {
+		return nil
+	}
+	return ast.NewStringValue(&ast.StringValue{Value: description})
+}
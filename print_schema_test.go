@@ -0,0 +1,64 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestPrintSchema_PrintsSortedDefinitions(t *testing.T) {
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, `
+		schema { query: Root }
+		directive @cost(weight: Int = 1) on FIELD_DEFINITION
+		enum Color { RED GREEN @deprecated BLUE @deprecated(reason: "Too dark") }
+		input Filter { color: Color = RED, limit: Int }
+		type Root {
+			"""Things to show"""
+			things(filter: Filter, after: String): [Thing!]!
+		}
+		union Thing = Root
+	`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `schema {
+  query: Root
+}
+
+directive @cost(weight: Int = 1) on FIELD_DEFINITION
+
+enum Color {
+  BLUE @deprecated(reason: "Too dark")
+  GREEN @deprecated
+  RED
+}
+
+input Filter {
+  color: Color = RED
+  limit: Int
+}
+
+type Root {
+
+  """Things to show"""
+  things(after: String, filter: Filter): [Thing!]!
+}
+
+union Thing = Root
+`
+	if printed := graphql.PrintSchema(schema); printed != expected {
+		t.Fatalf("unexpected schema, got:\n%v\nwant:\n%v", printed, expected)
+	}
+}
+
+func TestPrintSchema_OutputCanBeBuiltAgain(t *testing.T) {
+	printed := graphql.PrintSchema(testutil.StarWarsSchema)
+	schema, err := graphql.BuildASTSchema(testutil.TestParse(t, printed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reprinted := graphql.PrintSchema(schema); reprinted != printed {
+		t.Fatalf("schema differs after round-trip, got:\n%v\nwant:\n%v", reprinted, printed)
+	}
+}
@@ -0,0 +1,163 @@
+// Package relay provides helpers to build schemas that follow the Relay
+// specifications: cursor based connections, the Node interface with global
+// object identification and mutations with a client mutation identifier.
+package relay
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+// ConnectionArgs are the arguments of a field returning a connection, usable
+// in both directions.
+var ConnectionArgs = graphql.FieldConfigArgument{
+	"before": &graphql.ArgumentConfig{
+		Type: graphql.String,
+	},
+	"after": &graphql.ArgumentConfig{
+		Type: graphql.String,
+	},
+	"first": &graphql.ArgumentConfig{
+		Type: graphql.Int,
+	},
+	"last": &graphql.ArgumentConfig{
+		Type: graphql.Int,
+	},
+}
+
+// ForwardConnectionArgs are the arguments of a connection that can only be
+// paginated forward.
+var ForwardConnectionArgs = graphql.FieldConfigArgument{
+	"after": &graphql.ArgumentConfig{
+		Type: graphql.String,
+	},
+	"first": &graphql.ArgumentConfig{
+		Type: graphql.Int,
+	},
+}
+
+// BackwardConnectionArgs are the arguments of a connection that can only be
+// paginated backward.
+var BackwardConnectionArgs = graphql.FieldConfigArgument{
+	"before": &graphql.ArgumentConfig{
+		Type: graphql.String,
+	},
+	"last": &graphql.ArgumentConfig{
+		Type: graphql.Int,
+	},
+}
+
+// NewConnectionArgs returns ConnectionArgs merged with additional arguments,
+// for example filters applied before paginating.
+func NewConnectionArgs(configMap graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{}
+	for name, arg := range ConnectionArgs {
+		args[name] = arg
+	}
+	for name, arg := range configMap {
+		args[name] = arg
+	}
+	return args
+}
+
+// ConnectionConfig describes the connection types to build for a node type.
+type ConnectionConfig struct {
+	// Name prefixes the generated type names, defaults to the name of the
+	// node type.
+	Name     string
+	NodeType *graphql.Object
+
+	// ResolveNode resolves the node of an edge, defaults to the `node` field
+	// of the EdgeType value.
+	ResolveNode graphql.FieldResolveFn
+
+	// ResolveCursor resolves the cursor of an edge, defaults to the `cursor`
+	// field of the EdgeType value.
+	ResolveCursor graphql.FieldResolveFn
+
+	EdgeFields       graphql.Fields
+	ConnectionFields graphql.Fields
+}
+
+// GraphQLConnectionDefinitions holds the types built by ConnectionDefinitions.
+type GraphQLConnectionDefinitions struct {
+	EdgeType       *graphql.Object
+	ConnectionType *graphql.Object
+}
+
+// PageInfoType is the `PageInfo` type shared by every connection.
+var PageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "PageInfo",
+	Description: "Information about pagination in a connection.",
+	Fields: graphql.Fields{
+		"hasNextPage": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "When paginating forwards, are there more items?",
+		},
+		"hasPreviousPage": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.Boolean),
+			Description: "When paginating backwards, are there more items?",
+		},
+		"startCursor": &graphql.Field{
+			Type:        graphql.String,
+			Description: "When paginating backwards, the cursor to continue.",
+		},
+		"endCursor": &graphql.Field{
+			Type:        graphql.String,
+			Description: "When paginating forwards, the cursor to continue.",
+		},
+	},
+})
+
+// ConnectionDefinitions builds the `<Name>Edge` and `<Name>Connection` types
+// for a node type.
+func ConnectionDefinitions(config ConnectionConfig) *GraphQLConnectionDefinitions {
+	name := config.Name
+	if name == "" {
+		name = config.NodeType.Name()
+	}
+
+	edgeFields := graphql.Fields{
+		"node": &graphql.Field{
+			Type:        config.NodeType,
+			Resolve:     config.ResolveNode,
+			Description: "The item at the end of the edge",
+		},
+		"cursor": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve:     config.ResolveCursor,
+			Description: "A cursor for use in pagination",
+		},
+	}
+	for fieldName, field := range config.EdgeFields {
+		edgeFields[fieldName] = field
+	}
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Edge",
+		Description: "An edge in a connection",
+		Fields:      edgeFields,
+	})
+
+	connectionFields := graphql.Fields{
+		"pageInfo": &graphql.Field{
+			Type:        graphql.NewNonNull(PageInfoType),
+			Description: "Information to aid in pagination.",
+		},
+		"edges": &graphql.Field{
+			Type:        graphql.NewList(edgeType),
+			Description: "A list of edges.",
+		},
+	}
+	for fieldName, field := range config.ConnectionFields {
+		connectionFields[fieldName] = field
+	}
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Connection",
+		Description: "A connection to a list of items.",
+		Fields:      connectionFields,
+	})
+
+	return &GraphQLConnectionDefinitions{
+		EdgeType:       edgeType,
+		ConnectionType: connectionType,
+	}
+}
@@ -0,0 +1,80 @@
+package relay
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+)
+
+// MutationFn performs a mutation with the fields of its input and returns the
+// fields of its payload, without the client mutation id.
+type MutationFn func(ctx context.Context, inputMap map[string]interface{}, info graphql.ResolveInfo) (map[string]interface{}, error)
+
+// MutationConfig configures MutationWithClientMutationID.
+type MutationConfig struct {
+	Name                string
+	Description         string
+	DeprecationReason   string
+	InputFields         graphql.InputObjectConfigFieldMap
+	OutputFields        graphql.Fields
+	MutateAndGetPayload MutationFn
+}
+
+// MutationWithClientMutationID builds a mutation field taking a single
+// `input: <Name>Input!` argument and returning a `<Name>Payload`. Both carry
+// a `clientMutationId` that is passed through unchanged.
+func MutationWithClientMutationID(config MutationConfig) *graphql.Field {
+	augmentedInputFields := graphql.InputObjectConfigFieldMap{
+		"clientMutationId": &graphql.InputObjectFieldConfig{
+			Type: graphql.String,
+		},
+	}
+	for name, field := range config.InputFields {
+		augmentedInputFields[name] = field
+	}
+	augmentedOutputFields := graphql.Fields{
+		"clientMutationId": &graphql.Field{
+			Type: graphql.String,
+		},
+	}
+	for name, field := range config.OutputFields {
+		augmentedOutputFields[name] = field
+	}
+
+	inputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   config.Name + "Input",
+		Fields: augmentedInputFields,
+	})
+	outputType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   config.Name + "Payload",
+		Fields: augmentedOutputFields,
+	})
+	return &graphql.Field{
+		Name:              config.Name,
+		Description:       config.Description,
+		DeprecationReason: config.DeprecationReason,
+		Type:              outputType,
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(inputType),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			input, _ := p.Args["input"].(map[string]interface{})
+			if input == nil {
+				input = map[string]interface{}{}
+			}
+			payload, err := config.MutateAndGetPayload(p.Context, input, p.Info)
+			if err != nil {
+				return nil, err
+			}
+			if payload == nil {
+				payload = map[string]interface{}{}
+			}
+			if clientMutationID, ok := input["clientMutationId"]; ok {
+				payload["clientMutationId"] = clientMutationID
+			}
+			return payload, nil
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package relay_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/relay"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestMutationWithClientMutationID_PassesClientMutationIDThrough(t *testing.T) {
+	addNumbers := relay.MutationWithClientMutationID(relay.MutationConfig{
+		Name: "AddNumbers",
+		InputFields: graphql.InputObjectConfigFieldMap{
+			"a": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"b": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		},
+		OutputFields: graphql.Fields{
+			"sum": &graphql.Field{Type: graphql.Int},
+		},
+		MutateAndGetPayload: func(ctx context.Context, input map[string]interface{}, info graphql.ResolveInfo) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"sum": input["a"].(int) + input["b"].(int),
+			}, nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"noop": &graphql.Field{Type: graphql.String}},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Mutation",
+			Fields: graphql.Fields{"addNumbers": addNumbers},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `mutation {
+			withID: addNumbers(input: { a: 1, b: 2, clientMutationId: "abc" }) { sum clientMutationId }
+			withoutID: addNumbers(input: { a: 3, b: 4 }) { sum clientMutationId }
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"withID": map[string]interface{}{
+				"sum":              3,
+				"clientMutationId": "abc",
+			},
+			"withoutID": map[string]interface{}{
+				"sum":              7,
+				"clientMutationId": nil,
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if schema.Type("AddNumbersInput") == nil || schema.Type("AddNumbersPayload") == nil {
+		t.Fatalf("expected input and payload types in the schema")
+	}
+}
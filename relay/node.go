@@ -0,0 +1,129 @@
+package relay
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// IDFetcherFn fetches an object from its global id.
+type IDFetcherFn func(ctx context.Context, id string, info graphql.ResolveInfo) (interface{}, error)
+
+// GlobalIDFetcherFn returns the id of obj, the part of its global id that
+// identifies it within its type.
+type GlobalIDFetcherFn func(ctx context.Context, obj interface{}, info graphql.ResolveInfo) (string, error)
+
+// NodeDefinitionsConfig configures NodeDefinitions.
+type NodeDefinitionsConfig struct {
+	IDFetcher IDFetcherFn
+
+	// TypeResolve resolves the concrete type of a fetched object, if the
+	// implementing types do not define IsTypeOf.
+	TypeResolve graphql.ResolveTypeFn
+}
+
+// NodeDefinitions holds the Node interface and the `node` field built by
+// NewNodeDefinitions.
+type NodeDefinitions struct {
+	NodeInterface *graphql.Interface
+	NodeField     *graphql.Field
+}
+
+// NewNodeDefinitions builds the `Node` interface and a `node(id: ID!)` root
+// field that refetches any object implementing it.
+func NewNodeDefinitions(config NodeDefinitionsConfig) *NodeDefinitions {
+	nodeInterface := graphql.NewInterface(graphql.InterfaceConfig{
+		Name:        "Node",
+		Description: "An object with an ID",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "The id of the object",
+			},
+		},
+		ResolveType: config.TypeResolve,
+	})
+
+	nodeField := &graphql.Field{
+		Name:        "node",
+		Description: "Fetches an object given its ID",
+		Type:        nodeInterface,
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "The ID of an object",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if config.IDFetcher == nil {
+				return nil, nil
+			}
+			id := ""
+			if iid, ok := p.Args["id"]; ok {
+				id = fmt.Sprintf("%v", iid)
+			}
+			return config.IDFetcher(p.Context, id, p.Info)
+		},
+	}
+	return &NodeDefinitions{
+		NodeInterface: nodeInterface,
+		NodeField:     nodeField,
+	}
+}
+
+// ResolvedGlobalID is the type name and id decoded from a global id.
+type ResolvedGlobalID struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// ToGlobalID takes a type name and an id specific to that type name, and
+// returns a globally unique id.
+func ToGlobalID(ttype string, id string) string {
+	return base64.StdEncoding.EncodeToString([]byte(ttype + ":" + id))
+}
+
+// FromGlobalID decodes a global id created by ToGlobalID into the type name
+// and id it was created from.
+func FromGlobalID(globalID string) (*ResolvedGlobalID, error) {
+	b, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid global id %q", globalID)
+	}
+	tokens := strings.SplitN(string(b), ":", 2)
+	if len(tokens) != 2 || tokens[0] == "" {
+		return nil, fmt.Errorf("Invalid global id %q", globalID)
+	}
+	return &ResolvedGlobalID{
+		Type: tokens[0],
+		ID:   tokens[1],
+	}, nil
+}
+
+// GlobalIDField returns an `id: ID!` field that resolves to the global id of
+// an object. If idFetcher is nil, the id is read with the default resolver
+// from the `id` of the source.
+func GlobalIDField(typeName string, idFetcher GlobalIDFetcherFn) *graphql.Field {
+	return &graphql.Field{
+		Name:        "id",
+		Description: "The ID of an object",
+		Type:        graphql.NewNonNull(graphql.ID),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if idFetcher != nil {
+				id, err := idFetcher(p.Context, p.Source, p.Info)
+				if err != nil {
+					return nil, err
+				}
+				return ToGlobalID(typeName, id), nil
+			}
+			id, err := graphql.DefaultResolveFn(p)
+			if err != nil || id == nil {
+				return nil, err
+			}
+			return ToGlobalID(typeName, fmt.Sprintf("%v", id)), nil
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package relay_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/relay"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type user struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type photo struct {
+	ID    string `json:"id"`
+	Width int    `json:"width"`
+}
+
+var nodeTestSchema graphql.Schema
+
+func init() {
+	users := map[string]*user{"1": {ID: "1", Name: "John Doe"}}
+	photos := map[string]*photo{"3": {ID: "3", Width: 300}}
+
+	var userType, photoType *graphql.Object
+	nodeDefinitions := relay.NewNodeDefinitions(relay.NodeDefinitionsConfig{
+		IDFetcher: func(ctx context.Context, id string, info graphql.ResolveInfo) (interface{}, error) {
+			resolvedID, err := relay.FromGlobalID(id)
+			if err != nil {
+				return nil, err
+			}
+			switch resolvedID.Type {
+			case "User":
+				return users[resolvedID.ID], nil
+			case "Photo":
+				return photos[resolvedID.ID], nil
+			}
+			return nil, errors.New("Unknown node type")
+		},
+		TypeResolve: func(p graphql.ResolveTypeParams) *graphql.Object {
+			switch p.Value.(type) {
+			case *user:
+				return userType
+			case *photo:
+				return photoType
+			}
+			return nil
+		},
+	})
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":   relay.GlobalIDField("User", nil),
+			"name": &graphql.Field{Type: graphql.String},
+		},
+		Interfaces: []*graphql.Interface{nodeDefinitions.NodeInterface},
+	})
+	photoType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Photo",
+		Fields: graphql.Fields{
+			"id": relay.GlobalIDField("Photo", func(ctx context.Context, obj interface{}, info graphql.ResolveInfo) (string, error) {
+				return obj.(*photo).ID, nil
+			}),
+			"width": &graphql.Field{Type: graphql.Int},
+		},
+		Interfaces: []*graphql.Interface{nodeDefinitions.NodeInterface},
+	})
+
+	var err error
+	nodeTestSchema, err = graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"node": nodeDefinitions.NodeField,
+			},
+		}),
+		Types: []graphql.Type{userType, photoType},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestGlobalID_RoundTrips(t *testing.T) {
+	globalID := relay.ToGlobalID("User", "a:b")
+	if globalID != "VXNlcjphOmI=" {
+		t.Fatalf("unexpected global id: %v", globalID)
+	}
+	resolved, err := relay.FromGlobalID(globalID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(resolved, &relay.ResolvedGlobalID{Type: "User", ID: "a:b"}) {
+		t.Fatalf("unexpected resolved id: %+v", resolved)
+	}
+	for _, invalid := range []string{"not base64!", "bm9jb2xvbg=="} {
+		if _, err := relay.FromGlobalID(invalid); err == nil {
+			t.Fatalf("expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestNodeDefinitions_RefetchesObjectsByGlobalID(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema: nodeTestSchema,
+		RequestString: `{
+			user: node(id: "VXNlcjox") { id ... on User { name } }
+			photo: node(id: "UGhvdG86Mw==") { id ... on Photo { width } }
+			missing: node(id: "VXNlcjo5") { id }
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": map[string]interface{}{
+				"id":   "VXNlcjox",
+				"name": "John Doe",
+			},
+			"photo": map[string]interface{}{
+				"id":    "UGhvdG86Mw==",
+				"width": 300,
+			},
+			"missing": nil,
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
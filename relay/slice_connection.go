@@ -0,0 +1,198 @@
+package relay
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const cursorPrefix = "arrayconnection:"
+
+// ConnectionCursor is an opaque pagination cursor.
+type ConnectionCursor string
+
+// PageInfo is the value resolved by PageInfoType.
+type PageInfo struct {
+	StartCursor     ConnectionCursor `json:"startCursor"`
+	EndCursor       ConnectionCursor `json:"endCursor"`
+	HasPreviousPage bool             `json:"hasPreviousPage"`
+	HasNextPage     bool             `json:"hasNextPage"`
+}
+
+// Edge is the value resolved by a connection's edge type.
+type Edge struct {
+	Node   interface{}      `json:"node"`
+	Cursor ConnectionCursor `json:"cursor"`
+}
+
+// Connection is the value resolved by a connection type.
+type Connection struct {
+	Edges    []*Edge  `json:"edges"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// ConnectionArguments holds the pagination arguments of a connection field.
+// First and Last are nil when they are not provided.
+type ConnectionArguments struct {
+	Before ConnectionCursor `json:"before"`
+	After  ConnectionCursor `json:"after"`
+	First  *int             `json:"first"`
+	Last   *int             `json:"last"`
+}
+
+// NewConnectionArguments reads the pagination arguments from the arguments of
+// a field, typically ResolveParams.Args.
+func NewConnectionArguments(args map[string]interface{}) ConnectionArguments {
+	connArgs := ConnectionArguments{}
+	if before, ok := args["before"].(string); ok {
+		connArgs.Before = ConnectionCursor(before)
+	}
+	if after, ok := args["after"].(string); ok {
+		connArgs.After = ConnectionCursor(after)
+	}
+	if first, ok := args["first"].(int); ok {
+		connArgs.First = &first
+	}
+	if last, ok := args["last"].(int); ok {
+		connArgs.Last = &last
+	}
+	return connArgs
+}
+
+// SliceMeta describes where a slice is located in the complete list when
+// only part of the list was loaded.
+type SliceMeta struct {
+	SliceStart  int
+	ArrayLength int
+}
+
+// ConnectionFromSlice builds a connection from a slice that holds the
+// complete list of items.
+func ConnectionFromSlice(data []interface{}, args ConnectionArguments) (*Connection, error) {
+	return ConnectionFromSliceWithMeta(data, args, SliceMeta{
+		SliceStart:  0,
+		ArrayLength: len(data),
+	})
+}
+
+// ConnectionFromSliceWithMeta builds a connection from a slice that holds
+// part of a list, as described by meta.
+func ConnectionFromSliceWithMeta(data []interface{}, args ConnectionArguments, meta SliceMeta) (*Connection, error) {
+	if args.First != nil && *args.First < 0 {
+		return nil, errors.New(`Argument "first" must be a non-negative integer`)
+	}
+	if args.Last != nil && *args.Last < 0 {
+		return nil, errors.New(`Argument "last" must be a non-negative integer`)
+	}
+
+	sliceEnd := meta.SliceStart + len(data)
+	beforeOffset, err := offsetWithDefault(args.Before, meta.ArrayLength)
+	if err != nil {
+		return nil, err
+	}
+	afterOffset, err := offsetWithDefault(args.After, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	startOffset := maxInt(meta.SliceStart-1, afterOffset, -1) + 1
+	endOffset := minInt(sliceEnd, beforeOffset, meta.ArrayLength)
+	if args.First != nil {
+		endOffset = minInt(endOffset, startOffset+*args.First)
+	}
+	if args.Last != nil {
+		startOffset = maxInt(startOffset, endOffset-*args.Last)
+	}
+
+	begin := maxInt(startOffset-meta.SliceStart, 0)
+	end := len(data) - (sliceEnd - endOffset)
+	edges := []*Edge{}
+	if begin < end {
+		for i, node := range data[begin:end] {
+			edges = append(edges, &Edge{
+				Node:   node,
+				Cursor: OffsetToCursor(startOffset + i),
+			})
+		}
+	}
+
+	lowerBound := 0
+	if args.After != "" {
+		lowerBound = afterOffset + 1
+	}
+	upperBound := meta.ArrayLength
+	if args.Before != "" {
+		upperBound = beforeOffset
+	}
+	pageInfo := PageInfo{
+		HasPreviousPage: args.Last != nil && startOffset > lowerBound,
+		HasNextPage:     args.First != nil && endOffset < upperBound,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = edges[0].Cursor
+		pageInfo.EndCursor = edges[len(edges)-1].Cursor
+	}
+	return &Connection{
+		Edges:    edges,
+		PageInfo: pageInfo,
+	}, nil
+}
+
+// OffsetToCursor creates the cursor of the item at offset.
+func OffsetToCursor(offset int) ConnectionCursor {
+	return ConnectionCursor(base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%v%v", cursorPrefix, offset))))
+}
+
+// CursorToOffset extracts the offset from a cursor created by OffsetToCursor.
+func CursorToOffset(cursor ConnectionCursor) (int, error) {
+	b, err := base64.StdEncoding.DecodeString(string(cursor))
+	if err != nil || !strings.HasPrefix(string(b), cursorPrefix) {
+		return 0, fmt.Errorf("Invalid cursor %q", cursor)
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(b), cursorPrefix))
+	if err != nil {
+		return 0, fmt.Errorf("Invalid cursor %q", cursor)
+	}
+	return offset, nil
+}
+
+// CursorForObjectInConnection returns the cursor of object in data, or an
+// empty cursor if it is not part of it.
+func CursorForObjectInConnection(data []interface{}, object interface{}) ConnectionCursor {
+	for i, item := range data {
+		if reflect.DeepEqual(item, object) {
+			return OffsetToCursor(i)
+		}
+	}
+	return ""
+}
+
+func offsetWithDefault(cursor ConnectionCursor, defaultOffset int) (int, error) {
+	if cursor == "" {
+		return defaultOffset, nil
+	}
+	return CursorToOffset(cursor)
+}
+
+func maxInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value > result {
+			result = value
+		}
+	}
+	return result
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
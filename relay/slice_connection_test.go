@@ -0,0 +1,239 @@
+package relay_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/relay"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+var letters = []interface{}{"A", "B", "C", "D", "E"}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func edgesOf(t *testing.T, conn *relay.Connection) []interface{} {
+	nodes := []interface{}{}
+	for _, edge := range conn.Edges {
+		nodes = append(nodes, edge.Node)
+		offset, err := relay.CursorToOffset(edge.Cursor)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if letters[offset] != edge.Node {
+			t.Fatalf("cursor %v does not point to %v", edge.Cursor, edge.Node)
+		}
+	}
+	return nodes
+}
+
+func TestConnectionFromSlice_Pagination(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            relay.ConnectionArguments
+		nodes           []interface{}
+		hasPreviousPage bool
+		hasNextPage     bool
+	}{
+		{
+			name:  "all elements without pagination",
+			args:  relay.ConnectionArguments{},
+			nodes: letters,
+		},
+		{
+			name:        "first",
+			args:        relay.ConnectionArguments{First: intPtr(2)},
+			nodes:       []interface{}{"A", "B"},
+			hasNextPage: true,
+		},
+		{
+			name:  "first larger than the list",
+			args:  relay.ConnectionArguments{First: intPtr(10)},
+			nodes: letters,
+		},
+		{
+			name:            "last",
+			args:            relay.ConnectionArguments{Last: intPtr(2)},
+			nodes:           []interface{}{"D", "E"},
+			hasPreviousPage: true,
+		},
+		{
+			name:        "first after",
+			args:        relay.ConnectionArguments{First: intPtr(2), After: relay.OffsetToCursor(1)},
+			nodes:       []interface{}{"C", "D"},
+			hasNextPage: true,
+		},
+		{
+			name:            "last before",
+			args:            relay.ConnectionArguments{Last: intPtr(2), Before: relay.OffsetToCursor(3)},
+			nodes:           []interface{}{"B", "C"},
+			hasPreviousPage: true,
+		},
+		{
+			name:  "after and before",
+			args:  relay.ConnectionArguments{After: relay.OffsetToCursor(0), Before: relay.OffsetToCursor(4)},
+			nodes: []interface{}{"B", "C", "D"},
+		},
+		{
+			name:        "first with after and before",
+			args:        relay.ConnectionArguments{First: intPtr(2), After: relay.OffsetToCursor(0), Before: relay.OffsetToCursor(4)},
+			nodes:       []interface{}{"B", "C"},
+			hasNextPage: true,
+		},
+		{
+			name:  "zero elements",
+			args:  relay.ConnectionArguments{First: intPtr(0)},
+			nodes: []interface{}{},
+			// nothing was returned, but there are more elements after
+			hasNextPage: true,
+		},
+		{
+			name:  "after the last element",
+			args:  relay.ConnectionArguments{First: intPtr(2), After: relay.OffsetToCursor(4)},
+			nodes: []interface{}{},
+		},
+	}
+	for _, test := range tests {
+		conn, err := relay.ConnectionFromSlice(letters, test.args)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", test.name, err)
+		}
+		if nodes := edgesOf(t, conn); !reflect.DeepEqual(nodes, test.nodes) {
+			t.Fatalf("%v: expected %v, got %v", test.name, test.nodes, nodes)
+		}
+		if conn.PageInfo.HasPreviousPage != test.hasPreviousPage || conn.PageInfo.HasNextPage != test.hasNextPage {
+			t.Fatalf("%v: unexpected page info %+v", test.name, conn.PageInfo)
+		}
+		if len(conn.Edges) > 0 && (conn.PageInfo.StartCursor != conn.Edges[0].Cursor || conn.PageInfo.EndCursor != conn.Edges[len(conn.Edges)-1].Cursor) {
+			t.Fatalf("%v: unexpected cursors %+v", test.name, conn.PageInfo)
+		}
+	}
+}
+
+func TestConnectionFromSliceWithMeta_PartialSlice(t *testing.T) {
+	conn, err := relay.ConnectionFromSliceWithMeta(letters[2:4], relay.ConnectionArguments{
+		First: intPtr(2),
+		After: relay.OffsetToCursor(1),
+	}, relay.SliceMeta{SliceStart: 2, ArrayLength: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nodes := edgesOf(t, conn); !reflect.DeepEqual(nodes, []interface{}{"C", "D"}) {
+		t.Fatalf("unexpected nodes: %v", nodes)
+	}
+	if !conn.PageInfo.HasNextPage {
+		t.Fatalf("expected a next page")
+	}
+}
+
+func TestConnectionFromSlice_RejectsInvalidArguments(t *testing.T) {
+	for _, first := range []int{-1, -2} {
+		if _, err := relay.ConnectionFromSlice(letters, relay.ConnectionArguments{First: intPtr(first)}); err == nil {
+			t.Fatalf("expected an error for first %v", first)
+		}
+	}
+	if _, err := relay.ConnectionFromSlice(letters, relay.ConnectionArguments{Last: intPtr(-1)}); err == nil {
+		t.Fatalf("expected an error for a negative last")
+	}
+	if _, err := relay.ConnectionFromSlice(letters, relay.ConnectionArguments{After: "invalid"}); err == nil {
+		t.Fatalf("expected an error for an invalid cursor")
+	}
+}
+
+func TestCursorForObjectInConnection(t *testing.T) {
+	if cursor := relay.CursorForObjectInConnection(letters, "C"); cursor != relay.OffsetToCursor(2) {
+		t.Fatalf("unexpected cursor: %v", cursor)
+	}
+	if cursor := relay.CursorForObjectInConnection(letters, "Z"); cursor != "" {
+		t.Fatalf("expected no cursor, got %v", cursor)
+	}
+}
+
+func TestConnectionDefinitions_ExecutesConnectionQuery(t *testing.T) {
+	letterType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Letter",
+		Fields: graphql.Fields{
+			"value": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+	letterConnection := relay.ConnectionDefinitions(relay.ConnectionConfig{
+		NodeType: letterType,
+		ConnectionFields: graphql.Fields{
+			"totalCount": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return len(letters), nil
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"letters": &graphql.Field{
+					Type: letterConnection.ConnectionType,
+					Args: relay.ConnectionArgs,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return relay.ConnectionFromSlice(letters, relay.NewConnectionArguments(p.Args))
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			letters(first: 2, after: "YXJyYXljb25uZWN0aW9uOjA=") {
+				totalCount
+				edges { cursor node { value } }
+				pageInfo { startCursor endCursor hasNextPage hasPreviousPage }
+			}
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"letters": map[string]interface{}{
+				"totalCount": 5,
+				"edges": []interface{}{
+					map[string]interface{}{
+						"cursor": "YXJyYXljb25uZWN0aW9uOjE=",
+						"node":   map[string]interface{}{"value": "B"},
+					},
+					map[string]interface{}{
+						"cursor": "YXJyYXljb25uZWN0aW9uOjI=",
+						"node":   map[string]interface{}{"value": "C"},
+					},
+				},
+				"pageInfo": map[string]interface{}{
+					"startCursor":     "YXJyYXljb25uZWN0aW9uOjE=",
+					"endCursor":       "YXJyYXljb25uZWN0aW9uOjI=",
+					"hasNextPage":     true,
+					"hasPreviousPage": false,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	result = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ letters(first: -1) { edges { cursor } } }`,
+	})
+	if len(result.Errors) != 1 || result.Errors[0].Message != `Argument "first" must be a non-negative integer` {
+		t.Fatalf("expected an error for a negative first, got %v", result)
+	}
+}
@@ -0,0 +1,119 @@
+package responsecache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// MemoryCache is a Cache holding the results in memory, until they expire or
+// are evicted by more recently used results once it holds its maximum number
+// of entries. It is safe for concurrent use.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	// lru holds the entries, the most recently used first
+	lru     *list.List
+	entries map[string]*list.Element
+	// tags holds the keys of the entries by tag
+	tags map[string]map[string]bool
+}
+
+var _ Cache = (*MemoryCache)(nil)
+
+type memoryEntry struct {
+	key     string
+	result  *graphql.Result
+	expires time.Time
+	tags    []string
+}
+
+// NewMemoryCache returns a MemoryCache holding at most maxEntries results,
+// or any number of results if maxEntries is not positive.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    map[string]*list.Element{},
+		tags:       map[string]map[string]bool{},
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) (*graphql.Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if !time.Now().Before(entry.expires) {
+		c.remove(element)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry.result, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, result *graphql.Result, ttl time.Duration, tags []string) {
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	entry := &memoryEntry{
+		key:     key,
+		result:  result,
+		expires: time.Now().Add(ttl),
+		tags:    tags,
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for _, tag := range tags {
+		if c.tags[tag] == nil {
+			c.tags[tag] = map[string]bool{}
+		}
+		c.tags[tag][key] = true
+	}
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Invalidate implements Cache.
+func (c *MemoryCache) Invalidate(tags ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if element, ok := c.entries[key]; ok {
+				c.remove(element)
+			}
+		}
+	}
+}
+
+// Len returns the number of results held by the cache, expired ones
+// included.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *MemoryCache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*memoryEntry)
+	delete(c.entries, entry.key)
+	for _, tag := range entry.tags {
+		delete(c.tags[tag], entry.key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+// Package responsecache caches whole query results, sparing their execution
+// when the same query is requested again.
+//
+// Extension.Do executes requests in place of graphql.Do: results are looked
+// up by a key made of the operation, normalized with its literal values
+// kept, of the variables, of Params.PreserveFieldOrder and, for results of
+// PRIVATE scope, of the session of the request. The results are cached for
+// the maxAge of their cachecontrol.Policy, so that the schema must have a
+// cachecontrol.Extension too. Results with errors, results of mutations and
+// subscriptions, and results of PRIVATE scope requested without session are
+// not cached. Requests with Params.ValidationRules bypass the cache, since
+// their result depends on rules which cannot be part of the key.
+//
+// Cached results are tagged with the names of the object, interface and
+// union types returned by their fields, and with the tags added by their
+// resolvers with AddTags. Mutation resolvers invalidate the results holding
+// the data they modify with Invalidate.
+//
+// Cache hits are not validated again: requests whose validation depends on
+// the request, e.g. through SchemaConfig.Visibility, must have different
+// session keys, and their results should be PRIVATE.
+package responsecache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/cachecontrol"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Name is the name of the extension and of its entry in Result.Extensions.
+const Name = "responseCache"
+
+// Cache stores results by key. Its implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the result stored at key, if it has not expired.
+	Get(key string) (*graphql.Result, bool)
+
+	// Set stores result at key for ttl, replacing the result stored there,
+	// with tags for invalidating it.
+	Set(key string, result *graphql.Result, ttl time.Duration, tags []string)
+
+	// Invalidate removes the results with any of tags.
+	Invalidate(tags ...string)
+}
+
+// Config configures New.
+type Config struct {
+	// SessionKey returns the key of the session of a request, identifying
+	// its user, or "" if it has none. Results of PRIVATE scope are cached
+	// per session, and not at all if SessionKey is nil.
+	SessionKey func(ctx context.Context) string
+}
+
+// Status reports in Result.Extensions whether a result was read from the
+// cache.
+type Status struct {
+	Hit bool `json:"hit"`
+}
+
+// StatusOf returns the status added to result by the Extension.
+func StatusOf(result *graphql.Result) (Status, bool) {
+	if result == nil {
+		return Status{}, false
+	}
+	status, ok := result.Extensions[Name].(Status)
+	return status, ok
+}
+
+// Extension is a graphql.Extension caching the results of the requests
+// executed with Do. It must be added to the schema with Schema.AddExtensions.
+type Extension struct {
+	cache  Cache
+	config Config
+}
+
+var _ graphql.Extension = (*Extension)(nil)
+
+// New returns an Extension storing the results in cache.
+func New(cache Cache, config Config) *Extension {
+	return &Extension{cache: cache, config: config}
+}
+
+// Invalidate removes the cached results with any of tags.
+func (e *Extension) Invalidate(tags ...string) {
+	e.cache.Invalidate(tags...)
+}
+
+// Do executes the request of p like graphql.Do, returning the cached result
+// of an identical request if any, and caching the result otherwise. The
+// cached results are shared: they must not be modified.
+func (e *Extension) Do(p graphql.Params) *graphql.Result {
+	if p.Context == nil {
+		p.Context = context.Background()
+	}
+	keys, ok := e.keys(p)
+	if !ok {
+		return graphql.Do(p)
+	}
+	for _, key := range []string{keys.private, keys.public} {
+		if key == "" {
+			continue
+		}
+		if result, ok := e.cache.Get(key); ok {
+			return withStatus(result, Status{Hit: true})
+		}
+	}
+
+	state := &state{extension: e, tags: map[string]bool{}}
+	p.Context = context.WithValue(p.Context, stateKey{}, state)
+	result := graphql.Do(p)
+	if len(result.Errors) != 0 {
+		return result
+	}
+	policy, ok := cachecontrol.PolicyOf(result)
+	if !ok || !policy.Cacheable() {
+		return result
+	}
+	key := keys.public
+	if policy.Scope == cachecontrol.Private {
+		key = keys.private
+	}
+	if key != "" {
+		e.cache.Set(key, withoutStatus(result), time.Duration(policy.MaxAge)*time.Second, state.tagList())
+	}
+	return result
+}
+
+// requestKeys are the keys of the result of a request, the private key being
+// "" if the request has no session.
+type requestKeys struct {
+	public  string
+	private string
+}
+
+// keys returns the keys of the result of the request of p, if it is a query
+// whose result may be cached.
+func (e *Extension) keys(p graphql.Params) (requestKeys, bool) {
+	if p.ValidationRules != nil {
+		return requestKeys{}, false
+	}
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(p.RequestString),
+			Name: "GraphQL request",
+		}),
+		Options: parser.ParseOptions{
+			MaxTokens: p.MaxTokens,
+			MaxDepth:  p.MaxDepth,
+		},
+	})
+	if err != nil {
+		return requestKeys{}, false
+	}
+	if operation := operationOf(doc, p.OperationName); operation == nil || operation.Operation != ast.OperationTypeQuery {
+		return requestKeys{}, false
+	}
+	// the order of the fields matters to results whose objects are ordered
+	signature, err := graphql.NormalizeWithOptions(doc, p.OperationName, graphql.NormalizeOptions{
+		KeepLiterals:       true,
+		KeepSelectionOrder: p.PreserveFieldOrder,
+	})
+	if err != nil {
+		return requestKeys{}, false
+	}
+	// maps are marshaled with sorted keys
+	variables, err := json.Marshal(p.VariableValues)
+	if err != nil {
+		return requestKeys{}, false
+	}
+
+	key := fmt.Sprintf("%v\x00%v\x00%s", p.PreserveFieldOrder, signature, variables)
+	keys := requestKeys{public: graphql.SignatureHash("public\x00" + key)}
+	if e.config.SessionKey != nil {
+		if session := e.config.SessionKey(p.Context); session != "" {
+			keys.private = graphql.SignatureHash("private\x00" + session + "\x00" + key)
+		}
+	}
+	return keys, true
+}
+
+// operationOf returns the operation of doc named operationName, or its only
+// operation if operationName is empty.
+func operationOf(doc *ast.Document, operationName string) *ast.OperationDefinition {
+	var operation *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		def, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" {
+			if operation != nil {
+				return nil
+			}
+			operation = def
+		} else if def.Name != nil && def.Name.Value == operationName {
+			return def
+		}
+	}
+	return operation
+}
+
+// withStatus returns a copy of result with status in its extensions.
+func withStatus(result *graphql.Result, status Status) *graphql.Result {
+	copied := *result
+	copied.Extensions = map[string]interface{}{Name: status}
+	for name, value := range result.Extensions {
+		if name != Name {
+			copied.Extensions[name] = value
+		}
+	}
+	return &copied
+}
+
+// withoutStatus returns a copy of result without status in its extensions.
+func withoutStatus(result *graphql.Result) *graphql.Result {
+	copied := *result
+	copied.Extensions = map[string]interface{}{}
+	for name, value := range result.Extensions {
+		if name != Name {
+			copied.Extensions[name] = value
+		}
+	}
+	return &copied
+}
+
+type stateKey struct{}
+
+// state holds the tags of the result of a request being executed.
+type state struct {
+	extension *Extension
+	mu        sync.Mutex
+	tags      map[string]bool
+}
+
+func stateOf(ctx context.Context) (*state, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	state, ok := ctx.Value(stateKey{}).(*state)
+	return state, ok
+}
+
+func (s *state) addTags(tags ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tag := range tags {
+		s.tags[tag] = true
+	}
+}
+
+func (s *state) tagList() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tags := make([]string, 0, len(s.tags))
+	for tag := range s.tags {
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// AddTags adds tags to the result of the request resolved by p, e.g.
+// "Book:42" for the resolver of a book, for invalidating it with
+// Invalidate. The tags are only used by Extension.Do.
+func AddTags(p graphql.ResolveParams, tags ...string) {
+	if state, ok := stateOf(p.Context); ok {
+		state.addTags(tags...)
+	}
+}
+
+// Invalidate removes the cached results with any of tags, from the resolver
+// of a mutation executed by p. It does nothing if the schema has no
+// Extension.
+func Invalidate(p graphql.ResolveParams, tags ...string) {
+	if state, ok := stateOf(p.Context); ok {
+		state.extension.Invalidate(tags...)
+	}
+}
+
+// Init implements graphql.Extension.
+func (e *Extension) Init(ctx context.Context, p *graphql.Params) context.Context {
+	return ctx
+}
+
+// Name implements graphql.Extension.
+func (e *Extension) Name() string {
+	return Name
+}
+
+// ParseDidStart implements graphql.Extension.
+func (e *Extension) ParseDidStart(ctx context.Context) (context.Context, graphql.ParseFinishFunc) {
+	return ctx, func(err error) {}
+}
+
+// ValidationDidStart implements graphql.Extension.
+func (e *Extension) ValidationDidStart(ctx context.Context) (context.Context, graphql.ValidationFinishFunc) {
+	return ctx, func([]gqlerrors.FormattedError) {}
+}
+
+// ExecutionDidStart implements graphql.Extension. The requests not executed
+// with Do get a state too, for the mutations to invalidate results.
+func (e *Extension) ExecutionDidStart(ctx context.Context) (context.Context, graphql.ExecutionFinishFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := stateOf(ctx); !ok {
+		ctx = context.WithValue(ctx, stateKey{}, &state{extension: e, tags: map[string]bool{}})
+	}
+	return ctx, func(*graphql.Result) {}
+}
+
+// ResolveFieldDidStart implements graphql.Extension, tagging the result with
+// the names of the object, interface and union types returned by the fields.
+func (e *Extension) ResolveFieldDidStart(ctx context.Context, info *graphql.ResolveInfo) (context.Context, graphql.ResolveFieldFinishFunc) {
+	if state, ok := stateOf(ctx); ok && info != nil {
+		switch named := graphql.GetNamed(info.ReturnType).(type) {
+		case *graphql.Object, *graphql.Interface, *graphql.Union:
+			state.addTags(named.(graphql.Composite).Name())
+		}
+	}
+	return ctx, func(interface{}, error) {}
+}
+
+// HasResult implements graphql.Extension.
+func (e *Extension) HasResult() bool {
+	return true
+}
+
+// GetResult implements graphql.Extension, returning the Status of an executed
+// request, which was not read from the cache.
+func (e *Extension) GetResult(ctx context.Context) interface{} {
+	return Status{Hit: false}
+}
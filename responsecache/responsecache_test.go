@@ -0,0 +1,314 @@
+package responsecache_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/cachecontrol"
+	"github.com/graphql-go/graphql/responsecache"
+)
+
+type sessionKey struct{}
+
+// testServer is a schema caching its results, counting the executions of
+// its resolvers.
+type testServer struct {
+	schema    graphql.Schema
+	cache     *responsecache.MemoryCache
+	extension *responsecache.Extension
+	calls     int32
+}
+
+func newTestServer(t *testing.T) *testServer {
+	server := &testServer{cache: responsecache.NewMemoryCache(0)}
+	titles := map[string]string{"1": "Dune", "2": "Emma"}
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.String},
+			"title": &graphql.Field{Type: graphql.String},
+		},
+	})
+	book := func(p graphql.ResolveParams) (interface{}, error) {
+		atomic.AddInt32(&server.calls, 1)
+		id, _ := p.Args["id"].(string)
+		responsecache.AddTags(p, "Book:"+id)
+		return map[string]interface{}{"id": id, "title": titles[id]}, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"book": &graphql.Field{
+					Type:    bookType,
+					Args:    graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.String}},
+					Resolve: book,
+				},
+				"myBook": &graphql.Field{
+					Type: bookType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						cachecontrol.SetHint(p, cachecontrol.Hint{Scope: cachecontrol.Private})
+						p.Args = map[string]interface{}{"id": p.Context.Value(sessionKey{})}
+						return book(p)
+					},
+				},
+				"fail": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						atomic.AddInt32(&server.calls, 1)
+						return nil, errors.New("failed")
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"renameBook": &graphql.Field{
+					Type: bookType,
+					Args: graphql.FieldConfigArgument{
+						"id":    &graphql.ArgumentConfig{Type: graphql.String},
+						"title": &graphql.ArgumentConfig{Type: graphql.String},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						id, _ := p.Args["id"].(string)
+						titles[id], _ = p.Args["title"].(string)
+						responsecache.Invalidate(p, "Book:"+id)
+						return book(p)
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server.extension = responsecache.New(server.cache, responsecache.Config{
+		SessionKey: func(ctx context.Context) string {
+			session, _ := ctx.Value(sessionKey{}).(string)
+			return session
+		},
+	})
+	schema.AddExtensions(
+		cachecontrol.NewExtension(cachecontrol.Config{DefaultMaxAge: 60}),
+		server.extension,
+	)
+	server.schema = schema
+	return server
+}
+
+// do executes query in session, returning its result and whether it was a
+// cache hit.
+func (s *testServer) do(t *testing.T, session, query string, variables map[string]interface{}) (*graphql.Result, bool) {
+	result := s.extension.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  query,
+		VariableValues: variables,
+		Context:        context.WithValue(context.Background(), sessionKey{}, session),
+	})
+	status, ok := responsecache.StatusOf(result)
+	if !ok {
+		t.Fatalf("%v: expected a status in the extensions, got %v", query, result.Extensions)
+	}
+	return result, status.Hit
+}
+
+func TestExtension_CachesQueryResults(t *testing.T) {
+	server := newTestServer(t)
+	first, hit := server.do(t, "", `{ book(id: "1") { title } }`, nil)
+	if hit || len(first.Errors) != 0 {
+		t.Fatalf("expected a miss, got %v", first)
+	}
+	second, hit := server.do(t, "", `query { book(id:"1") {
+		# same query
+		title
+	} }`, nil)
+	if !hit || server.calls != 1 {
+		t.Fatalf("expected a hit, got %v after %v calls", second, server.calls)
+	}
+	if !reflect.DeepEqual(first.Data, second.Data) {
+		t.Fatalf("expected %v, got %v", first.Data, second.Data)
+	}
+	if policy, ok := cachecontrol.PolicyOf(second); !ok || policy.MaxAge != 60 {
+		t.Fatalf("expected the cached policy, got %v", second.Extensions)
+	}
+
+	// the literal values and the variables are parts of the key
+	if _, hit := server.do(t, "", `{ book(id: "2") { title } }`, nil); hit {
+		t.Fatal("expected a miss for another literal value")
+	}
+	query := `query Book($id: String) { book(id: $id) { title } }`
+	if _, hit := server.do(t, "", query, map[string]interface{}{"id": "1"}); hit {
+		t.Fatal("expected a miss for another query")
+	}
+	if _, hit := server.do(t, "", query, map[string]interface{}{"id": "2"}); hit {
+		t.Fatal("expected a miss for other variables")
+	}
+	if _, hit := server.do(t, "", query, map[string]interface{}{"id": "1"}); !hit {
+		t.Fatal("expected a hit for the same variables")
+	}
+}
+
+func TestExtension_KeysOrderedResultsByFieldOrder(t *testing.T) {
+	server := newTestServer(t)
+	do := func(query string, preserveFieldOrder bool) (*graphql.Result, bool) {
+		result := server.extension.Do(graphql.Params{
+			Schema:             server.schema,
+			RequestString:      query,
+			PreserveFieldOrder: preserveFieldOrder,
+		})
+		status, _ := responsecache.StatusOf(result)
+		return result, status.Hit
+	}
+	do(`{ book(id: "1") { title id } }`, false)
+	result, hit := do(`{ book(id: "1") { title id } }`, true)
+	if hit {
+		t.Fatal("expected a miss for ordered fields")
+	}
+	if _, ok := result.Data.(*graphql.OrderedMap); !ok {
+		t.Fatalf("expected ordered data, got %T", result.Data)
+	}
+	if _, hit := do(`{ book(id: "1") { id title } }`, true); hit {
+		t.Fatal("expected a miss for another order of the fields")
+	}
+	result, hit = do(`{ book(id: "1") { title id } }`, true)
+	if !hit {
+		t.Fatal("expected a hit for the same order of the fields")
+	}
+	if _, ok := result.Data.(*graphql.OrderedMap); !ok {
+		t.Fatalf("expected ordered data, got %T", result.Data)
+	}
+	if _, hit := do(`{ book(id: "1") { id title } }`, false); !hit {
+		t.Fatal("expected a hit for unordered fields in another order")
+	}
+}
+
+func TestExtension_CachesPrivateResultsPerSession(t *testing.T) {
+	server := newTestServer(t)
+	query := `{ myBook { title } }`
+	if _, hit := server.do(t, "1", query, nil); hit {
+		t.Fatal("expected a miss")
+	}
+	if result, hit := server.do(t, "1", query, nil); !hit {
+		t.Fatalf("expected a hit in the same session, got %v", result)
+	}
+	result, hit := server.do(t, "2", query, nil)
+	if hit {
+		t.Fatal("expected a miss in another session")
+	}
+	if expected := map[string]interface{}{"myBook": map[string]interface{}{"title": "Emma"}}; !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+	for i := 0; i < 2; i++ {
+		if _, hit := server.do(t, "", query, nil); hit {
+			t.Fatal("expected no caching without session")
+		}
+	}
+}
+
+func TestExtension_DoesNotCacheErrorsAndMutations(t *testing.T) {
+	server := newTestServer(t)
+	for i := 0; i < 2; i++ {
+		if result, hit := server.do(t, "", `{ fail }`, nil); hit || len(result.Errors) == 0 {
+			t.Fatalf("expected an uncached error, got %v", result)
+		}
+	}
+	mutation := `mutation { renameBook(id: "3", title: "Ulysses") { title } }`
+	for i := 0; i < 2; i++ {
+		if result, hit := server.do(t, "", mutation, nil); hit || len(result.Errors) != 0 {
+			t.Fatalf("expected an uncached mutation, got %v", result)
+		}
+	}
+	if server.cache.Len() != 0 {
+		t.Fatalf("expected an empty cache, got %v results", server.cache.Len())
+	}
+}
+
+func TestExtension_BypassesTheCacheWithValidationRules(t *testing.T) {
+	server := newTestServer(t)
+	if _, hit := server.do(t, "", `{ book(id: "1") { title } }`, nil); hit {
+		t.Fatal("expected a miss")
+	}
+	for i := 0; i < 2; i++ {
+		result := server.extension.Do(graphql.Params{
+			Schema:          server.schema,
+			RequestString:   `{ book(id: "1") { title } }`,
+			ValidationRules: append([]graphql.ValidationRuleFn{graphql.DisableIntrospectionRule}, graphql.SpecifiedRules...),
+			Context:         context.Background(),
+		})
+		if status, _ := responsecache.StatusOf(result); status.Hit || len(result.Errors) != 0 {
+			t.Fatalf("expected an uncached result, got %v", result)
+		}
+	}
+	if server.calls != 3 {
+		t.Fatalf("expected 3 calls, got %v", server.calls)
+	}
+}
+
+func TestExtension_InvalidatesTaggedResults(t *testing.T) {
+	server := newTestServer(t)
+	server.do(t, "", `{ book(id: "1") { title } }`, nil)
+	server.do(t, "", `{ book(id: "2") { title } }`, nil)
+
+	result, _ := server.do(t, "", `mutation { renameBook(id: "1", title: "Dune Messiah") { title } }`, nil)
+	if len(result.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	result, hit := server.do(t, "", `{ book(id: "1") { title } }`, nil)
+	if hit {
+		t.Fatal("expected the result of the renamed book to be invalidated")
+	}
+	if expected := map[string]interface{}{"book": map[string]interface{}{"title": "Dune Messiah"}}; !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("expected %v, got %v", expected, result.Data)
+	}
+	if _, hit := server.do(t, "", `{ book(id: "2") { title } }`, nil); !hit {
+		t.Fatal("expected the result of the other book to be kept")
+	}
+
+	// the results are tagged with the types of their fields
+	server.extension.Invalidate("Book")
+	if server.cache.Len() != 0 {
+		t.Fatalf("expected an empty cache, got %v results", server.cache.Len())
+	}
+}
+
+func TestMemoryCache_ExpiresAndEvictsResults(t *testing.T) {
+	cache := responsecache.NewMemoryCache(2)
+	a, b, c := &graphql.Result{Data: "a"}, &graphql.Result{Data: "b"}, &graphql.Result{Data: "c"}
+	cache.Set("a", a, time.Minute, []string{"x"})
+	cache.Set("b", b, time.Minute, []string{"x", "y"})
+	if result, ok := cache.Get("a"); !ok || result != a {
+		t.Fatalf("expected a, got %v", result)
+	}
+	// b is the least recently used
+	cache.Set("c", c, time.Minute, nil)
+	if _, ok := cache.Get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be kept")
+	}
+	cache.Invalidate("y")
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a to be kept")
+	}
+	cache.Invalidate("x")
+	if _, ok := cache.Get("a"); ok || cache.Len() != 1 {
+		t.Fatalf("expected a to be invalidated, got %v results", cache.Len())
+	}
+
+	cache.Set("d", a, 10*time.Millisecond, nil)
+	cache.Set("e", a, 0, nil)
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := cache.Get("d"); ok {
+		t.Fatal("expected d to expire")
+	}
+	if _, ok := cache.Get("e"); ok {
+		t.Fatal("expected e not to be stored")
+	}
+}
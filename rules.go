@@ -16,8 +16,7 @@ import (
 
 // SpecifiedRules set includes all validation rules defined by the GraphQL spec.
 var SpecifiedRules = []ValidationRuleFn{
-	ArgumentsOfCorrectTypeRule,
-	DefaultValuesOfCorrectTypeRule,
+	ExecutableDefinitionsRule,
 	FieldsOnCorrectTypeRule,
 	FragmentsOnCompositeTypesRule,
 	KnownArgumentNamesRule,
@@ -33,15 +32,36 @@ var SpecifiedRules = []ValidationRuleFn{
 	PossibleFragmentSpreadsRule,
 	ProvidedNonNullArgumentsRule,
 	ScalarLeafsRule,
+	SingleFieldSubscriptionsRule,
 	UniqueArgumentNamesRule,
+	UniqueDirectivesPerLocationRule,
 	UniqueFragmentNamesRule,
 	UniqueInputFieldNamesRule,
 	UniqueOperationNamesRule,
 	UniqueVariableNamesRule,
+	ValuesOfCorrectTypeRule,
 	VariablesAreInputTypesRule,
 	VariablesInAllowedPositionRule,
 }
 
+// SpecifiedSDLRules set includes the validation rules for documents of type
+// definitions (SDL), see ValidateSDL.
+var SpecifiedSDLRules = []ValidationRuleFn{
+	KnownDirectivesRule,
+	KnownTypeNamesRule,
+	LoneSchemaDefinitionRule,
+	PossibleTypeExtensionsRule,
+	UniqueArgumentDefinitionNamesRule,
+	UniqueArgumentNamesRule,
+	UniqueDirectiveNamesRule,
+	UniqueDirectivesPerLocationRule,
+	UniqueEnumValueNamesRule,
+	UniqueFieldDefinitionNamesRule,
+	UniqueInputFieldNamesRule,
+	UniqueOperationTypesRule,
+	UniqueTypeNamesRule,
+}
+
 type ValidationRuleInstance struct {
 	VisitorOpts *visitor.VisitorOptions
 }
@@ -67,6 +87,8 @@ func reportError(context *ValidationContext, message string, nodes []ast.Node) (
 //
 // A GraphQL document is only valid if all field argument literal values are
 // of the type expected by their position.
+//
+// It is replaced by ValuesOfCorrectTypeRule in SpecifiedRules.
 func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance {
 	visitorOpts := &visitor.VisitorOptions{
 		KindFuncMap: map[string]visitor.NamedVisitFuncs{
@@ -107,6 +129,8 @@ func ArgumentsOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInsta
 //
 // A GraphQL document is only valid if all variable default values are of the
 // type expected by their definition.
+//
+// It is replaced by ValuesOfCorrectTypeRule in SpecifiedRules.
 func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance {
 	visitorOpts := &visitor.VisitorOptions{
 		KindFuncMap: map[string]visitor.NamedVisitFuncs{
@@ -163,6 +187,94 @@ func DefaultValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleI
 		VisitorOpts: visitorOpts,
 	}
 }
+
+// DisableIntrospectionRule Disable introspection
+//
+// A document is only valid for a request which may not introspect the schema
+// if it does not select the __schema and __type fields. It is not part of
+// SpecifiedRules, but can be added to the rules of such requests, see
+// Params.ValidationRules.
+func DisableIntrospectionRule(context *ValidationContext) *ValidationRuleInstance {
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Field); ok {
+						switch context.FieldDef() {
+						case SchemaMetaFieldDef, TypeMetaFieldDef:
+							reportError(
+								context,
+								fmt.Sprintf(`GraphQL introspection is disabled, but the query contained the field "%v".`, node.Name.Value),
+								[]ast.Node{node},
+							)
+						}
+					}
+					return visitor.ActionNoChange, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// ExecutableDefinitionsRule Executable definitions
+//
+// A GraphQL document is only valid for execution if all definitions are either
+// operation or fragment definitions.
+func ExecutableDefinitionsRule(context *ValidationContext) *ValidationRuleInstance {
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Document: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Document); ok {
+						for _, definition := range node.Definitions {
+							switch definition := definition.(type) {
+							case *ast.OperationDefinition, *ast.FragmentDefinition, *ast.Invalid:
+							case *ast.SchemaDefinition, *ast.SchemaExtensionDefinition:
+								reportError(
+									context,
+									`The schema definition is not executable.`,
+									[]ast.Node{definition},
+								)
+							default:
+								reportError(
+									context,
+									fmt.Sprintf(`The "%v" definition is not executable.`, definitionName(definition)),
+									[]ast.Node{definition},
+								)
+							}
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// definitionName returns the name of a type system definition.
+func definitionName(definition ast.Node) string {
+	var name *ast.Name
+	if extended := extendedTypeDefinition(definition); extended != nil {
+		definition = extended
+	}
+	switch definition := definition.(type) {
+	case *ast.DirectiveDefinition:
+		name = definition.Name
+	case interface{ GetName() *ast.Name }:
+		name = definition.GetName()
+	}
+	if name == nil {
+		return ""
+	}
+	return name.Value
+}
+
 func quoteStrings(slice []string) []string {
 	quoted := []string{}
 	for _, s := range slice {
@@ -229,12 +341,12 @@ func FieldsOnCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance
 								nodeName = node.Name.Value
 							}
 							// First determine if there are any suggested types to condition on.
-							suggestedTypeNames := getSuggestedTypeNames(context.Schema(), ttype, nodeName)
+							suggestedTypeNames := getSuggestedTypeNames(context, ttype, nodeName)
 
 							// If there are no suggested types, then perhaps this was a typo?
 							suggestedFieldNames := []string{}
 							if len(suggestedTypeNames) == 0 {
-								suggestedFieldNames = getSuggestedFieldNames(context.Schema(), ttype, nodeName)
+								suggestedFieldNames = getSuggestedFieldNames(context, ttype, nodeName)
 							}
 							reportError(
 								context,
@@ -256,8 +368,8 @@ func FieldsOnCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance
 // getSuggestedTypeNames Go through all of the implementations of type, as well as the interfaces
 // that they implement. If any of those types include the provided field,
 // suggest them, sorted by how often the type is referenced,  starting
-// with Interfaces.
-func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []string {
+// with Interfaces. Fields hidden from the request are left out.
+func getSuggestedTypeNames(context *ValidationContext, ttype Output, fieldName string) []string {
 	var (
 		suggestedObjectTypes = []string{}
 		suggestedInterfaces  = []*suggestedInterface{}
@@ -266,10 +378,11 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 		// stores a maps of object name => true to remove duplicates from results
 		suggestedObjectMap = map[string]bool{}
 	)
+	schema := context.Schema()
 	possibleTypes := schema.PossibleTypes(ttype)
 
 	for _, possibleType := range possibleTypes {
-		if field, ok := possibleType.Fields()[fieldName]; !ok || field == nil {
+		if field, ok := possibleType.Fields()[fieldName]; !ok || field == nil || !schema.isFieldVisible(context.Context(), possibleType, field) {
 			continue
 		}
 		// This object type defines this field.
@@ -277,7 +390,7 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 		suggestedObjectMap[possibleType.Name()] = true
 
 		for _, possibleInterface := range possibleType.Interfaces() {
-			if field, ok := possibleInterface.Fields()[fieldName]; !ok || field == nil {
+			if field, ok := possibleInterface.Fields()[fieldName]; !ok || field == nil || !schema.isFieldVisible(context.Context(), possibleInterface, field) {
 				continue
 			}
 
@@ -322,8 +435,8 @@ func getSuggestedTypeNames(schema *Schema, ttype Output, fieldName string) []str
 }
 
 // getSuggestedFieldNames For the field name provided, determine if there are any similar field names
-// that may be the result of a typo.
-func getSuggestedFieldNames(schema *Schema, ttype Output, fieldName string) []string {
+// that may be the result of a typo, among the ones visible to the request.
+func getSuggestedFieldNames(context *ValidationContext, ttype Output, fieldName string) []string {
 
 	fields := FieldDefinitionMap{}
 	switch ttype := ttype.(type) {
@@ -336,7 +449,7 @@ func getSuggestedFieldNames(schema *Schema, ttype Output, fieldName string) []st
 	}
 
 	possibleFieldNames := []string{}
-	for possibleFieldName := range fields {
+	for possibleFieldName := range context.Schema().visibleFields(context.Context(), ttype, fields) {
 		possibleFieldNames = append(possibleFieldNames, possibleFieldName)
 	}
 	return suggestionList(fieldName, possibleFieldNames)
@@ -528,6 +641,26 @@ func MisplaceDirectiveMessage(directiveName string, location string) string {
 // A GraphQL document is only valid if all `@directives` are known by the
 // schema and legally positioned.
 func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
+	directives := SpecifiedDirectives
+	if context.Schema() != nil {
+		directives = context.Schema().Directives()
+	}
+	locationsByName := map[string][]string{}
+	for _, def := range directives {
+		locationsByName[def.Name] = def.Locations
+	}
+	if context.sdl {
+		for _, def := range context.Document().Definitions {
+			if def, ok := def.(*ast.DirectiveDefinition); ok && def.Name != nil {
+				locations := []string{}
+				for _, location := range def.Locations {
+					locations = append(locations, location.Value)
+				}
+				locationsByName[def.Name.Value] = locations
+			}
+		}
+	}
+
 	visitorOpts := &visitor.VisitorOptions{
 		KindFuncMap: map[string]visitor.NamedVisitFuncs{
 			kinds.Directive: {
@@ -541,13 +674,8 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 							nodeName = node.Name.Value
 						}
 
-						var directiveDef *Directive
-						for _, def := range context.Schema().Directives() {
-							if def.Name == nodeName {
-								directiveDef = def
-							}
-						}
-						if directiveDef == nil {
+						locations, ok := locationsByName[nodeName]
+						if !ok {
 							return reportError(
 								context,
 								fmt.Sprintf(`Unknown directive "%v".`, nodeName),
@@ -558,7 +686,7 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 						candidateLocation := getDirectiveLocationForASTPath(p.Ancestors)
 
 						directiveHasLocation := false
-						for _, loc := range directiveDef.Locations {
+						for _, loc := range locations {
 							if loc == candidateLocation {
 								directiveHasLocation = true
 								break
@@ -714,29 +842,31 @@ func unknownTypeMessage(typeName string, suggestedTypes []string) string {
 //
 // A GraphQL document is only valid if referenced types (specifically
 // variable definitions and fragment conditions) are defined by the type schema.
+//
+// In SDL documents, types are also known if they are defined by the document
+// or specified scalars, and references within type definitions are checked.
 func KnownTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	skipTypeDefinition := func(p visitor.VisitFuncParams) (string, interface{}) {
+		if context.sdl {
+			return visitor.ActionNoChange, nil
+		}
+		return visitor.ActionSkip, nil
+	}
+	definedTypes := map[string]ast.Node{}
+	if context.sdl {
+		for _, definition := range context.Document().Definitions {
+			if name := sdlTypeDefinitionName(definition); name != "" {
+				definedTypes[name] = definition
+			}
+		}
+	}
+
 	visitorOpts := &visitor.VisitorOptions{
 		KindFuncMap: map[string]visitor.NamedVisitFuncs{
-			kinds.ObjectDefinition: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					return visitor.ActionSkip, nil
-				},
-			},
-			kinds.InterfaceDefinition: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					return visitor.ActionSkip, nil
-				},
-			},
-			kinds.UnionDefinition: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					return visitor.ActionSkip, nil
-				},
-			},
-			kinds.InputObjectDefinition: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					return visitor.ActionSkip, nil
-				},
-			},
+			kinds.ObjectDefinition:      {Kind: skipTypeDefinition},
+			kinds.InterfaceDefinition:   {Kind: skipTypeDefinition},
+			kinds.UnionDefinition:       {Kind: skipTypeDefinition},
+			kinds.InputObjectDefinition: {Kind: skipTypeDefinition},
 			kinds.Named: {
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
 					if node, ok := p.Node.(*ast.Named); ok {
@@ -745,6 +875,19 @@ func KnownTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
 						if typeName != nil {
 							typeNameValue = typeName.Value
 						}
+						if context.sdl {
+							if _, ok := definedTypes[typeNameValue]; ok || specifiedScalarTypes[typeNameValue] != nil {
+								return visitor.ActionNoChange, nil
+							}
+							if context.Schema() == nil || context.Schema().Type(typeNameValue) == nil {
+								reportError(
+									context,
+									unknownTypeMessage(typeNameValue, suggestionList(typeNameValue, knownSDLTypeNames(context, definedTypes))),
+									[]ast.Node{node},
+								)
+							}
+							return visitor.ActionNoChange, nil
+						}
 						ttype := context.Schema().Type(typeNameValue)
 						if ttype == nil {
 							suggestedTypes := []string{}
@@ -810,6 +953,49 @@ func LoneAnonymousOperationRule(context *ValidationContext) *ValidationRuleInsta
 	}
 }
 
+// LoneSchemaDefinitionRule Lone schema definition
+//
+// A GraphQL document is only valid if it contains only one schema definition,
+// and none when it extends an existing schema.
+func LoneSchemaDefinitionRule(context *ValidationContext) *ValidationRuleInstance {
+	alreadyDefined := context.Schema() != nil
+	schemaDefinitionsCount := 0
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.SchemaDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.SchemaDefinition); ok {
+						if alreadyDefined {
+							reportError(
+								context,
+								`Cannot define a new schema within a schema extension.`,
+								[]ast.Node{node},
+							)
+						} else if schemaDefinitionsCount > 0 {
+							reportError(
+								context,
+								`Must provide only one schema definition.`,
+								[]ast.Node{node},
+							)
+						}
+						schemaDefinitionsCount++
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.SchemaExtensionDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
 func CycleErrorMessage(fragName string, spreadNames []string) string {
 	via := ""
 	if len(spreadNames) > 0 {
@@ -1241,6 +1427,139 @@ func PossibleFragmentSpreadsRule(context *ValidationContext) *ValidationRuleInst
 	}
 }
 
+// PossibleTypeExtensionsRule Possible type extensions
+//
+// A type extension is only valid if the type is defined, by the document or
+// the schema being extended, and has the same kind.
+func PossibleTypeExtensionsRule(context *ValidationContext) *ValidationRuleInstance {
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Document: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Document)
+					if !ok {
+						return visitor.ActionSkip, nil
+					}
+					definedTypes := map[string]ast.Node{}
+					for _, definition := range node.Definitions {
+						if name := sdlTypeDefinitionName(definition); name != "" {
+							definedTypes[name] = definition
+						}
+					}
+					for _, extension := range node.Definitions {
+						extended := extendedTypeDefinition(extension)
+						typeName := typeDefinitionNameNode(extended)
+						if typeName == nil {
+							continue
+						}
+						if defNode, ok := definedTypes[typeName.Value]; ok {
+							if defNode.GetKind() != extended.GetKind() {
+								reportError(
+									context,
+									extendingWrongKindMessage(typeName.Value, extended),
+									[]ast.Node{defNode, extension},
+								)
+							}
+							continue
+						}
+						var existingType Type
+						if schema := context.Schema(); schema != nil {
+							existingType = schema.Type(typeName.Value)
+						}
+						if existingType == nil {
+							reportError(
+								context,
+								unknownExtendedTypeMessage(typeName.Value, suggestionList(typeName.Value, knownSDLTypeNames(context, definedTypes))),
+								[]ast.Node{typeName},
+							)
+						} else if definitionKind(existingType) != extended.GetKind() {
+							reportError(
+								context,
+								extendingWrongKindMessage(typeName.Value, extended),
+								[]ast.Node{extension},
+							)
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+func extendingWrongKindMessage(typeName string, extended ast.TypeDefinition) string {
+	kind := map[string]string{
+		kinds.ScalarDefinition:      "scalar",
+		kinds.ObjectDefinition:      "object",
+		kinds.InterfaceDefinition:   "interface",
+		kinds.UnionDefinition:       "union",
+		kinds.EnumDefinition:        "enum",
+		kinds.InputObjectDefinition: "input object",
+	}[extended.GetKind()]
+	return fmt.Sprintf(`Cannot extend non-%v type "%v".`, kind, typeName)
+}
+
+// definitionKind returns the kind of the definition node describing ttype.
+func definitionKind(ttype Type) string {
+	switch ttype.(type) {
+	case *Scalar:
+		return kinds.ScalarDefinition
+	case *Object:
+		return kinds.ObjectDefinition
+	case *Interface:
+		return kinds.InterfaceDefinition
+	case *Union:
+		return kinds.UnionDefinition
+	case *Enum:
+		return kinds.EnumDefinition
+	case *InputObject:
+		return kinds.InputObjectDefinition
+	}
+	return ""
+}
+
+func unknownExtendedTypeMessage(typeName string, suggestedTypes []string) string {
+	message := fmt.Sprintf(`Cannot extend type "%v" because it is not defined.`, typeName)
+	if len(suggestedTypes) > 0 {
+		message = fmt.Sprintf(`%v Did you mean %v?`, message, quotedOrList(suggestedTypes))
+	}
+	return message
+}
+
+// sdlTypeDefinitionName returns the name of definition if it defines a type.
+func sdlTypeDefinitionName(definition ast.Node) string {
+	if definition, ok := definition.(ast.TypeDefinition); ok {
+		return typeDefinitionName(definition)
+	}
+	return ""
+}
+
+// knownSDLTypeNames returns the sorted names of the types of the schema being
+// extended, the specified scalars and the types defined by the document.
+func knownSDLTypeNames(context *ValidationContext, definedTypes map[string]ast.Node) []string {
+	names := map[string]bool{}
+	if schema := context.Schema(); schema != nil {
+		for name := range schema.TypeMap() {
+			names[name] = true
+		}
+	}
+	for name := range specifiedScalarTypes {
+		names[name] = true
+	}
+	for name := range definedTypes {
+		names[name] = true
+	}
+	sorted := []string{}
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
 // ProvidedNonNullArgumentsRule Provided required arguments
 //
 // A field or directive is only valid if all required (non-null) field arguments
@@ -1381,42 +1700,28 @@ func ScalarLeafsRule(context *ValidationContext) *ValidationRuleInstance {
 	}
 }
 
-// UniqueArgumentNamesRule Unique argument names
+// SingleFieldSubscriptionsRule Subscriptions must only include one field
 //
-// A GraphQL field or directive is only valid if all supplied arguments are
-// uniquely named.
-func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance {
-	knownArgNames := map[string]*ast.Name{}
-
+// A GraphQL subscription is valid only if it contains a single root field.
+func SingleFieldSubscriptionsRule(context *ValidationContext) *ValidationRuleInstance {
 	visitorOpts := &visitor.VisitorOptions{
 		KindFuncMap: map[string]visitor.NamedVisitFuncs{
-			kinds.Field: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					knownArgNames = map[string]*ast.Name{}
-					return visitor.ActionNoChange, nil
-				},
-			},
-			kinds.Directive: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					knownArgNames = map[string]*ast.Name{}
-					return visitor.ActionNoChange, nil
-				},
-			},
-			kinds.Argument: {
+			kinds.OperationDefinition: {
 				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					if node, ok := p.Node.(*ast.Argument); ok {
-						argName := ""
-						if node.Name != nil {
-							argName = node.Name.Value
-						}
-						if nameAST, ok := knownArgNames[argName]; ok {
-							reportError(
-								context,
-								fmt.Sprintf(`There can be only one argument named "%v".`, argName),
-								[]ast.Node{nameAST, node.Name},
-							)
-						} else {
-							knownArgNames[argName] = node.Name
+					if node, ok := p.Node.(*ast.OperationDefinition); ok && node.Operation == ast.OperationTypeSubscription {
+						responseNames := []string{}
+						fields := map[string][]ast.Node{}
+						collectRootFields(context, node.SelectionSet, map[string]bool{}, &responseNames, fields)
+						if len(responseNames) > 1 {
+							message := `Anonymous Subscription must select only one top level field.`
+							if node.Name != nil {
+								message = fmt.Sprintf(`Subscription "%v" must select only one top level field.`, node.Name.Value)
+							}
+							nodes := []ast.Node{}
+							for _, responseName := range responseNames[1:] {
+								nodes = append(nodes, fields[responseName]...)
+							}
+							reportError(context, message, nodes)
 						}
 					}
 					return visitor.ActionSkip, nil
@@ -1429,25 +1734,412 @@ func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance
 	}
 }
 
-// UniqueFragmentNamesRule Unique fragment names
-//
-// A GraphQL document is only valid if all defined fragments have unique names.
-func UniqueFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance {
-	knownFragmentNames := map[string]*ast.Name{}
+// collectRootFields collects the fields of selectionSet by response name, in
+// order, through its fragment spreads and inline fragments, as collectFields
+// does.
+func collectRootFields(context *ValidationContext, selectionSet *ast.SelectionSet, visitedFragments map[string]bool, responseNames *[]string, fields map[string][]ast.Node) {
+	if selectionSet == nil {
+		return
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			responseName := fieldASTName(selection)
+			if selection.Alias != nil {
+				responseName = selection.Alias.Value
+			}
+			if _, ok := fields[responseName]; !ok {
+				*responseNames = append(*responseNames, responseName)
+			}
+			fields[responseName] = append(fields[responseName], selection)
+		case *ast.InlineFragment:
+			collectRootFields(context, selection.SelectionSet, visitedFragments, responseNames, fields)
+		case *ast.FragmentSpread:
+			if selection.Name == nil || visitedFragments[selection.Name.Value] {
+				continue
+			}
+			visitedFragments[selection.Name.Value] = true
+			if fragment := context.Fragment(selection.Name.Value); fragment != nil {
+				collectRootFields(context, fragment.SelectionSet, visitedFragments, responseNames, fields)
+			}
+		}
+	}
+}
 
-	visitorOpts := &visitor.VisitorOptions{
-		KindFuncMap: map[string]visitor.NamedVisitFuncs{
-			kinds.OperationDefinition: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					return visitor.ActionSkip, nil
-				},
-			},
-			kinds.FragmentDefinition: {
-				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
-					if node, ok := p.Node.(*ast.FragmentDefinition); ok && node != nil {
-						fragmentName := ""
-						if node.Name != nil {
-							fragmentName = node.Name.Value
+// UniqueArgumentDefinitionNamesRule Unique argument definition names
+//
+// A GraphQL object, interface or directive definition is only valid if all
+// its arguments are uniquely named.
+func UniqueArgumentDefinitionNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	checkArgUniqueness := func(parentName string, argDefs []*ast.InputValueDefinition) {
+		argNodes := map[string][]ast.Node{}
+		argNames := []string{}
+		for _, argDef := range argDefs {
+			if argDef.Name == nil {
+				continue
+			}
+			argName := argDef.Name.Value
+			if _, ok := argNodes[argName]; !ok {
+				argNames = append(argNames, argName)
+			}
+			argNodes[argName] = append(argNodes[argName], argDef.Name)
+		}
+		for _, argName := range argNames {
+			if len(argNodes[argName]) > 1 {
+				reportError(
+					context,
+					fmt.Sprintf(`Argument "%v(%v:)" can only be defined once.`, parentName, argName),
+					argNodes[argName],
+				)
+			}
+		}
+	}
+	checkFields := func(typeName *ast.Name, fieldDefs []*ast.FieldDefinition) {
+		if typeName == nil {
+			return
+		}
+		for _, fieldDef := range fieldDefs {
+			if fieldDef.Name != nil {
+				checkArgUniqueness(fmt.Sprintf("%v.%v", typeName.Value, fieldDef.Name.Value), fieldDef.Arguments)
+			}
+		}
+	}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.ObjectDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.ObjectDefinition); ok {
+						checkFields(node.Name, node.Fields)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.InterfaceDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.InterfaceDefinition); ok {
+						checkFields(node.Name, node.Fields)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.DirectiveDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.DirectiveDefinition); ok && node.Name != nil {
+						checkArgUniqueness("@"+node.Name.Value, node.Arguments)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// UniqueArgumentNamesRule Unique argument names
+//
+// A GraphQL field or directive is only valid if all supplied arguments are
+// uniquely named.
+func UniqueArgumentNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	knownArgNames := map[string]*ast.Name{}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Field: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					knownArgNames = map[string]*ast.Name{}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.Directive: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					knownArgNames = map[string]*ast.Name{}
+					return visitor.ActionNoChange, nil
+				},
+			},
+			kinds.Argument: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.Argument); ok {
+						argName := ""
+						if node.Name != nil {
+							argName = node.Name.Value
+						}
+						if nameAST, ok := knownArgNames[argName]; ok {
+							reportError(
+								context,
+								fmt.Sprintf(`There can be only one argument named "%v".`, argName),
+								[]ast.Node{nameAST, node.Name},
+							)
+						} else {
+							knownArgNames[argName] = node.Name
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// UniqueDirectiveNamesRule Unique directive names
+//
+// A GraphQL document is only valid if all defined directives have unique
+// names.
+func UniqueDirectiveNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	knownDirectiveNames := map[string]*ast.Name{}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.DirectiveDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.DirectiveDefinition)
+					if !ok || node.Name == nil {
+						return visitor.ActionSkip, nil
+					}
+					directiveName := node.Name.Value
+					if schema := context.Schema(); schema != nil && schema.Directive(directiveName) != nil {
+						reportError(
+							context,
+							fmt.Sprintf(`Directive "@%v" already exists in the schema. It cannot be redefined.`, directiveName),
+							[]ast.Node{node.Name},
+						)
+					} else if nameAST, ok := knownDirectiveNames[directiveName]; ok {
+						reportError(
+							context,
+							fmt.Sprintf(`There can be only one directive named "@%v".`, directiveName),
+							[]ast.Node{nameAST, node.Name},
+						)
+					} else {
+						knownDirectiveNames[directiveName] = node.Name
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// UniqueDirectivesPerLocationRule Unique directive names per location
+//
+// A GraphQL document is only valid if all directives at a given location are
+// uniquely named.
+func UniqueDirectivesPerLocationRule(context *ValidationContext) *ValidationRuleInstance {
+	knownDirectives := map[ast.Node]map[string]*ast.Directive{}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Directive: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.Directive)
+					if !ok || node.Name == nil || len(p.Ancestors) == 0 {
+						return visitor.ActionSkip, nil
+					}
+					location := p.Ancestors[len(p.Ancestors)-1]
+					if knownDirectives[location] == nil {
+						knownDirectives[location] = map[string]*ast.Directive{}
+					}
+					directiveName := node.Name.Value
+					if known, ok := knownDirectives[location][directiveName]; ok {
+						reportError(
+							context,
+							fmt.Sprintf(`The directive "@%v" can only be used once at this location.`, directiveName),
+							[]ast.Node{known, node},
+						)
+					} else {
+						knownDirectives[location][directiveName] = node
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// UniqueEnumValueNamesRule Unique enum value names
+//
+// A GraphQL enum type is only valid if all its values are uniquely named.
+func UniqueEnumValueNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	knownValueNames := map[string]map[string]*ast.Name{}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.EnumDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					node, ok := p.Node.(*ast.EnumDefinition)
+					if !ok || node.Name == nil {
+						return visitor.ActionSkip, nil
+					}
+					typeName := node.Name.Value
+					if knownValueNames[typeName] == nil {
+						knownValueNames[typeName] = map[string]*ast.Name{}
+					}
+					var existingType *Enum
+					if schema := context.Schema(); schema != nil {
+						existingType, _ = schema.Type(typeName).(*Enum)
+					}
+					for _, valueDef := range node.Values {
+						if valueDef.Name == nil {
+							continue
+						}
+						valueName := valueDef.Name.Value
+						if existingType != nil && existingType.getNameLookup()[valueName] != nil {
+							reportError(
+								context,
+								fmt.Sprintf(`Enum value "%v.%v" already exists in the schema. It cannot also be defined in this type extension.`, typeName, valueName),
+								[]ast.Node{valueDef.Name},
+							)
+						} else if nameAST, ok := knownValueNames[typeName][valueName]; ok {
+							reportError(
+								context,
+								fmt.Sprintf(`Enum value "%v.%v" can only be defined once.`, typeName, valueName),
+								[]ast.Node{nameAST, valueDef.Name},
+							)
+						} else {
+							knownValueNames[typeName][valueName] = valueDef.Name
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// UniqueFieldDefinitionNamesRule Unique field definition names
+//
+// A GraphQL complex type is only valid if all its fields are uniquely named,
+// across its definition and extensions.
+func UniqueFieldDefinitionNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	knownFieldNames := map[string]map[string]*ast.Name{}
+
+	checkFieldUniqueness := func(typeName *ast.Name, fieldNames []*ast.Name) {
+		if typeName == nil {
+			return
+		}
+		if knownFieldNames[typeName.Value] == nil {
+			knownFieldNames[typeName.Value] = map[string]*ast.Name{}
+		}
+		var existingType Type
+		if schema := context.Schema(); schema != nil {
+			existingType = schema.Type(typeName.Value)
+		}
+		for _, fieldName := range fieldNames {
+			if fieldName == nil {
+				continue
+			}
+			if hasFieldDefinition(existingType, fieldName.Value) {
+				reportError(
+					context,
+					fmt.Sprintf(`Field "%v.%v" already exists in the schema. It cannot also be defined in this type extension.`, typeName.Value, fieldName.Value),
+					[]ast.Node{fieldName},
+				)
+			} else if nameAST, ok := knownFieldNames[typeName.Value][fieldName.Value]; ok {
+				reportError(
+					context,
+					fmt.Sprintf(`Field "%v.%v" can only be defined once.`, typeName.Value, fieldName.Value),
+					[]ast.Node{nameAST, fieldName},
+				)
+			} else {
+				knownFieldNames[typeName.Value][fieldName.Value] = fieldName
+			}
+		}
+	}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.ObjectDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.ObjectDefinition); ok {
+						checkFieldUniqueness(node.Name, fieldDefinitionNames(node.Fields))
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.InterfaceDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.InterfaceDefinition); ok {
+						checkFieldUniqueness(node.Name, fieldDefinitionNames(node.Fields))
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.InputObjectDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.InputObjectDefinition); ok {
+						names := []*ast.Name{}
+						for _, fieldDef := range node.Fields {
+							names = append(names, fieldDef.Name)
+						}
+						checkFieldUniqueness(node.Name, names)
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+func fieldDefinitionNames(fieldDefs []*ast.FieldDefinition) []*ast.Name {
+	names := []*ast.Name{}
+	for _, fieldDef := range fieldDefs {
+		names = append(names, fieldDef.Name)
+	}
+	return names
+}
+
+// hasFieldDefinition reports whether ttype is a complex type with a field of
+// fieldName.
+func hasFieldDefinition(ttype Type, fieldName string) bool {
+	switch ttype := ttype.(type) {
+	case *Object:
+		_, ok := ttype.Fields()[fieldName]
+		return ok
+	case *Interface:
+		_, ok := ttype.Fields()[fieldName]
+		return ok
+	case *InputObject:
+		_, ok := ttype.Fields()[fieldName]
+		return ok
+	}
+	return false
+}
+
+// UniqueFragmentNamesRule Unique fragment names
+//
+// A GraphQL document is only valid if all defined fragments have unique names.
+func UniqueFragmentNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	knownFragmentNames := map[string]*ast.Name{}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.FragmentDefinition); ok && node != nil {
+						fragmentName := ""
+						if node.Name != nil {
+							fragmentName = node.Name.Value
 						}
 						if nameAST, ok := knownFragmentNames[fragmentName]; ok {
 							reportError(
@@ -1563,6 +2255,127 @@ func UniqueOperationNamesRule(context *ValidationContext) *ValidationRuleInstanc
 	}
 }
 
+// UniqueOperationTypesRule Unique operation types
+//
+// A GraphQL document is only valid if it has only one type per operation, and
+// none for the operations of the schema it extends.
+func UniqueOperationTypesRule(context *ValidationContext) *ValidationRuleInstance {
+	knownOperationTypes := map[string]*ast.OperationTypeDefinition{}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.OperationTypeDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if node, ok := p.Node.(*ast.OperationTypeDefinition); ok {
+						if context.sdl && rootTypeForOperation(context.Schema(), node.Operation) != nil {
+							reportError(
+								context,
+								fmt.Sprintf(`Type for %v already defined in the schema. It cannot be redefined.`, node.Operation),
+								[]ast.Node{node},
+							)
+						} else if known, ok := knownOperationTypes[node.Operation]; ok {
+							reportError(
+								context,
+								fmt.Sprintf(`There can be only one %v type in schema.`, node.Operation),
+								[]ast.Node{known, node},
+							)
+						} else {
+							knownOperationTypes[node.Operation] = node
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.OperationDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.FragmentDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// rootTypeForOperation returns the root type of schema for operation, if any.
+func rootTypeForOperation(schema *Schema, operation string) *Object {
+	if schema == nil {
+		return nil
+	}
+	switch operation {
+	case ast.OperationTypeQuery:
+		return schema.QueryType()
+	case ast.OperationTypeMutation:
+		return schema.MutationType()
+	case ast.OperationTypeSubscription:
+		return schema.SubscriptionType()
+	}
+	return nil
+}
+
+// UniqueTypeNamesRule Unique type names
+//
+// A GraphQL document is only valid if all defined types have unique names.
+func UniqueTypeNamesRule(context *ValidationContext) *ValidationRuleInstance {
+	knownTypeNames := map[string]*ast.Name{}
+
+	checkTypeName := func(p visitor.VisitFuncParams) (string, interface{}) {
+		definition, ok := p.Node.(ast.TypeDefinition)
+		if !ok {
+			return visitor.ActionSkip, nil
+		}
+		typeName := typeDefinitionNameNode(definition)
+		if typeName == nil {
+			return visitor.ActionSkip, nil
+		}
+		if schema := context.Schema(); schema != nil && schema.Type(typeName.Value) != nil {
+			reportError(
+				context,
+				fmt.Sprintf(`Type "%v" already exists in the schema. It cannot also be defined in this type definition.`, typeName.Value),
+				[]ast.Node{typeName},
+			)
+		} else if nameAST, ok := knownTypeNames[typeName.Value]; ok {
+			reportError(
+				context,
+				fmt.Sprintf(`There can be only one type named "%v".`, typeName.Value),
+				[]ast.Node{nameAST, typeName},
+			)
+		} else {
+			knownTypeNames[typeName.Value] = typeName
+		}
+		return visitor.ActionSkip, nil
+	}
+	skipExtension := func(p visitor.VisitFuncParams) (string, interface{}) {
+		return visitor.ActionSkip, nil
+	}
+
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.ScalarDefinition:               {Kind: checkTypeName},
+			kinds.ObjectDefinition:               {Kind: checkTypeName},
+			kinds.InterfaceDefinition:            {Kind: checkTypeName},
+			kinds.UnionDefinition:                {Kind: checkTypeName},
+			kinds.EnumDefinition:                 {Kind: checkTypeName},
+			kinds.InputObjectDefinition:          {Kind: checkTypeName},
+			kinds.ScalarExtensionDefinition:      {Kind: skipExtension},
+			kinds.TypeExtensionDefinition:        {Kind: skipExtension},
+			kinds.InterfaceExtensionDefinition:   {Kind: skipExtension},
+			kinds.UnionExtensionDefinition:       {Kind: skipExtension},
+			kinds.EnumExtensionDefinition:        {Kind: skipExtension},
+			kinds.InputObjectExtensionDefinition: {Kind: skipExtension},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
 // UniqueVariableNamesRule Unique variable names
 //
 // A GraphQL operation is only valid if all its variables are uniquely named.
@@ -1608,6 +2421,141 @@ func UniqueVariableNamesRule(context *ValidationContext) *ValidationRuleInstance
 	}
 }
 
+// ValuesOfCorrectTypeRule Value literals of correct type
+//
+// A GraphQL document is only valid if all value literals, in arguments and
+// variable default values, are of the type expected at their position.
+func ValuesOfCorrectTypeRule(context *ValidationContext) *ValidationRuleInstance {
+	visitorOpts := &visitor.VisitorOptions{
+		KindFuncMap: map[string]visitor.NamedVisitFuncs{
+			kinds.Argument: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if argAST, ok := p.Node.(*ast.Argument); ok {
+						if argDef := context.Argument(); argDef != nil {
+							validateValueLiteral(context, argDef.Type, argAST.Value)
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+			kinds.VariableDefinition: {
+				Kind: func(p visitor.VisitFuncParams) (string, interface{}) {
+					if varDefAST, ok := p.Node.(*ast.VariableDefinition); ok && varDefAST.DefaultValue != nil {
+						if ttype := context.InputType(); ttype != nil {
+							validateValueLiteral(context, ttype, varDefAST.DefaultValue)
+						}
+					}
+					return visitor.ActionSkip, nil
+				},
+			},
+		},
+	}
+	return &ValidationRuleInstance{
+		VisitorOpts: visitorOpts,
+	}
+}
+
+// validateValueLiteral reports the values of valueAST which are not of the
+// types expected at their position, locationType being the type expected for
+// valueAST itself.
+func validateValueLiteral(context *ValidationContext, locationType Input, valueAST ast.Value) {
+	// Variables are checked by VariablesInAllowedPositionRule.
+	if valueAST == nil || valueAST.GetKind() == kinds.Variable {
+		return
+	}
+	ttype := locationType
+	if nonNull, ok := ttype.(*NonNull); ok {
+		ttype, _ = nonNull.OfType.(Input)
+	}
+	badValue := func(suffix string) {
+		reportError(
+			context,
+			fmt.Sprintf(`Expected value of type "%v", found %v%v`, locationType, printer.Print(valueAST), suffix),
+			[]ast.Node{valueAST},
+		)
+	}
+
+	switch ttype := ttype.(type) {
+	case *List:
+		itemType, _ := ttype.OfType.(Input)
+		// Lists accept a non-list value as a list of one.
+		listAST, ok := valueAST.(*ast.ListValue)
+		if !ok {
+			validateValueLiteral(context, itemType, valueAST)
+			return
+		}
+		for _, itemAST := range listAST.Values {
+			validateValueLiteral(context, itemType, itemAST)
+		}
+	case *InputObject:
+		objectAST, ok := valueAST.(*ast.ObjectValue)
+		if !ok {
+			badValue(".")
+			return
+		}
+		fields := ttype.Fields()
+		fieldNames := []string{}
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+
+		provided := map[string]bool{}
+		for _, fieldAST := range objectAST.Fields {
+			if fieldAST.Name == nil {
+				continue
+			}
+			fieldName := fieldAST.Name.Value
+			provided[fieldName] = true
+			field, ok := fields[fieldName]
+			if !ok {
+				message := fmt.Sprintf(`Field "%v" is not defined by type "%v".`, fieldName, ttype.Name())
+				if suggestions := suggestionList(fieldName, fieldNames); len(suggestions) > 0 {
+					message = fmt.Sprintf(`%v Did you mean %v?`, message, quotedOrList(suggestions))
+				}
+				reportError(context, message, []ast.Node{fieldAST})
+				continue
+			}
+			validateValueLiteral(context, field.Type, fieldAST.Value)
+		}
+		for _, fieldName := range fieldNames {
+			field := fields[fieldName]
+			if _, ok := field.Type.(*NonNull); ok && field.DefaultValue == nil && !provided[fieldName] {
+				reportError(
+					context,
+					fmt.Sprintf(`Field "%v.%v" of required type "%v" was not provided.`, ttype.Name(), fieldName, field.Type),
+					[]ast.Node{objectAST},
+				)
+			}
+		}
+	case *Enum:
+		enumAST, ok := valueAST.(*ast.EnumValue)
+		if !ok {
+			badValue(".")
+			return
+		}
+		if _, ok := ttype.getNameLookup()[enumAST.Value]; ok {
+			return
+		}
+		valueNames := []string{}
+		for _, value := range ttype.Values() {
+			valueNames = append(valueNames, value.Name)
+		}
+		message := fmt.Sprintf(`Value "%v" does not exist in "%v" enum.`, enumAST.Value, ttype.Name())
+		if suggestions := suggestionList(enumAST.Value, valueNames); len(suggestions) > 0 {
+			message = fmt.Sprintf(`%v Did you mean the enum value %v?`, message, quotedOrList(suggestions))
+		}
+		reportError(context, message, []ast.Node{valueAST})
+	case *Scalar:
+		parsed := ttype.ParseLiteral(valueAST)
+		if err, ok := parsed.(error); ok {
+			badValue(fmt.Sprintf("; %v", err))
+		} else if isNullish(parsed) {
+			badValue(".")
+		}
+	}
+}
+
 // VariablesAreInputTypesRule Variables are input types
 //
 // A GraphQL operation is only valid if all the variables it defines are of
@@ -1797,7 +2745,11 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 		}
 		return (len(messagesReduce) == 0), messagesReduce
 	case *Scalar:
-		if isNullish(ttype.ParseLiteral(valueAST)) {
+		parsed := ttype.ParseLiteral(valueAST)
+		if err, ok := parsed.(error); ok {
+			return false, []string{fmt.Sprintf(`Expected type "%v", found %v; %v`, ttype.Name(), printer.Print(valueAST), err)}
+		}
+		if isNullish(parsed) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
 		}
 	case *Enum:
@@ -0,0 +1,45 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_DisableIntrospection_AllowsTypename(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.DisableIntrospectionRule, `
+      {
+        __typename
+        dog {
+          __typename
+          name
+        }
+      }
+    `)
+}
+func TestValidate_DisableIntrospection_RejectsSchemaAndTypeFields(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.DisableIntrospectionRule, `
+      {
+        __schema {
+          queryType { name }
+        }
+        dog: __type(name: "Dog") {
+          name
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`GraphQL introspection is disabled, but the query contained the field "__schema".`, 3, 9),
+		testutil.RuleError(`GraphQL introspection is disabled, but the query contained the field "__type".`, 6, 9),
+	})
+}
+func TestValidate_DisableIntrospection_IgnoresFieldsNamedLikeIntrospectionFieldsOutsideTheQueryType(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.DisableIntrospectionRule, `
+      {
+        dog {
+          __schema
+        }
+      }
+    `)
+}
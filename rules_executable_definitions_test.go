@@ -0,0 +1,70 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_ExecutableDefinitions_WithOnlyOperation(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ExecutableDefinitionsRule, `
+      query Foo {
+        dog {
+          name
+        }
+      }
+    `)
+}
+func TestValidate_ExecutableDefinitions_WithOperationAndFragment(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ExecutableDefinitionsRule, `
+      query Foo {
+        dog {
+          name
+          ...Frag
+        }
+      }
+
+      fragment Frag on Dog {
+        name
+      }
+    `)
+}
+func TestValidate_ExecutableDefinitions_WithTypeDefinition(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ExecutableDefinitionsRule, `
+      query Foo {
+        dog {
+          name
+        }
+      }
+
+      type Cow {
+        name: String
+      }
+
+      extend type Dog {
+        color: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The "Cow" definition is not executable.`, 8, 7),
+		testutil.RuleError(`The "Dog" definition is not executable.`, 12, 7),
+	})
+}
+func TestValidate_ExecutableDefinitions_WithSchemaDefinition(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ExecutableDefinitionsRule, `
+      schema {
+        query: Query
+      }
+
+      type Query {
+        test: String
+      }
+
+      directive @cached on FIELD
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The schema definition is not executable.`, 2, 7),
+		testutil.RuleError(`The "Query" definition is not executable.`, 6, 7),
+		testutil.RuleError(`The "cached" definition is not executable.`, 10, 7),
+	})
+}
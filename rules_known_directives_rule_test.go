@@ -163,3 +163,38 @@ func TestValidate_KnownDirectives_WithinSchemaLanguage_WithMisplacedDirectives(t
 		testutil.RuleError(`Directive "onObject" may not be used on SCHEMA.`, 22, 16),
 	})
 }
+
+func TestValidate_KnownDirectives_WithinSDL_WithDirectivesDefinedInDocument(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.KnownDirectivesRule, `
+      type Query {
+        foo: String @deprecated @cached(maxAge: 10)
+      }
+
+      directive @cached(maxAge: Int) on FIELD_DEFINITION | OBJECT
+    `)
+}
+func TestValidate_KnownDirectives_WithinSDL_WithUnknownAndMisplacedDirectives(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.KnownDirectivesRule, `
+      type Query @cached {
+        foo: String @unknown
+      }
+
+      enum Color @cached {
+        RED @deprecated
+      }
+
+      directive @cached on FIELD_DEFINITION | OBJECT
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown directive "unknown".`, 3, 21),
+		testutil.RuleError(`Directive "cached" may not be used on ENUM.`, 6, 18),
+	})
+}
+func TestValidate_KnownDirectives_WithinSDL_WithDirectivesOfSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.KnownDirectivesRule, `
+      type Kennel @onObject {
+        name: String @onObject
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "onObject" may not be used on FIELD_DEFINITION.`, 3, 22),
+	})
+}
@@ -59,3 +59,66 @@ func TestValidate_KnownTypeNames_IgnoresTypeDefinitions(t *testing.T) {
 		testutil.RuleError(`Unknown type "NotInTheSchema".`, 12, 23),
 	})
 }
+
+func TestValidate_KnownTypeNames_WithinSDL_KnownTypes(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.KnownTypeNamesRule, `
+      type Query {
+        foo(arg: FooInput): Foo
+        id: ID
+      }
+
+      type Foo implements Node {
+        id: ID!
+        bar: [Bar!]
+      }
+
+      interface Node {
+        id: ID!
+      }
+
+      union Bar = Foo
+
+      input FooInput {
+        baz: String
+      }
+
+      schema {
+        query: Query
+      }
+    `)
+}
+func TestValidate_KnownTypeNames_WithinSDL_UnknownTypes(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.KnownTypeNamesRule, `
+      type Query {
+        foo(arg: FooInpt): Foo
+      }
+
+      type Foo implements Nod {
+        bar: Bar
+      }
+
+      input FooInput {
+        baz: Strng
+      }
+
+      schema {
+        query: Qeury
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown type "FooInpt". Did you mean "FooInput"?`, 3, 18),
+		testutil.RuleError(`Unknown type "Nod".`, 6, 27),
+		testutil.RuleError(`Unknown type "Bar".`, 7, 14),
+		testutil.RuleError(`Unknown type "Strng". Did you mean "String"?`, 11, 14),
+		testutil.RuleError(`Unknown type "Qeury". Did you mean "Query"?`, 15, 16),
+	})
+}
+func TestValidate_KnownTypeNames_WithinSDL_ReferencesTypesOfSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.KnownTypeNamesRule, `
+      type Kennel {
+        dogs: [Dog]
+        cats: [Kat]
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Unknown type "Kat". Did you mean "Cat"?`, 4, 16),
+	})
+}
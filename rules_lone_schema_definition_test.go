@@ -0,0 +1,59 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_LoneSchemaDefinition_NoSchema(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.LoneSchemaDefinitionRule, `
+      type Query {
+        foo: String
+      }
+    `)
+}
+func TestValidate_LoneSchemaDefinition_OneSchemaDefinition(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.LoneSchemaDefinitionRule, `
+      schema {
+        query: Foo
+      }
+
+      type Foo {
+        foo: String
+      }
+    `)
+}
+func TestValidate_LoneSchemaDefinition_MultipleSchemaDefinitions(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.LoneSchemaDefinitionRule, `
+      schema {
+        query: Foo
+      }
+
+      type Foo {
+        foo: String
+      }
+
+      schema {
+        mutation: Foo
+      }
+
+      schema {
+        subscription: Foo
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Must provide only one schema definition.`, 10, 7),
+		testutil.RuleError(`Must provide only one schema definition.`, 14, 7),
+	})
+}
+func TestValidate_LoneSchemaDefinition_DefineSchemaInSchemaExtension(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.LoneSchemaDefinitionRule, `
+      schema {
+        query: QueryRoot
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot define a new schema within a schema extension.`, 2, 7),
+	})
+}
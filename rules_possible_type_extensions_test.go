@@ -0,0 +1,109 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_PossibleTypeExtensions_ExtendingDefinedType(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      extend type Foo {
+        bar: String
+      }
+
+      type Foo {
+        foo: String
+      }
+    `)
+}
+func TestValidate_PossibleTypeExtensions_ExtendingUndefinedType(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      type Known {
+        foo: String
+      }
+
+      extend type Missing {
+        foo: String
+      }
+
+      extend type Knwon {
+        foo: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend type "Missing" because it is not defined.`, 6, 19),
+		testutil.RuleError(`Cannot extend type "Knwon" because it is not defined. Did you mean "Known"?`, 10, 19),
+	})
+}
+func TestValidate_PossibleTypeExtensions_ExtendingIncorrectType(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      input FooInput {
+        foo: String
+      }
+
+      extend type FooInput {
+        bar: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend non-object type "FooInput".`, 2, 7, 6, 7),
+	})
+}
+func TestValidate_PossibleTypeExtensions_ExtendingTypesOfSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.PossibleTypeExtensionsRule, `
+      extend type Dog {
+        bark: String
+      }
+
+      extend type ComplexInput {
+        bar: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend non-object type "ComplexInput".`, 6, 7),
+	})
+}
+func TestValidate_PossibleTypeExtensions_ExtendingSameKinds(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      scalar FooScalar
+      interface FooInterface { foo: String }
+      union FooUnion = FooObject
+      enum FooEnum { FOO }
+      input FooInput { foo: String }
+      type FooObject { foo: String }
+
+      extend scalar FooScalar @dummy
+      extend interface FooInterface { bar: String }
+      extend union FooUnion = BarObject
+      extend enum FooEnum { BAR }
+      extend input FooInput { bar: String }
+    `)
+}
+func TestValidate_PossibleTypeExtensions_ExtendingDifferentKinds(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.PossibleTypeExtensionsRule, `
+      scalar FooScalar
+      type FooObject { foo: String }
+
+      extend enum FooScalar { BAR }
+      extend input FooObject { bar: String }
+      extend interface Missing { bar: String }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend non-enum type "FooScalar".`, 2, 7, 5, 7),
+		testutil.RuleError(`Cannot extend non-input object type "FooObject".`, 3, 7, 6, 7),
+		testutil.RuleError(`Cannot extend type "Missing" because it is not defined.`, 7, 24),
+	})
+}
+func TestValidate_PossibleTypeExtensions_ExtendingKindsOfSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.PossibleTypeExtensionsRule, `
+      extend interface Pet { age: Int }
+      extend union CatOrDog = Human
+      extend enum FurColor { GREEN }
+      extend input ComplexInput { extra: String }
+
+      extend union Dog = Cat
+      extend scalar FurColor @onScalar
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend non-union type "Dog".`, 7, 7),
+		testutil.RuleError(`Cannot extend non-scalar type "FurColor".`, 8, 7),
+	})
+}
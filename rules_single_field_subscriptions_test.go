@@ -0,0 +1,96 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_SingleFieldSubscriptions_ValidSubscription(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+      }
+    `)
+}
+func TestValidate_SingleFieldSubscriptions_QueriesWithMoreThanOneField(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.SingleFieldSubscriptionsRule, `
+      query Pets {
+        dog {
+          name
+        }
+        cat {
+          name
+        }
+      }
+    `)
+}
+func TestValidate_SingleFieldSubscriptions_FailsWithMoreThanOneRootField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+        notImportantEmails
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Subscription "ImportantEmails" must select only one top level field.`, 4, 9),
+	})
+}
+func TestValidate_SingleFieldSubscriptions_FailsWithMoreThanOneRootFieldIncludingIntrospection(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+        __typename
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Subscription "ImportantEmails" must select only one top level field.`, 4, 9),
+	})
+}
+func TestValidate_SingleFieldSubscriptions_FailsWithManyMoreThanOneRootField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+        notImportantEmails
+        spamEmails
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Subscription "ImportantEmails" must select only one top level field.`, 4, 9, 5, 9),
+	})
+}
+func TestValidate_SingleFieldSubscriptions_FailsWithMoreThanOneRootFieldInAnonymousSubscription(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription {
+        importantEmails
+        notImportantEmails
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Anonymous Subscription must select only one top level field.`, 4, 9),
+	})
+}
+func TestValidate_SingleFieldSubscriptions_FailsWithMoreThanOneRootFieldInFragments(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        ...Emails
+      }
+      fragment Emails on SubscriptionRoot {
+        importantEmails
+        ... on SubscriptionRoot {
+          notImportantEmails
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Subscription "ImportantEmails" must select only one top level field.`, 8, 11),
+	})
+}
+func TestValidate_SingleFieldSubscriptions_CountsFieldsByResponseName(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.SingleFieldSubscriptionsRule, `
+      subscription ImportantEmails {
+        importantEmails
+        ...Emails
+      }
+      fragment Emails on SubscriptionRoot {
+        importantEmails
+      }
+    `)
+}
@@ -0,0 +1,50 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_UniqueArgumentDefinitionNames_NoArgs(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueArgumentDefinitionNamesRule, `
+      type SomeObject {
+        someField: String
+      }
+
+      directive @someDirective on QUERY
+    `)
+}
+func TestValidate_UniqueArgumentDefinitionNames_UniqueArgs(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueArgumentDefinitionNamesRule, `
+      type SomeObject {
+        someField(foo: String, bar: String): String
+      }
+
+      interface SomeInterface {
+        someField(foo: String): String
+        otherField(foo: String): String
+      }
+
+      directive @someDirective(foo: String, bar: String) on QUERY
+    `)
+}
+func TestValidate_UniqueArgumentDefinitionNames_DuplicateArgs(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueArgumentDefinitionNamesRule, `
+      type SomeObject {
+        someField(foo: String, bar: String, foo: String): String
+      }
+
+      extend type SomeObject {
+        otherField(foo: String, foo: String, foo: String): String
+      }
+
+      directive @someDirective(foo: String, foo: String) on QUERY
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Argument "SomeObject.someField(foo:)" can only be defined once.`, 3, 19, 3, 45),
+		testutil.RuleError(`Argument "SomeObject.otherField(foo:)" can only be defined once.`, 7, 20, 7, 33, 7, 46),
+		testutil.RuleError(`Argument "@someDirective(foo:)" can only be defined once.`, 10, 32, 10, 45),
+	})
+}
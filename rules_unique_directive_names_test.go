@@ -0,0 +1,52 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_UniqueDirectiveNames_NoDirective(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueDirectiveNamesRule, `
+      type Foo {
+        foo: String
+      }
+    `)
+}
+func TestValidate_UniqueDirectiveNames_ManyDirectives(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueDirectiveNamesRule, `
+      directive @foo on SCHEMA
+      directive @bar on SCHEMA
+      directive @baz on SCHEMA
+    `)
+}
+func TestValidate_UniqueDirectiveNames_DirectiveAndTypeWithSameName(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueDirectiveNamesRule, `
+      directive @foo on SCHEMA
+
+      type foo {
+        foo: String
+      }
+    `)
+}
+func TestValidate_UniqueDirectiveNames_DuplicateDirectives(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueDirectiveNamesRule, `
+      directive @foo on SCHEMA
+
+      directive @foo on SCHEMA
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can be only one directive named "@foo".`, 2, 18, 4, 18),
+	})
+}
+func TestValidate_UniqueDirectiveNames_DirectivesDefinedInSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.UniqueDirectiveNamesRule, `
+      directive @skip on FIELD
+      directive @onQuery on QUERY
+      directive @onOther on QUERY
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "@skip" already exists in the schema. It cannot be redefined.`, 2, 18),
+		testutil.RuleError(`Directive "@onQuery" already exists in the schema. It cannot be redefined.`, 3, 18),
+	})
+}
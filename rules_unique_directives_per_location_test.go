@@ -0,0 +1,85 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_UniqueDirectivesPerLocation_NoDirectives(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_UniqueDirectivesInDifferentLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @directiveA {
+        field @directiveB
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_UniqueDirectivesInSameLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @directiveA @directiveB {
+        field @directiveA @directiveB
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_SameDirectivesInDifferentLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @directiveA {
+        field @directiveA
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_SameDirectivesInSimilarLocations(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @directive
+        field @directive
+      }
+    `)
+}
+func TestValidate_UniqueDirectivesPerLocation_DuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @directive @directive
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@directive" can only be used once at this location.`, 3, 15, 3, 26),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_ManyDuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @directive @directive @directive
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@directive" can only be used once at this location.`, 3, 15, 3, 26),
+		testutil.RuleError(`The directive "@directive" can only be used once at this location.`, 3, 15, 3, 37),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_DifferentDuplicateDirectivesInOneLocation(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type {
+        field @directiveA @directiveB @directiveA @directiveB
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@directiveA" can only be used once at this location.`, 3, 15, 3, 39),
+		testutil.RuleError(`The directive "@directiveB" can only be used once at this location.`, 3, 27, 3, 51),
+	})
+}
+func TestValidate_UniqueDirectivesPerLocation_DuplicateDirectivesInManyLocations(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueDirectivesPerLocationRule, `
+      fragment Test on Type @directive @directive {
+        field @directive @directive
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`The directive "@directive" can only be used once at this location.`, 2, 29, 2, 40),
+		testutil.RuleError(`The directive "@directive" can only be used once at this location.`, 3, 15, 3, 26),
+	})
+}
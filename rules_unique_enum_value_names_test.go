@@ -0,0 +1,42 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_UniqueEnumValueNames_OneValue(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueEnumValueNamesRule, `
+      enum SomeEnum {
+        FOO
+      }
+    `)
+}
+func TestValidate_UniqueEnumValueNames_MultipleValues(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueEnumValueNamesRule, `
+      enum SomeEnum {
+        FOO
+        BAR
+      }
+
+      enum OtherEnum {
+        FOO
+      }
+    `)
+}
+func TestValidate_UniqueEnumValueNames_DuplicateValues(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueEnumValueNamesRule, `
+      enum SomeEnum {
+        FOO
+        BAR
+        FOO
+        FOO
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Enum value "SomeEnum.FOO" can only be defined once.`, 3, 9, 5, 9),
+		testutil.RuleError(`Enum value "SomeEnum.FOO" can only be defined once.`, 3, 9, 6, 9),
+	})
+}
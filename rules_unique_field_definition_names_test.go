@@ -0,0 +1,84 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_UniqueFieldDefinitionNames_OneField(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueFieldDefinitionNamesRule, `
+      type SomeObject {
+        foo: String
+      }
+
+      interface SomeInterface {
+        foo: String
+      }
+
+      input SomeInputObject {
+        foo: String
+      }
+    `)
+}
+func TestValidate_UniqueFieldDefinitionNames_MultipleFieldsInExtensions(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueFieldDefinitionNamesRule, `
+      type SomeObject {
+        foo: String
+      }
+      extend type SomeObject {
+        bar: String
+      }
+      extend type SomeObject {
+        baz: String
+      }
+    `)
+}
+func TestValidate_UniqueFieldDefinitionNames_DuplicateFieldsInsideDefinition(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueFieldDefinitionNamesRule, `
+      type SomeObject {
+        foo: String
+        bar: String
+        foo: String
+      }
+
+      interface SomeInterface {
+        foo: String
+        foo: String
+      }
+
+      input SomeInputObject {
+        foo: String
+        bar: String
+        foo: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "SomeObject.foo" can only be defined once.`, 3, 9, 5, 9),
+		testutil.RuleError(`Field "SomeInterface.foo" can only be defined once.`, 9, 9, 10, 9),
+		testutil.RuleError(`Field "SomeInputObject.foo" can only be defined once.`, 14, 9, 16, 9),
+	})
+}
+func TestValidate_UniqueFieldDefinitionNames_DuplicateFieldsInExtension(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueFieldDefinitionNamesRule, `
+      type SomeObject {
+        foo: String
+      }
+      extend type SomeObject {
+        foo: String
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "SomeObject.foo" can only be defined once.`, 3, 9, 6, 9),
+	})
+}
+func TestValidate_UniqueFieldDefinitionNames_ExtendingFieldsOfSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.UniqueFieldDefinitionNamesRule, `
+      extend type Dog {
+        name: String
+        owner: Human
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "Dog.name" already exists in the schema. It cannot also be defined in this type extension.`, 3, 9),
+	})
+}
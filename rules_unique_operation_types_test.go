@@ -0,0 +1,83 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_UniqueOperationTypes_NoSchemaDefinition(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueOperationTypesRule, `
+      type Foo {
+        field: String
+      }
+    `)
+}
+func TestValidate_UniqueOperationTypes_SchemaDefinitionWithAllTypes(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.UniqueOperationTypesRule, `
+      type Foo {
+        field: String
+      }
+
+      schema {
+        query: Foo
+        mutation: Foo
+        subscription: Foo
+      }
+    `)
+}
+func TestValidate_UniqueOperationTypes_DuplicateOperationTypes(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueOperationTypesRule, `
+      type Foo {
+        field: String
+      }
+
+      schema {
+        query: Foo
+        mutation: Foo
+        subscription: Foo
+
+        query: Foo
+        mutation: Foo
+        subscription: Foo
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can be only one query type in schema.`, 7, 9, 11, 9),
+		testutil.RuleError(`There can be only one mutation type in schema.`, 8, 9, 12, 9),
+		testutil.RuleError(`There can be only one subscription type in schema.`, 9, 9, 13, 9),
+	})
+}
+func TestValidate_UniqueOperationTypes_DuplicateOperationTypesInSeparateSchemaDefinitions(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.UniqueOperationTypesRule, `
+      type Foo {
+        field: String
+      }
+
+      schema {
+        query: Foo
+      }
+
+      schema {
+        query: Foo
+        mutation: Foo
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can be only one query type in schema.`, 7, 9, 11, 9),
+	})
+}
+func TestValidate_UniqueOperationTypes_OperationTypesOfSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.UniqueOperationTypesRule, `
+      type Foo {
+        field: String
+      }
+
+      extend schema {
+        query: Foo
+        mutation: Foo
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Type for query already defined in the schema. It cannot be redefined.`, 7, 9),
+	})
+}
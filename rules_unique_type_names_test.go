@@ -0,0 +1,66 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_UniqueTypeNames_NoTypes(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueTypeNamesRule, `
+      directive @test on SCHEMA
+    `)
+}
+func TestValidate_UniqueTypeNames_OneType(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueTypeNamesRule, `
+      type Foo {
+        foo: String
+      }
+    `)
+}
+func TestValidate_UniqueTypeNames_ManyTypes(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueTypeNamesRule, `
+      type Foo { foo: String }
+      type Bar { bar: String }
+      type Baz { baz: String }
+    `)
+}
+func TestValidate_UniqueTypeNames_TypeAndExtensionWithSameName(t *testing.T) {
+	testutil.ExpectPassesSDLRule(t, graphql.UniqueTypeNamesRule, `
+      type Foo { foo: String }
+      extend type Foo {
+        bar: String
+      }
+    `)
+}
+func TestValidate_UniqueTypeNames_DuplicateTypes(t *testing.T) {
+	testutil.ExpectFailsSDLRule(t, graphql.UniqueTypeNamesRule, `
+      type Foo { foo: String }
+
+      scalar Foo
+      type Foo { foo: String }
+      interface Foo { foo: String }
+      union Foo = Bar
+      enum Foo { FOO }
+      input Foo { foo: String }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`There can be only one type named "Foo".`, 2, 12, 4, 14),
+		testutil.RuleError(`There can be only one type named "Foo".`, 2, 12, 5, 12),
+		testutil.RuleError(`There can be only one type named "Foo".`, 2, 12, 6, 17),
+		testutil.RuleError(`There can be only one type named "Foo".`, 2, 12, 7, 13),
+		testutil.RuleError(`There can be only one type named "Foo".`, 2, 12, 8, 12),
+		testutil.RuleError(`There can be only one type named "Foo".`, 2, 12, 9, 13),
+	})
+}
+func TestValidate_UniqueTypeNames_TypesDefinedInSchema(t *testing.T) {
+	testutil.ExpectFailsSDLRuleWithSchema(t, testutil.TestSchema, graphql.UniqueTypeNamesRule, `
+      type Dog { name: String }
+      scalar String
+      type Cow { name: String }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Type "Dog" already exists in the schema. It cannot also be defined in this type definition.`, 2, 12),
+		testutil.RuleError(`Type "String" already exists in the schema. It cannot also be defined in this type definition.`, 3, 14),
+	})
+}
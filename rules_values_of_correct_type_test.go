@@ -0,0 +1,110 @@
+package graphql_test
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestValidate_ValuesOfCorrectType_ValidValues(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ValuesOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            intArgField(intArg: 2)
+            floatArgField(floatArg: 1)
+            idArgField(idArg: 1)
+            enumArgField(enumArg: BROWN)
+            stringListArgField(stringListArg: "one")
+            complexArgField(complexArg: {requiredField: true, stringListField: ["one", "two"]})
+          }
+        }
+    `)
+}
+func TestValidate_ValuesOfCorrectType_VariablesAreNotChecked(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ValuesOfCorrectTypeRule, `
+        query Query($intArg: String) {
+          complicatedArgs {
+            intArgField(intArg: $intArg)
+            complexArgField(complexArg: {requiredField: $intArg})
+          }
+        }
+    `)
+}
+func TestValidate_ValuesOfCorrectType_InvalidScalarValues(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ValuesOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            intArgField(intArg: 3.0)
+            nonNullIntArgField(nonNullIntArg: "3")
+            booleanArgField(booleanArg: TRUE)
+          }
+        }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Expected value of type "Int", found 3.0.`, 4, 33),
+		testutil.RuleError(`Expected value of type "Int!", found "3".`, 5, 47),
+		testutil.RuleError(`Expected value of type "Boolean", found TRUE.`, 6, 41),
+	})
+}
+func TestValidate_ValuesOfCorrectType_InvalidEnumValues(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ValuesOfCorrectTypeRule, `
+        {
+          dog {
+            doesKnowCommand(dogCommand: "SIT")
+            isHousetrained(atOtherHomes: true) @include(if: 1)
+          }
+          complicatedArgs {
+            enumArgField(enumArg: BROWNN)
+          }
+        }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Expected value of type "DogCommand", found "SIT".`, 4, 41),
+		testutil.RuleError(`Expected value of type "Boolean!", found 1.`, 5, 61),
+		testutil.RuleError(`Value "BROWNN" does not exist in "FurColor" enum. Did you mean the enum value "BROWN"?`, 8, 35),
+	})
+}
+func TestValidate_ValuesOfCorrectType_ReportsListItems(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ValuesOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            stringListArgField(stringListArg: ["one", 2, "three", 4])
+            complexArgField(complexArg: {requiredField: true, stringListField: [1]})
+          }
+        }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Expected value of type "String", found 2.`, 4, 55),
+		testutil.RuleError(`Expected value of type "String", found 4.`, 4, 67),
+		testutil.RuleError(`Expected value of type "String", found 1.`, 5, 81),
+	})
+}
+func TestValidate_ValuesOfCorrectType_ReportsInputObjectFields(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ValuesOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            complexArgField(complexArg: {intField: 4})
+            complexArgField(complexArg: {requiredField: true, stringfield: "x"})
+            complexArgField(complexArg: "requiredField")
+          }
+        }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "ComplexInput.requiredField" of required type "Boolean!" was not provided.`, 4, 41),
+		testutil.RuleError(`Field "stringfield" is not defined by type "ComplexInput". Did you mean "stringField", "intField", or "stringListField"?`, 5, 63),
+		testutil.RuleError(`Expected value of type "ComplexInput", found "requiredField".`, 6, 41),
+	})
+}
+func TestValidate_ValuesOfCorrectType_ReportsDefaultValues(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ValuesOfCorrectTypeRule, `
+        query InvalidDefaultValues(
+          $a: Int = "one",
+          $b: ComplexInput = {requiredField: "true", intField: 1},
+          $c: [String] = ["ok", 3]
+        ) {
+          dog { name }
+        }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Expected value of type "Int", found "one".`, 3, 21),
+		testutil.RuleError(`Expected value of type "Boolean!", found "true".`, 4, 46),
+		testutil.RuleError(`Expected value of type "String", found 3.`, 5, 33),
+	})
+}
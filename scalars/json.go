@@ -0,0 +1,90 @@
+package scalars
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// JSON represents arbitrary JSON values. Object and list literals are
+// accepted as input, including variables nested in them, e.g.
+// `{filter: {name: $name, tags: ["a", $tag]}}`.
+var JSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "JSON",
+	Description: "The `JSON` scalar type represents arbitrary JSON values: objects, " +
+		"lists, strings, numbers and booleans.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc8259",
+	Serialize:      serializeJSON,
+	ParseValue:     serializeJSON,
+	ParseLiteral:   parseJSONLiteral,
+})
+
+func serializeJSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case json.RawMessage:
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return fmt.Errorf("JSON cannot represent invalid raw message: %v", err)
+		}
+		return decoded
+	case *json.RawMessage:
+		if value == nil {
+			return nil
+		}
+		return serializeJSON(*value)
+	}
+	return value
+}
+
+func parseJSONLiteral(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.ObjectValue:
+		obj := map[string]interface{}{}
+		for _, field := range valueAST.Fields {
+			value := parseJSONLiteral(field.Value)
+			if err, ok := value.(error); ok {
+				return err
+			}
+			obj[field.Name.Value] = value
+		}
+		return obj
+	case *ast.ListValue:
+		list := []interface{}{}
+		for _, item := range valueAST.Values {
+			value := parseJSONLiteral(item)
+			if err, ok := value.(error); ok {
+				return err
+			}
+			list = append(list, value)
+		}
+		return list
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.IntValue:
+		if value, err := strconv.ParseInt(valueAST.Value, 10, 64); err == nil {
+			if int64(int(value)) == value {
+				return int(value)
+			}
+			return value
+		}
+		if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return value
+		}
+	case *ast.FloatValue:
+		if value, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return value
+		}
+	case *ast.NullValue:
+		return nil
+	case *ast.Variable:
+		// variables are only left in literals during validation, they are
+		// substituted with their values before execution
+		return nil
+	}
+	return literalError("JSON", valueAST)
+}
@@ -0,0 +1,256 @@
+package scalars
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Int64 represents signed 64-bit integers, serialized as JSON numbers.
+// Numeric strings are accepted in variables for clients that cannot represent
+// such numbers exactly.
+var Int64 = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Int64",
+	Description: "The `Int64` scalar type represents non-fractional signed whole numeric " +
+		"values between -(2^63) and 2^63 - 1.",
+	SpecifiedByURL: "https://pkg.go.dev/github.com/graphql-go/graphql/scalars#Int64",
+	Serialize:      coerceInt64,
+	ParseValue:     coerceInt64,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return coerceInt64(valueAST.Value)
+		}
+		return literalError("Int64", valueAST)
+	},
+})
+
+func coerceInt64(value interface{}) interface{} {
+	switch value := indirect(value).(type) {
+	case int:
+		return int64(value)
+	case int8:
+		return int64(value)
+	case int16:
+		return int64(value)
+	case int32:
+		return int64(value)
+	case int64:
+		return value
+	case uint:
+		return coerceInt64(uint64(value))
+	case uint8:
+		return int64(value)
+	case uint16:
+		return int64(value)
+	case uint32:
+		return int64(value)
+	case uint64:
+		if value > math.MaxInt64 {
+			return fmt.Errorf("Int64 cannot represent %v: value out of range", value)
+		}
+		return int64(value)
+	case float32:
+		return coerceInt64(float64(value))
+	case float64:
+		if value != math.Trunc(value) {
+			return fmt.Errorf("Int64 cannot represent non-integer value: %v", value)
+		}
+		if value < math.MinInt64 || value >= math.MaxInt64 {
+			return fmt.Errorf("Int64 cannot represent %v: value out of range", value)
+		}
+		return int64(value)
+	case json.Number:
+		return coerceInt64(string(value))
+	case string:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err == nil {
+			return i
+		}
+		if err.(*strconv.NumError).Err == strconv.ErrRange {
+			return fmt.Errorf("Int64 cannot represent %v: value out of range", value)
+		}
+		return fmt.Errorf("Int64 cannot represent non-integer value: %q", value)
+	}
+	return fmt.Errorf("Int64 cannot represent value: %v", value)
+}
+
+// BigInt represents integers of any size as *big.Int values. They are
+// serialized as strings, since most JSON clients cannot represent them
+// exactly as numbers.
+var BigInt = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "BigInt",
+	Description: "The `BigInt` scalar type represents non-fractional signed whole numeric " +
+		"values of arbitrary size, serialized as strings.",
+	SpecifiedByURL: "https://pkg.go.dev/github.com/graphql-go/graphql/scalars#BigInt",
+	Serialize: func(value interface{}) interface{} {
+		i := coerceBigInt(value)
+		if i, ok := i.(*big.Int); ok {
+			return i.String()
+		}
+		return i
+	},
+	ParseValue: coerceBigInt,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return coerceBigInt(valueAST.Value)
+		case *ast.StringValue:
+			return coerceBigInt(valueAST.Value)
+		}
+		return literalError("BigInt", valueAST)
+	},
+})
+
+func coerceBigInt(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *big.Int:
+		if value == nil {
+			return nil
+		}
+		return value
+	case big.Int:
+		return &value
+	}
+	switch value := indirect(value).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		i, _ := new(big.Int).SetString(fmt.Sprintf("%d", value), 10)
+		return i
+	case float32:
+		return coerceBigInt(float64(value))
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) || value != math.Trunc(value) {
+			return fmt.Errorf("BigInt cannot represent non-integer value: %v", value)
+		}
+		i, _ := big.NewFloat(value).Int(nil)
+		return i
+	case json.Number:
+		return coerceBigInt(string(value))
+	case string:
+		if i, ok := new(big.Int).SetString(value, 10); ok {
+			return i
+		}
+		return fmt.Errorf("BigInt cannot represent non-integer value: %q", value)
+	}
+	return fmt.Errorf("BigInt cannot represent value: %v", value)
+}
+
+// MaxDecimalExponent is the largest absolute exponent of the values parsed
+// by Decimal.
+const MaxDecimalExponent = 1000
+
+// Decimal represents exact decimal numbers as *big.Rat values, serialized as
+// strings such as "12.5" without losing precision. Fractions without a
+// finite decimal representation, such as 1/3, cannot be serialized. The
+// exponents of the input values, as in "1e10", are limited to
+// ±MaxDecimalExponent, since the size of the values grows with them.
+var Decimal = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Decimal",
+	Description: "The `Decimal` scalar type represents exact signed decimal numbers, " +
+		"serialized as strings.",
+	SpecifiedByURL: "https://pkg.go.dev/github.com/graphql-go/graphql/scalars#Decimal",
+	Serialize: func(value interface{}) interface{} {
+		r := coerceDecimal(value)
+		if r, ok := r.(*big.Rat); ok {
+			return decimalString(r)
+		}
+		return r
+	},
+	ParseValue: coerceDecimal,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			return coerceDecimal(valueAST.Value)
+		case *ast.FloatValue:
+			return coerceDecimal(valueAST.Value)
+		case *ast.StringValue:
+			return coerceDecimal(valueAST.Value)
+		}
+		return literalError("Decimal", valueAST)
+	},
+})
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+func coerceDecimal(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *big.Rat:
+		if value == nil {
+			return nil
+		}
+		return value
+	case big.Rat:
+		return &value
+	case *big.Int:
+		if value == nil {
+			return nil
+		}
+		return new(big.Rat).SetInt(value)
+	}
+	switch value := indirect(value).(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return coerceDecimal(fmt.Sprintf("%d", value))
+	case float32:
+		return coerceDecimal(strconv.FormatFloat(float64(value), 'f', -1, 32))
+	case float64:
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return fmt.Errorf("Decimal cannot represent non-numeric value: %v", value)
+		}
+		// the shortest representation, 0.1 rather than the exact binary value
+		return coerceDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	case json.Number:
+		return coerceDecimal(string(value))
+	case string:
+		// big.Rat also accepts fractions such as "1/3", and hexadecimal or
+		// binary exponents, which are not decimals
+		if !decimalPattern.MatchString(value) {
+			return fmt.Errorf("Decimal cannot represent non-decimal value: %q", value)
+		}
+		if i := strings.IndexAny(value, "eE"); i >= 0 {
+			exponent, err := strconv.Atoi(value[i+1:])
+			if err != nil || exponent > MaxDecimalExponent || exponent < -MaxDecimalExponent {
+				return fmt.Errorf("Decimal cannot represent %q: exponent out of range", value)
+			}
+		}
+		if r, ok := new(big.Rat).SetString(value); ok {
+			return r
+		}
+		return fmt.Errorf("Decimal cannot represent non-decimal value: %q", value)
+	}
+	return fmt.Errorf("Decimal cannot represent value: %v", value)
+}
+
+// decimalString formats r with as many fractional digits as needed to be
+// exact.
+func decimalString(r *big.Rat) interface{} {
+	denominator := new(big.Int).Set(r.Denom())
+	two, five := big.NewInt(2), big.NewInt(5)
+	twos, fives := 0, 0
+	mod := new(big.Int)
+	for {
+		if q, m := new(big.Int).QuoRem(denominator, two, mod); m.Sign() == 0 {
+			denominator, twos = q, twos+1
+			continue
+		}
+		if q, m := new(big.Int).QuoRem(denominator, five, mod); m.Sign() == 0 {
+			denominator, fives = q, fives+1
+			continue
+		}
+		break
+	}
+	if denominator.Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("Decimal cannot represent %v: no finite decimal representation", r.String())
+	}
+	digits := twos
+	if fives > digits {
+		digits = fives
+	}
+	return r.FloatString(digits)
+}
@@ -0,0 +1,49 @@
+// Package scalars provides custom scalar types commonly needed on top of the
+// ones built into the graphql package: JSON, Int64, BigInt, Decimal, UUID,
+// Date, Time, Duration, URL and Email.
+//
+// Every scalar sets SpecifiedByURL. Invalid values are rejected with an error
+// explaining why, which is reported in the response in place of the generic
+// "Expected type" message.
+package scalars
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// indirect dereferences pointers to the values resolvers commonly return,
+// e.g. *string or *int64. Nil pointers yield nil.
+func indirect(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// stringValue returns the Go string held by value, for scalars represented
+// as strings.
+func stringValue(value interface{}) (string, bool) {
+	switch value := indirect(value).(type) {
+	case string:
+		return value, true
+	case fmt.Stringer:
+		return value.String(), true
+	}
+	return "", false
+}
+
+// literalError reports a literal a scalar cannot parse.
+func literalError(name string, valueAST ast.Value) error {
+	return fmt.Errorf("%v cannot represent literal %v", name, printer.Print(valueAST))
+}
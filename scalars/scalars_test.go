@@ -0,0 +1,327 @@
+package scalars_test
+
+import (
+	"math/big"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/scalars"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+var allScalars = []*graphql.Scalar{
+	scalars.JSON,
+	scalars.Int64,
+	scalars.BigInt,
+	scalars.Decimal,
+	scalars.UUID,
+	scalars.Date,
+	scalars.Time,
+	scalars.Duration,
+	scalars.URL,
+	scalars.Email,
+}
+
+// echoSchema has a field per scalar returning its argument, and records the
+// parsed arguments.
+func echoSchema(t *testing.T, parsed map[string]interface{}) graphql.Schema {
+	fields := graphql.Fields{}
+	for _, scalar := range allScalars {
+		name := strings.ToLower(scalar.Name())
+		fields[name] = &graphql.Field{
+			Type: scalar,
+			Args: graphql.FieldConfigArgument{
+				"value": &graphql.ArgumentConfig{Type: scalar},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				parsed[p.Info.FieldName] = p.Args["value"]
+				return p.Args["value"], nil
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: fields,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestScalars_RoundTripLiterals(t *testing.T) {
+	parsed := map[string]interface{}{}
+	result := graphql.Do(graphql.Params{
+		Schema: echoSchema(t, parsed),
+		RequestString: `{
+			json(value: {name: "gopher", tags: ["a", 1, 2.5, true], nested: {ok: false}})
+			int64(value: 9223372036854775807)
+			bigint(value: 123456789012345678901234567890)
+			decimal(value: 12.50)
+			uuid(value: "F47AC10B-58CC-4372-A567-0E02B2C3D479")
+			date(value: "2006-01-02")
+			time(value: "15:04:05.25")
+			duration(value: "P1DT1H30M0.5S")
+			url(value: "https://example.com/a?b=c")
+			email(value: "gopher@example.com")
+		}`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"json": map[string]interface{}{
+				"name":   "gopher",
+				"tags":   []interface{}{"a", 1, 2.5, true},
+				"nested": map[string]interface{}{"ok": false},
+			},
+			"int64":    int64(9223372036854775807),
+			"bigint":   "123456789012345678901234567890",
+			"decimal":  "12.5",
+			"uuid":     "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			"date":     "2006-01-02",
+			"time":     "15:04:05.25",
+			"duration": "P1DT1H30M0.5S",
+			"url":      "https://example.com/a?b=c",
+			"email":    "gopher@example.com",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+
+	if _, ok := parsed["bigint"].(*big.Int); !ok {
+		t.Fatalf("expected BigInt to parse to *big.Int, got %T", parsed["bigint"])
+	}
+	if r, ok := parsed["decimal"].(*big.Rat); !ok || r.Cmp(big.NewRat(25, 2)) != 0 {
+		t.Fatalf("expected Decimal to parse to 25/2, got %v", parsed["decimal"])
+	}
+	if d, ok := parsed["duration"].(time.Duration); !ok || d != 25*time.Hour+30*time.Minute+500*time.Millisecond {
+		t.Fatalf("unexpected duration: %v", parsed["duration"])
+	}
+	if u, ok := parsed["url"].(*url.URL); !ok || u.Host != "example.com" {
+		t.Fatalf("unexpected url: %v", parsed["url"])
+	}
+	if d, ok := parsed["date"].(time.Time); !ok || !d.Equal(time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date: %v", parsed["date"])
+	}
+}
+
+func TestScalars_ParseVariables(t *testing.T) {
+	parsed := map[string]interface{}{}
+	result := graphql.Do(graphql.Params{
+		Schema: echoSchema(t, parsed),
+		RequestString: `query (
+			$int64: Int64, $bigint: BigInt, $decimal: Decimal, $duration: Duration
+		) {
+			int64(value: $int64)
+			bigint(value: $bigint)
+			decimal(value: $decimal)
+			duration(value: $duration)
+		}`,
+		VariableValues: map[string]interface{}{
+			"int64":    "-9223372036854775808",
+			"bigint":   float64(1e20),
+			"decimal":  0.1,
+			"duration": "PT90M",
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"int64":    int64(-9223372036854775808),
+			"bigint":   "100000000000000000000",
+			"decimal":  "0.1",
+			"duration": "PT1H30M",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestJSON_AcceptsVariablesNestedInLiterals(t *testing.T) {
+	parsed := map[string]interface{}{}
+	result := graphql.Do(graphql.Params{
+		Schema: echoSchema(t, parsed),
+		RequestString: `query ($name: String, $tag: String, $meta: JSON) {
+			json(value: {name: $name, tags: ["a", $tag], meta: $meta})
+		}`,
+		VariableValues: map[string]interface{}{
+			"name": "gopher",
+			"tag":  "b",
+			"meta": map[string]interface{}{"count": 2, "ratio": 0.5},
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"json": map[string]interface{}{
+				"name": "gopher",
+				"tags": []interface{}{"a", "b"},
+				"meta": map[string]interface{}{"count": 2, "ratio": 0.5},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestJSON_KeepsNullVariablesNestedInLiterals(t *testing.T) {
+	parsed := map[string]interface{}{}
+	result := graphql.Do(graphql.Params{
+		Schema: echoSchema(t, parsed),
+		RequestString: `query ($name: String, $tag: String, $meta: JSON) {
+			json(value: {name: $name, tags: [1, $tag], meta: $meta})
+		}`,
+		VariableValues: map[string]interface{}{
+			"name": nil,
+			"meta": map[string]interface{}{"count": nil},
+		},
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"json": map[string]interface{}{
+				"name": nil,
+				"tags": []interface{}{1, nil},
+				"meta": map[string]interface{}{"count": nil},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestScalars_ReportMeaningfulErrors(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			`{ int64(value: 9223372036854775808) }`,
+			"Expected value of type \"Int64\", found 9223372036854775808; Int64 cannot represent 9223372036854775808: value out of range",
+		},
+		{
+			`{ uuid(value: "f47ac10b-58cc-4372-a567-0e02b2c3d47") }`,
+			"Expected value of type \"UUID\", found \"f47ac10b-58cc-4372-a567-0e02b2c3d47\"; UUID cannot represent \"f47ac10b-58cc-4372-a567-0e02b2c3d47\": invalid length",
+		},
+		{
+			`{ date(value: "2006-02-30") }`,
+			"Expected value of type \"Date\", found \"2006-02-30\"; Date cannot represent \"2006-02-30\": day out of range",
+		},
+		{
+			`{ duration(value: "P1Y") }`,
+			"Expected value of type \"Duration\", found \"P1Y\"; Duration cannot represent \"P1Y\": years, months and weeks are not supported",
+		},
+		{
+			`{ url(value: "/relative") }`,
+			"Expected value of type \"URL\", found \"/relative\"; URL cannot represent \"/relative\": not an absolute URL",
+		},
+		{
+			`{ email(value: "Gopher <gopher@example.com>") }`,
+			"Expected value of type \"Email\", found \"Gopher <gopher@example.com>\"; Email cannot represent \"Gopher <gopher@example.com>\": expected a bare address",
+		},
+		{
+			`{ decimal(value: 1e999999) }`,
+			"Expected value of type \"Decimal\", found 1e999999; Decimal cannot represent \"1e999999\": exponent out of range",
+		},
+		{
+			`{ decimal(value: "1e-99999999999999999999") }`,
+			"Expected value of type \"Decimal\", found \"1e-99999999999999999999\"; Decimal cannot represent \"1e-99999999999999999999\": exponent out of range",
+		},
+		{
+			`{ decimal(value: "1p999999") }`,
+			"Expected value of type \"Decimal\", found \"1p999999\"; Decimal cannot represent non-decimal value: \"1p999999\"",
+		},
+		{
+			`{ json(value: SOME_ENUM) }`,
+			"Expected value of type \"JSON\", found SOME_ENUM; JSON cannot represent literal SOME_ENUM",
+		},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:        echoSchema(t, map[string]interface{}{}),
+			RequestString: test.query,
+		})
+		if len(result.Errors) != 1 || result.Errors[0].Message != test.expected {
+			t.Fatalf("%v: expected error %q, got %v", test.query, test.expected, result.Errors)
+		}
+	}
+}
+
+func TestScalars_ReportInvalidVariables(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        echoSchema(t, map[string]interface{}{}),
+		RequestString: `query ($value: Decimal) { decimal(value: $value) }`,
+		VariableValues: map[string]interface{}{
+			"value": "1/3",
+		},
+	})
+	expected := `Variable "$value" got invalid value "1/3".` +
+		"\nExpected type \"Decimal\", found \"1/3\"; Decimal cannot represent non-decimal value: \"1/3\""
+	if len(result.Errors) != 1 || result.Errors[0].Message != expected {
+		t.Fatalf("expected error %q, got %v", expected, result.Errors)
+	}
+}
+
+func TestScalars_ReportSerializationErrors(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"third": &graphql.Field{
+					Type: scalars.Decimal,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return big.NewRat(1, 3), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ third }`,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got %v", result.Errors)
+	}
+	if result.Errors[0].Message != "Decimal cannot represent 1/3: no finite decimal representation" {
+		t.Fatalf("unexpected error: %v", result.Errors[0].Message)
+	}
+	if !reflect.DeepEqual(result.Errors[0].Path, []interface{}{"third"}) {
+		t.Fatalf("unexpected path: %v", result.Errors[0].Path)
+	}
+}
+
+func TestScalars_SetSpecifiedByURL(t *testing.T) {
+	schema := echoSchema(t, map[string]interface{}{})
+	for _, scalar := range allScalars {
+		if scalar.SpecifiedByURL() == "" {
+			t.Fatalf("%v has no specifiedByURL", scalar.Name())
+		}
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __type(name: "UUID") { specifiedByURL } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{
+				"specifiedByURL": "https://www.rfc-editor.org/rfc/rfc4122",
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if sdl := graphql.PrintSchema(schema); !strings.Contains(sdl, `scalar UUID @specifiedBy(url: "https://www.rfc-editor.org/rfc/rfc4122")`) {
+		t.Fatalf("expected @specifiedBy in printed schema, got:\n%v", sdl)
+	}
+}
@@ -0,0 +1,135 @@
+package scalars
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// UUID represents RFC 4122 UUIDs as strings, normalized to the lowercase
+// hyphenated form. Any value implementing fmt.Stringer with such a string
+// representation can be returned by resolvers.
+var UUID = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "UUID",
+	Description:    "The `UUID` scalar type represents RFC 4122 universally unique identifiers.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc4122",
+	Serialize:      coerceUUID,
+	ParseValue:     coerceUUID,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return coerceUUID(valueAST.Value)
+		}
+		return literalError("UUID", valueAST)
+	},
+})
+
+func coerceUUID(value interface{}) interface{} {
+	s, ok := stringValue(value)
+	if !ok {
+		return fmt.Errorf("UUID cannot represent non-string value: %v", value)
+	}
+	// also accept the URN form and braces
+	uuid := strings.TrimPrefix(strings.ToLower(s), "urn:uuid:")
+	if strings.HasPrefix(uuid, "{") && strings.HasSuffix(uuid, "}") {
+		uuid = uuid[1 : len(uuid)-1]
+	}
+	if len(uuid) != 36 {
+		return fmt.Errorf("UUID cannot represent %q: invalid length", s)
+	}
+	for i, c := range uuid {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return fmt.Errorf("UUID cannot represent %q: expected '-' at position %v", s, i+1)
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+				return fmt.Errorf("UUID cannot represent %q: invalid character %q", s, c)
+			}
+		}
+	}
+	return uuid
+}
+
+// URL represents absolute URLs, as defined by RFC 3986. Input values are
+// parsed to *url.URL; resolvers may return url.URL, *url.URL or strings.
+var URL = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "URL",
+	Description:    "The `URL` scalar type represents absolute URLs as defined by RFC 3986.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc3986",
+	Serialize: func(value interface{}) interface{} {
+		u := parseURL(value)
+		if u, ok := u.(*url.URL); ok {
+			return u.String()
+		}
+		return u
+	},
+	ParseValue: parseURL,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return parseURL(valueAST.Value)
+		}
+		return literalError("URL", valueAST)
+	},
+})
+
+func parseURL(value interface{}) interface{} {
+	var s string
+	switch value := value.(type) {
+	case *url.URL:
+		if value == nil {
+			return nil
+		}
+		s = value.String()
+	case url.URL:
+		s = value.String()
+	default:
+		var ok bool
+		if s, ok = stringValue(value); !ok {
+			return fmt.Errorf("URL cannot represent non-string value: %v", value)
+		}
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("URL cannot represent %q: %v", s, err)
+	}
+	if !u.IsAbs() || (u.Host == "" && u.Opaque == "") {
+		return fmt.Errorf("URL cannot represent %q: not an absolute URL", s)
+	}
+	return u
+}
+
+// Email represents e-mail addresses as defined by RFC 5322, without display
+// name, e.g. "gopher@example.com".
+var Email = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Email",
+	Description:    "The `Email` scalar type represents e-mail addresses as defined by RFC 5322.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc5322#section-3.4.1",
+	Serialize:      coerceEmail,
+	ParseValue:     coerceEmail,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return coerceEmail(valueAST.Value)
+		}
+		return literalError("Email", valueAST)
+	},
+})
+
+func coerceEmail(value interface{}) interface{} {
+	s, ok := stringValue(value)
+	if !ok {
+		return fmt.Errorf("Email cannot represent non-string value: %v", value)
+	}
+	address, err := mail.ParseAddress(s)
+	if err != nil {
+		return fmt.Errorf("Email cannot represent %q: %v", s, strings.TrimPrefix(err.Error(), "mail: "))
+	}
+	if address.Name != "" || address.Address != s {
+		return fmt.Errorf("Email cannot represent %q: expected a bare address", s)
+	}
+	return s
+}
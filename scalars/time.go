@@ -0,0 +1,248 @@
+package scalars
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05.999999999"
+)
+
+// Date represents calendar dates in the RFC 3339 full-date format, e.g.
+// "2006-01-02". Input values are parsed to time.Time values at midnight UTC;
+// the time of day and location of serialized time.Time values are ignored.
+var Date = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Date",
+	Description:    "The `Date` scalar type represents calendar dates such as \"2006-01-02\", as defined by RFC 3339.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc3339#section-5.6",
+	Serialize: func(value interface{}) interface{} {
+		return formatTime("Date", dateLayout, value)
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return parseTime("Date", dateLayout, value)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return parseTime("Date", dateLayout, valueAST.Value)
+		}
+		return literalError("Date", valueAST)
+	},
+})
+
+// Time represents times of day in the RFC 3339 partial-time format, e.g.
+// "15:04:05" or "15:04:05.123". Input values are parsed to time.Time values
+// on January 1, year 0, UTC; only the clock of serialized time.Time values is
+// used.
+var Time = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Time",
+	Description:    "The `Time` scalar type represents times of day such as \"15:04:05\", as defined by RFC 3339.",
+	SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc3339#section-5.6",
+	Serialize: func(value interface{}) interface{} {
+		return formatTime("Time", timeLayout, value)
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return parseTime("Time", timeLayout, value)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return parseTime("Time", timeLayout, valueAST.Value)
+		}
+		return literalError("Time", valueAST)
+	},
+})
+
+func formatTime(name, layout string, value interface{}) interface{} {
+	switch value := indirect(value).(type) {
+	case time.Time:
+		return value.Format(layout)
+	case string:
+		parsed := parseTime(name, layout, value)
+		if t, ok := parsed.(time.Time); ok {
+			return t.Format(layout)
+		}
+		return parsed
+	}
+	return fmt.Errorf("%v cannot represent value: %v", name, value)
+}
+
+func parseTime(name, layout string, value interface{}) interface{} {
+	switch value := indirect(value).(type) {
+	case time.Time:
+		t, _ := time.Parse(layout, value.Format(layout))
+		return t
+	case string:
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			return fmt.Errorf("%v cannot represent %q: %v", name, value, timeParseError(err))
+		}
+		return t
+	}
+	return fmt.Errorf("%v cannot represent non-string value: %v", name, value)
+}
+
+// timeParseError drops the layout from the errors of time.Parse, which means
+// nothing to API clients.
+func timeParseError(err error) string {
+	if err, ok := err.(*time.ParseError); ok && err.Message != "" {
+		return strings.TrimPrefix(err.Message, ": ")
+	}
+	return "invalid format"
+}
+
+// Duration represents time.Duration values in the ISO 8601 duration format
+// restricted to days, hours, minutes and seconds, e.g. "PT1H30M" or
+// "P1DT0.5S". Years, months and weeks are rejected since their length
+// varies. Days are 24 hours long.
+var Duration = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Duration",
+	Description:    "The `Duration` scalar type represents durations such as \"PT1H30M\", as defined by ISO 8601.",
+	SpecifiedByURL: "https://en.wikipedia.org/wiki/ISO_8601#Durations",
+	Serialize: func(value interface{}) interface{} {
+		d := coerceDuration(value)
+		if d, ok := d.(time.Duration); ok {
+			return formatDuration(d)
+		}
+		return d
+	},
+	ParseValue: coerceDuration,
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		if valueAST, ok := valueAST.(*ast.StringValue); ok {
+			return coerceDuration(valueAST.Value)
+		}
+		return literalError("Duration", valueAST)
+	},
+})
+
+func coerceDuration(value interface{}) interface{} {
+	switch value := indirect(value).(type) {
+	case time.Duration:
+		return value
+	case string:
+		d, err := parseDuration(value)
+		if err != nil {
+			return fmt.Errorf("Duration cannot represent %q: %v", value, err)
+		}
+		return d
+	}
+	return fmt.Errorf("Duration cannot represent non-string value: %v", value)
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	rest := s
+	negative := strings.HasPrefix(rest, "-")
+	rest = strings.TrimPrefix(rest, "-")
+	if !strings.HasPrefix(rest, "P") {
+		return 0, fmt.Errorf(`expected "P" designator`)
+	}
+	rest = rest[1:]
+	if rest == "" {
+		return 0, fmt.Errorf("missing duration components")
+	}
+
+	var total float64
+	inTime := false
+	last := -1
+	units := []struct {
+		designator byte
+		time       bool
+		size       time.Duration
+	}{
+		{'D', false, 24 * time.Hour},
+		{'H', true, time.Hour},
+		{'M', true, time.Minute},
+		{'S', true, time.Second},
+	}
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime {
+				return 0, fmt.Errorf(`unexpected "T" designator`)
+			}
+			inTime = true
+			rest = rest[1:]
+			if rest == "" {
+				return 0, fmt.Errorf(`missing time components after "T"`)
+			}
+			continue
+		}
+		end := strings.IndexFunc(rest, func(r rune) bool {
+			return !('0' <= r && r <= '9' || r == '.' || r == ',')
+		})
+		if end <= 0 {
+			return 0, fmt.Errorf("expected a number at %q", rest)
+		}
+		number, err := strconv.ParseFloat(strings.Replace(rest[:end], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q", rest[:end])
+		}
+		designator := rest[end]
+		rest = rest[end+1:]
+
+		unit := -1
+		for i, u := range units {
+			if u.designator == designator && u.time == inTime {
+				unit = i
+			}
+		}
+		if unit < 0 {
+			switch {
+			case designator == 'Y' || designator == 'W' || (designator == 'M' && !inTime):
+				return 0, fmt.Errorf("years, months and weeks are not supported")
+			default:
+				return 0, fmt.Errorf("unexpected designator %q", designator)
+			}
+		}
+		if unit <= last {
+			return 0, fmt.Errorf("designator %q out of order", designator)
+		}
+		last = unit
+		total += number * float64(units[unit].size)
+	}
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("value out of range")
+	}
+	if negative {
+		total = -total
+	}
+	return time.Duration(math.Round(total)), nil
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteString("-")
+		d = -d
+	}
+	b.WriteString("P")
+	if days := d / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+		d -= days * 24 * time.Hour
+	}
+	if d == 0 {
+		return b.String()
+	}
+	b.WriteString("T")
+	if hours := d / time.Hour; hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+		d -= minutes * time.Minute
+	}
+	if d > 0 {
+		seconds := strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+		fmt.Fprintf(&b, "%vS", seconds)
+	}
+	return b.String()
+}
@@ -7,6 +7,10 @@ type SchemaConfig struct {
 	Types        []Type
 	Directives   []*Directive
 	Extensions   []Extension
+
+	// Visibility, if set, hides fields from the requests it returns false
+	// for. See SchemaVisibility.
+	Visibility SchemaVisibility
 }
 
 type TypeMap map[string]Type
@@ -41,6 +45,7 @@ type Schema struct {
 	implementations  map[string][]*Object
 	possibleTypeMap  map[string]map[string]bool
 	extensions       []Extension
+	visibility       SchemaVisibility
 }
 
 func NewSchema(config SchemaConfig) (Schema, error) {
@@ -139,6 +144,7 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	if len(config.Extensions) != 0 {
 		schema.extensions = config.Extensions
 	}
+	schema.visibility = config.Visibility
 
 	return schema, nil
 }
@@ -265,6 +271,17 @@ func (gq *Schema) AddExtensions(e ...Extension) {
 	gq.extensions = append(gq.extensions, e...)
 }
 
+// Extensions returns the extensions of the schema
+func (gq *Schema) Extensions() []Extension {
+	return gq.extensions
+}
+
+// Visibility returns the visibility hook of the schema, nil if all fields
+// are visible to all requests.
+func (gq *Schema) Visibility() SchemaVisibility {
+	return gq.visibility
+}
+
 // map-reduce
 func typeMapReducer(schema *Schema, typeMap TypeMap, objectType Type) (TypeMap, error) {
 	var err error
@@ -0,0 +1,324 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DoStream is like Do, but writes the JSON encoded result to w as the fields
+// complete instead of returning it. See ExecuteStream.
+func DoStream(p Params, w io.Writer) error {
+	AST, result := parseAndValidate(&p)
+	if result != nil {
+		return writeJSON(w, result)
+	}
+	return ExecuteStream(ExecuteParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           AST,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		Context:       p.Context,
+	}, w)
+}
+
+// ExecuteStream executes the operation like Execute, writing the JSON
+// encoded result to w without building the result data in memory. Fields are
+// resolved depth-first and written in selection order; errors and extension
+// results follow the data.
+//
+// A value is buffered only when a non-null field or list item inside it may
+// fail and bubble null up to it, and its size is then bounded by the subtree
+// of that value. Resolvers returning thunks are called as soon as their value
+// is written, so thunks are not batched across a level of the response as
+// with Execute.
+//
+// The results passed to the ExecutionFinishFunc of extensions have no data.
+// It returns the error of w, if any; GraphQL errors are part of the written
+// result.
+func ExecuteStream(p ExecuteParams, w io.Writer) error {
+	out := &streamWriter{w: w}
+
+	extErrs, executionFinishFn := handleExtensionsExecutionDidStart(&p)
+	if len(extErrs) != 0 {
+		return writeJSON(out, &Result{Errors: extErrs})
+	}
+
+	result := &Result{}
+	io.WriteString(out, `{"data":`)
+	func() {
+		defer func() {
+			if err := recover(); err != nil {
+				result.Errors = append(result.Errors, gqlerrors.FormatError(err.(error)))
+				io.WriteString(out, "null")
+			}
+		}()
+
+		eCtx, err := buildExecutionContext(buildExecutionCtxParams{
+			Schema:        p.Schema,
+			Root:          p.Root,
+			AST:           p.AST,
+			OperationName: p.OperationName,
+			Args:          p.Args,
+			Result:        result,
+			Context:       p.Context,
+		})
+		if err != nil {
+			panic(err)
+		}
+		defer func() {
+			result.Errors = append(eCtx.Errors, result.Errors...)
+		}()
+
+		operationType, err := getOperationRootType(eCtx.Schema, eCtx.Operation)
+		if err != nil {
+			panic(err)
+		}
+		fields := collectFields(collectFieldsParams{
+			ExeContext:   eCtx,
+			RuntimeType:  operationType,
+			SelectionSet: eCtx.Operation.GetSelectionSet(),
+		})
+
+		s := &streamExecutor{eCtx: eCtx, nullable: map[nullableKey]bool{}}
+		if s.hasNonNullField(operationType, fields) {
+			var buf bytes.Buffer
+			s.writeFields(&buf, operationType, p.Root, fields, nil)
+			out.Write(buf.Bytes())
+			return
+		}
+		s.writeFields(out, operationType, p.Root, fields, nil)
+	}()
+
+	extErrs = executionFinishFn(result)
+	result.Errors = append(result.Errors, extErrs...)
+	addExtensionResults(&p, result)
+
+	if len(result.Errors) > 0 {
+		io.WriteString(out, `,"errors":`)
+		writeJSON(out, result.Errors)
+	}
+	if len(result.Extensions) > 0 {
+		io.WriteString(out, `,"extensions":`)
+		writeJSON(out, result.Extensions)
+	}
+	io.WriteString(out, "}")
+	return out.err
+}
+
+func writeJSON(w io.Writer, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// streamWriter keeps the first error of w, ignoring later writes.
+type streamWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (sw *streamWriter) Write(p []byte) (int, error) {
+	if sw.err != nil {
+		return 0, sw.err
+	}
+	n, err := sw.w.Write(p)
+	sw.err = err
+	return n, err
+}
+
+type nullableKey struct {
+	ttype    *Object
+	fieldAST *ast.Field
+	n        int
+}
+
+// streamExecutor writes the completed values of the fields. Errors are
+// handled as by completeValueCatchingError: they are panicked up to the
+// nearest nullable position, which is written as null. Nullable positions
+// which may be reached by such errors after their value has started being
+// written are buffered.
+type streamExecutor struct {
+	eCtx *executionContext
+	// nullable memoizes hasNonNullSubField
+	nullable map[nullableKey]bool
+}
+
+func (s *streamExecutor) writeFields(w io.Writer, parentType *Object, source interface{}, fields map[string][]*ast.Field, path *ResponsePath) {
+	if source == nil {
+		source = map[string]interface{}{}
+	}
+	io.WriteString(w, "{")
+	written := 0
+	for _, orderedField := range orderedFields(fields) {
+		fieldDef := getFieldDef(s.eCtx.Context, s.eCtx.Schema, parentType, fieldASTName(orderedField.fieldASTs[0]))
+		if fieldDef == nil {
+			continue
+		}
+		if written > 0 {
+			io.WriteString(w, ",")
+		}
+		written++
+		writeJSON(w, orderedField.responseName)
+		io.WriteString(w, ":")
+		s.writeField(w, parentType, source, fieldDef, orderedField.fieldASTs, path.WithKey(orderedField.responseName))
+	}
+	io.WriteString(w, "}")
+}
+
+func (s *streamExecutor) writeField(w io.Writer, parentType *Object, source interface{}, fieldDef *FieldDefinition, fieldASTs []*ast.Field, path *ResponsePath) {
+	// catch panic from resolveFn
+	defer func() {
+		if r := recover(); r != nil {
+			handleFieldError(r, FieldASTsToNodeASTs(fieldASTs), path, fieldDef.Type, s.eCtx)
+			io.WriteString(w, "null")
+		}
+	}()
+	info, result := resolveFieldValue(s.eCtx, fieldDef, parentType, source, fieldASTs, path)
+	s.writeValueCatchingError(w, fieldDef.Type, fieldASTs, info, path, result)
+}
+
+func (s *streamExecutor) writeValueCatchingError(w io.Writer, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result interface{}) {
+	out := w
+	var buf *bytes.Buffer
+	if _, ok := returnType.(*NonNull); !ok && s.mayBubbleNull(returnType, fieldASTs) {
+		buf = &bytes.Buffer{}
+		out = buf
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			// panics again if returnType is non-null
+			handleFieldError(r, FieldASTsToNodeASTs(fieldASTs), path, returnType, s.eCtx)
+			io.WriteString(w, "null")
+		}
+	}()
+	s.writeValue(out, returnType, fieldASTs, info, path, result)
+	if buf != nil {
+		w.Write(buf.Bytes())
+	}
+}
+
+// writeValue is the streaming version of completeValue.
+func (s *streamExecutor) writeValue(w io.Writer, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result interface{}) {
+	for isThunk(result) {
+		result = callThunk(result)
+	}
+
+	nonNull := false
+	if t, ok := returnType.(*NonNull); ok {
+		returnType = t.OfType
+		nonNull = true
+	}
+	writeNull := func() {
+		if nonNull {
+			err := NewLocatedErrorWithPath(
+				fmt.Sprintf("Cannot return null for non-nullable field %v.%v.", info.ParentType, info.FieldName),
+				FieldASTsToNodeASTs(fieldASTs),
+				path.AsArray(),
+			)
+			panic(gqlerrors.FormatError(err))
+		}
+		io.WriteString(w, "null")
+	}
+	if isNullish(result) {
+		writeNull()
+		return
+	}
+
+	switch returnType := returnType.(type) {
+	case *List:
+		resultVal := listValue(info, result)
+		io.WriteString(w, "[")
+		for i := 0; i < resultVal.Len(); i++ {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			s.writeValueCatchingError(w, returnType.OfType, fieldASTs, info, path.WithKey(i), resultVal.Index(i).Interface())
+		}
+		io.WriteString(w, "]")
+	case *Scalar, *Enum:
+		serialized := completeLeafValue(returnType.(Leaf), result)
+		if err, ok := serialized.(error); ok {
+			err := NewLocatedErrorWithPath(err, FieldASTsToNodeASTs(fieldASTs), path.AsArray())
+			panic(gqlerrors.FormatError(err))
+		}
+		if serialized == nil {
+			writeNull()
+			return
+		}
+		b, err := json.Marshal(serialized)
+		if err != nil {
+			panic(err)
+		}
+		w.Write(b)
+	case *Union, *Interface:
+		runtimeType := resolveRuntimeType(s.eCtx, returnType.(Abstract), info, result)
+		s.writeObject(w, runtimeType, fieldASTs, info, path, result)
+	case *Object:
+		s.writeObject(w, returnType, fieldASTs, info, path, result)
+	default:
+		err := invariantf(false, `Cannot complete value of unexpected type "%v."`, returnType)
+		panic(gqlerrors.FormatError(err))
+	}
+}
+
+func (s *streamExecutor) writeObject(w io.Writer, returnType *Object, fieldASTs []*ast.Field, info ResolveInfo, path *ResponsePath, result interface{}) {
+	checkIsTypeOf(s.eCtx, returnType, info, result)
+	s.writeFields(w, returnType, result, collectSubFields(s.eCtx, returnType, fieldASTs), path)
+}
+
+func isThunk(result interface{}) bool {
+	resultVal := reflect.ValueOf(result)
+	return resultVal.IsValid() && resultVal.Kind() == reflect.Func
+}
+
+// mayBubbleNull reports whether a null may bubble up to a nullable value of
+// returnType from a non-null list item or field inside it.
+func (s *streamExecutor) mayBubbleNull(returnType Type, fieldASTs []*ast.Field) bool {
+	switch returnType := returnType.(type) {
+	case *List:
+		_, ok := returnType.OfType.(*NonNull)
+		return ok
+	case *Object:
+		return s.hasNonNullSubField(returnType, fieldASTs)
+	case Abstract:
+		for _, possibleType := range s.eCtx.Schema.PossibleTypes(returnType) {
+			if s.hasNonNullSubField(possibleType, fieldASTs) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *streamExecutor) hasNonNullSubField(returnType *Object, fieldASTs []*ast.Field) bool {
+	key := nullableKey{returnType, fieldASTs[0], len(fieldASTs)}
+	if nonNull, ok := s.nullable[key]; ok {
+		return nonNull
+	}
+	nonNull := s.hasNonNullField(returnType, collectSubFields(s.eCtx, returnType, fieldASTs))
+	s.nullable[key] = nonNull
+	return nonNull
+}
+
+func (s *streamExecutor) hasNonNullField(parentType *Object, fields map[string][]*ast.Field) bool {
+	for _, fieldASTs := range fields {
+		fieldDef := getFieldDef(s.eCtx.Context, s.eCtx.Schema, parentType, fieldASTName(fieldASTs[0]))
+		if fieldDef == nil {
+			continue
+		}
+		if _, ok := fieldDef.Type.(*NonNull); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+package graphql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func streamTestSchema(t *testing.T, w *bytes.Buffer) graphql.Schema {
+	var itemType *graphql.Object
+	itemType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name": &graphql.Field{Type: graphql.String},
+				"written": &graphql.Field{
+					Type: graphql.Int,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						if w == nil {
+							return nil, nil
+						}
+						return w.Len(), nil
+					},
+				},
+				"error": &graphql.Field{
+					Type: graphql.String,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("nullable error")
+					},
+				},
+				"nonNullError": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.String),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return nil, errors.New("non-null error")
+					},
+				},
+				"thunk": &graphql.Field{
+					Type: itemType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return func() (interface{}, error) {
+							return map[string]interface{}{"id": "t", "name": "thunk"}, nil
+						}, nil
+					},
+				},
+				"nullItems": &graphql.Field{
+					Type: graphql.NewList(graphql.NewNonNull(itemType)),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{map[string]interface{}{"id": "x"}, nil}, nil
+					},
+				},
+			}
+		}),
+	})
+	var node = graphql.NewInterface(graphql.InterfaceConfig{
+		Name: "Node",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		},
+		ResolveType: func(p graphql.ResolveTypeParams) *graphql.Object {
+			return itemType
+		},
+	})
+	itemType.AddFieldConfig("self", &graphql.Field{
+		Type: node,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source, nil
+		},
+	})
+
+	items := func(p graphql.ResolveParams) (interface{}, error) {
+		return []interface{}{
+			map[string]interface{}{"id": "1", "name": "one"},
+			map[string]interface{}{"id": "2", "name": "<two>"},
+			map[string]interface{}{"id": "3"},
+		}, nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{Type: graphql.NewList(itemType), Resolve: items},
+				"nonNullItems": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(itemType))),
+					Resolve: items,
+				},
+				"nodes": &graphql.Field{Type: graphql.NewList(node), Resolve: items},
+			},
+		}),
+		Types: []graphql.Type{itemType},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+type streamTestResult struct {
+	Data   json.RawMessage          `json:"data"`
+	Errors []map[string]interface{} `json:"errors"`
+}
+
+func decodeStreamTestResult(t *testing.T, b []byte) streamTestResult {
+	var result streamTestResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	sort.Slice(result.Errors, func(i, j int) bool {
+		return errorPath(result.Errors[i]) < errorPath(result.Errors[j])
+	})
+	return result
+}
+
+func errorPath(err map[string]interface{}) string {
+	b, _ := json.Marshal(err["path"])
+	return string(b)
+}
+
+func TestDoStream_MatchesDo(t *testing.T) {
+	schema := streamTestSchema(t, nil)
+	queries := []string{
+		`{ items { id name thunk { name } } nodes { id ... on Item { name } } }`,
+		`{ items { id error } }`,
+		`{ items { id nonNullError } }`,
+		`{ nonNullItems { id nonNullError } }`,
+		`{ items { id nullItems { id } } }`,
+		`{ items { self { id ... on Item { nonNullError } } } }`,
+		`{ items { id } nonNullItems { name nonNullError } }`,
+		`query { unknownField }`,
+		`query A { items { id } } query B { items { name } }`,
+	}
+	for _, query := range queries {
+		params := graphql.Params{
+			Schema:             schema,
+			RequestString:      query,
+			PreserveFieldOrder: true,
+		}
+		expected, err := json.Marshal(graphql.Do(params))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := graphql.DoStream(params, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want, got := decodeStreamTestResult(t, expected), decodeStreamTestResult(t, buf.Bytes())
+		if string(want.Data) != string(got.Data) {
+			t.Fatalf("%v: unexpected data:\n%s\nexpected:\n%s", query, got.Data, want.Data)
+		}
+		if !reflect.DeepEqual(want.Errors, got.Errors) {
+			t.Fatalf("%v: Unexpected errors, Diff: %v", query, testutil.Diff(want.Errors, got.Errors))
+		}
+	}
+}
+
+func TestDoStream_WritesBeforeCompletion(t *testing.T) {
+	var buf bytes.Buffer
+	err := graphql.DoStream(graphql.Params{
+		Schema:        streamTestSchema(t, &buf),
+		RequestString: `{ items { name written } }`,
+	}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// written is the length of the output when the field is resolved
+	expected := `{"data":{"items":[{"name":"one","written":42},{"name":"\u003ctwo\u003e","written":82},{"name":null,"written":109}]}}`
+	if buf.String() != expected {
+		t.Fatalf("unexpected JSON:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestDoStream_BuffersNonNullBoundaries(t *testing.T) {
+	var buf bytes.Buffer
+	err := graphql.DoStream(graphql.Params{
+		Schema:        streamTestSchema(t, &buf),
+		RequestString: `{ items { id written } }`,
+	}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// items have a non-null field: each item is buffered, then written
+	// before the next one is resolved
+	expected := `{"data":{"items":[{"id":"1","written":18},{"id":"2","written":42},{"id":"3","written":66}]}}`
+	if buf.String() != expected {
+		t.Fatalf("unexpected JSON:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("closed")
+}
+
+func TestDoStream_ReturnsWriterErrors(t *testing.T) {
+	err := graphql.DoStream(graphql.Params{
+		Schema:        streamTestSchema(t, nil),
+		RequestString: `{ items { name } }`,
+	}, failingWriter{})
+	if err == nil || err.Error() != "closed" {
+		t.Fatalf("expected writer error, got %v", err)
+	}
+}
@@ -34,7 +34,10 @@ func Subscribe(p Params) chan *Result {
 	// TODO run extensions hooks
 
 	// parse the source
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	AST, err := parser.Parse(parser.ParseParams{
+		Source:  source,
+		Options: parseOptions(&p),
+	})
 	if err != nil {
 
 		// merge the errors from extensions and the original error from parser
@@ -43,8 +46,8 @@ func Subscribe(p Params) chan *Result {
 		})
 	}
 
-	// validate document
-	validationResult := ValidateDocument(&p.Schema, AST, nil)
+	// validate document, hiding the fields invisible to the request
+	validationResult := ValidateDocumentWithContext(p.Context, &p.Schema, AST, p.ValidationRules)
 
 	if !validationResult.IsValid {
 		// run validation finish functions for extensions
@@ -60,6 +63,8 @@ func Subscribe(p Params) chan *Result {
 		OperationName: p.OperationName,
 		Args:          p.VariableValues,
 		Context:       p.Context,
+
+		PreserveFieldOrder: p.PreserveFieldOrder,
 	})
 }
 
@@ -86,6 +91,8 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 			OperationName: p.OperationName,
 			Args:          p.Args,
 			Context:       p.Context,
+
+			PreserveFieldOrder: p.PreserveFieldOrder,
 		})
 	}
 	var resultChannel = make(chan *Result)
@@ -144,7 +151,7 @@ func ExecuteSubscription(p ExecuteParams) chan *Result {
 		fieldNodes := fields[responseName]
 		fieldNode := fieldNodes[0]
 		fieldName := fieldNode.Name.Value
-		fieldDef := getFieldDef(p.Schema, operationType, fieldName)
+		fieldDef := getFieldDef(exeContext.Context, p.Schema, operationType, fieldName)
 
 		if fieldDef == nil {
 			resultChannel <- &Result{
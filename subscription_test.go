@@ -87,7 +87,10 @@ func TestSchemaSubscribe(t *testing.T) {
 				}
 			`,
 			ExpectedResults: []testutil.TestResponse{
-				{Errors: []string{"Cannot query field \"xxx\" on type \"Subscription\"."}},
+				{Errors: []string{
+					"Anonymous Subscription must select only one top level field.",
+					"Cannot query field \"xxx\" on type \"Subscription\".",
+				}},
 			},
 		},
 		{
@@ -0,0 +1,154 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/internal/diff"
+)
+
+// UpdateEnv is the environment variable making RunGolden write the golden
+// files when set to a true value such as "1".
+const UpdateEnv = "GRAPHQL_UPDATE_GOLDEN"
+
+// updating reports whether RunGolden writes the golden files instead of
+// comparing the results with them. The package does not register the
+// -update flag, which would conflict with the flags of the packages importing
+// it, but uses the one they define, if any.
+func updating() bool {
+	if update, err := strconv.ParseBool(os.Getenv(UpdateEnv)); err == nil && update {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if getter, ok := f.Value.(flag.Getter); ok {
+			update, _ := getter.Get().(bool)
+			return update
+		}
+	}
+	return false
+}
+
+// RunGolden executes each *.graphql file of dir against schema, as a
+// subtest named after the file. The variables of the request are read from
+// the *.vars.json file of the same name, if any. The result, data, errors
+// and extensions, is printed as indented JSON with sorted object keys, and
+// compared with the *.golden.json file of the same name, the differences
+// being reported as a unified diff.
+//
+// Running the tests with GRAPHQL_UPDATE_GOLDEN=1 in the environment writes
+// the golden files instead, as does the boolean -update flag if the test
+// package defines it:
+//
+//	GRAPHQL_UPDATE_GOLDEN=1 go test -run TestQueries
+func RunGolden(t *testing.T, schema graphql.Schema, dir string) {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.graphql"))
+	if err != nil {
+		t.Fatalf("testutil: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("testutil: no .graphql files in %v", dir)
+	}
+	for _, path := range paths {
+		base := strings.TrimSuffix(path, ".graphql")
+		t.Run(filepath.Base(base), func(t *testing.T) {
+			runGolden(t, schema, base)
+		})
+	}
+}
+
+func runGolden(t *testing.T, schema graphql.Schema, base string) {
+	query, err := ioutil.ReadFile(base + ".graphql")
+	if err != nil {
+		t.Fatalf("testutil: %v", err)
+	}
+	var variables map[string]interface{}
+	if vars, err := ioutil.ReadFile(base + ".vars.json"); err == nil {
+		if err := json.Unmarshal(vars, &variables); err != nil {
+			t.Fatalf("testutil: invalid variables in %v.vars.json: %v", base, err)
+		}
+	} else if !os.IsNotExist(err) {
+		t.Fatalf("testutil: %v", err)
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  string(query),
+		VariableValues: variables,
+	})
+	actual, err := canonicalJSON(result)
+	if err != nil {
+		t.Fatalf("testutil: cannot marshal the result: %v", err)
+	}
+
+	golden := base + ".golden.json"
+	if updating() {
+		if err := ioutil.WriteFile(golden, actual, 0644); err != nil {
+			t.Fatalf("testutil: %v", err)
+		}
+		return
+	}
+	expected, err := ioutil.ReadFile(golden)
+	if os.IsNotExist(err) {
+		t.Fatalf("testutil: missing %v, run the tests with %v=1 to write it", golden, UpdateEnv)
+	}
+	if err != nil {
+		t.Fatalf("testutil: %v", err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("testutil: the result differs from %v, run the tests with %v=1 to accept it:\n%s",
+			golden, UpdateEnv, diff.Unified(golden, "result", string(expected), string(actual)))
+	}
+}
+
+// canonicalJSON returns the indented JSON of result, without escaping HTML
+// characters for readability. The errors are sorted by location and path,
+// since the fields of an object are executed in no particular order.
+func canonicalJSON(result *graphql.Result) ([]byte, error) {
+	sorted := *result
+	sorted.Errors = append([]gqlerrors.FormattedError{}, result.Errors...)
+	sort.SliceStable(sorted.Errors, func(i, j int) bool {
+		return errorSortKey(sorted.Errors[i]) < errorSortKey(sorted.Errors[j])
+	})
+	if len(result.Errors) == 0 {
+		sorted.Errors = result.Errors
+	}
+	result = &sorted
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// errorSortKey orders errors by their first location, then by path and
+// message.
+func errorSortKey(err gqlerrors.FormattedError) string {
+	key := ""
+	if len(err.Locations) > 0 {
+		key = fmt.Sprintf("%010d:%010d", err.Locations[0].Line, err.Locations[0].Column)
+	}
+	for _, segment := range err.Path {
+		if index, ok := segment.(int); ok {
+			key += fmt.Sprintf("/%010d", index)
+		} else {
+			key += fmt.Sprintf("/%v", segment)
+		}
+	}
+	return key + "\x00" + err.Message
+}
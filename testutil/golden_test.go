@@ -0,0 +1,88 @@
+package testutil_test
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// update is defined by the test package, as RunGolden does not define it.
+var update = flag.Bool("update", false, "write the golden files")
+
+func TestRunGolden(t *testing.T) {
+	testutil.RunGolden(t, testutil.StarWarsSchema, "testdata/golden")
+}
+
+func TestRunGolden_SortsErrors(t *testing.T) {
+	fields := graphql.Fields{}
+	for _, name := range []string{"a", "b", "c"} {
+		message := name + " failed"
+		fields[name] = &graphql.Field{
+			Type: graphql.String,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return nil, errors.New(message)
+			},
+		}
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{Name: "Query", Fields: fields}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the fields are executed in a different order each time
+	for i := 0; i < 20; i++ {
+		testutil.RunGolden(t, schema, "testdata/errors")
+	}
+}
+
+// writeGoldenQuery writes the query of a golden file to a temporary
+// directory, and returns the directory.
+func writeGoldenQuery(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "golden")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "hero.graphql"), []byte(`{ hero { name } }`), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return dir
+}
+
+func checkGoldenFile(t *testing.T, dir string) {
+	golden, err := ioutil.ReadFile(filepath.Join(dir, "hero.golden.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "{\n  \"data\": {\n    \"hero\": {\n      \"name\": \"R2-D2\"\n    }\n  }\n}\n"
+	if string(golden) != expected {
+		t.Fatalf("expected %q, got %q", expected, golden)
+	}
+	testutil.RunGolden(t, testutil.StarWarsSchema, dir)
+}
+
+func TestRunGolden_UpdatesGoldenFiles(t *testing.T) {
+	dir := writeGoldenQuery(t)
+	defer os.RemoveAll(dir)
+
+	os.Setenv(testutil.UpdateEnv, "1")
+	testutil.RunGolden(t, testutil.StarWarsSchema, dir)
+	os.Unsetenv(testutil.UpdateEnv)
+	checkGoldenFile(t, dir)
+}
+
+func TestRunGolden_UpdatesGoldenFilesWithTheUpdateFlag(t *testing.T) {
+	dir := writeGoldenQuery(t)
+	defer os.RemoveAll(dir)
+
+	*update = true
+	testutil.RunGolden(t, testutil.StarWarsSchema, dir)
+	*update = false
+	checkGoldenFile(t, dir)
+}
@@ -55,6 +55,7 @@ var IntrospectionQuery = `
     possibleTypes {
       ...TypeRef
     }
+    specifiedByURL
   }
 
   fragment InputValue on __InputValue {
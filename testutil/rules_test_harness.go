@@ -603,6 +603,43 @@ func ExpectFailsRuleWithSchema(t *testing.T, schema *graphql.Schema, rule graphq
 func ExpectPassesRuleWithSchema(t *testing.T, schema *graphql.Schema, rule graphql.ValidationRuleFn, queryString string) {
 	expectValidRule(t, schema, []graphql.ValidationRuleFn{rule}, queryString)
 }
+func expectSDLErrors(t *testing.T, schema *graphql.Schema, rule graphql.ValidationRuleFn, sdlString string, expectedErrors []gqlerrors.FormattedError) {
+	source := source.NewSource(&source.Source{
+		Body: []byte(sdlString),
+	})
+	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errors := graphql.VisitSDLUsingRules(schema, AST, []graphql.ValidationRuleFn{rule})
+	if len(errors) != len(expectedErrors) {
+		t.Fatalf("Should have %v errors, got %v: %v", len(expectedErrors), len(errors), errors)
+	}
+	for _, expectedErr := range expectedErrors {
+		found := false
+		for _, err := range errors {
+			if EqualFormattedError(expectedErr, err) {
+				found = true
+				break
+			}
+		}
+		if found == false {
+			t.Fatalf("Unexpected result, Diff: %v", Diff(expectedErrors, errors))
+		}
+	}
+}
+func ExpectPassesSDLRule(t *testing.T, rule graphql.ValidationRuleFn, sdlString string) {
+	expectSDLErrors(t, nil, rule, sdlString, nil)
+}
+func ExpectFailsSDLRule(t *testing.T, rule graphql.ValidationRuleFn, sdlString string, expectedErrors []gqlerrors.FormattedError) {
+	expectSDLErrors(t, nil, rule, sdlString, expectedErrors)
+}
+func ExpectPassesSDLRuleWithSchema(t *testing.T, schema *graphql.Schema, rule graphql.ValidationRuleFn, sdlString string) {
+	expectSDLErrors(t, schema, rule, sdlString, nil)
+}
+func ExpectFailsSDLRuleWithSchema(t *testing.T, schema *graphql.Schema, rule graphql.ValidationRuleFn, sdlString string, expectedErrors []gqlerrors.FormattedError) {
+	expectSDLErrors(t, schema, rule, sdlString, expectedErrors)
+}
 func RuleError(message string, locs ...int) gqlerrors.FormattedError {
 	locations := []location.SourceLocation{}
 	for i := 0; i < len(locs); i += 2 {
@@ -1,6 +1,9 @@
 package graphql
 
 import (
+	"bytes"
+	"encoding/json"
+
 	"github.com/graphql-go/graphql/gqlerrors"
 )
 
@@ -17,3 +20,76 @@ type Result struct {
 func (r *Result) HasErrors() bool {
 	return len(r.Errors) > 0
 }
+
+// OrderedMap is an object of the data of results executed with
+// PreserveFieldOrder: its keys are the response names of the fields in
+// selection order, and it is marshaled to JSON in that order.
+type OrderedMap struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// Get returns the value of a response name.
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	value, ok := m.Values[key]
+	return value, ok
+}
+
+// Len returns the number of keys.
+func (m *OrderedMap) Len() int {
+	return len(m.Keys)
+}
+
+// ToMap converts m, and the ordered maps nested in its values, to
+// map[string]interface{} as returned without PreserveFieldOrder.
+func (m *OrderedMap) ToMap() map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(m.Values))
+	for key, value := range m.Values {
+		result[key] = unorderValue(value)
+	}
+	return result
+}
+
+func unorderValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case *OrderedMap:
+		return value.ToMap()
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, item := range value {
+			list[i] = unorderValue(item)
+		}
+		return list
+	}
+	return value
+}
+
+// MarshalJSON implements json.Marshaler, writing the keys in order.
+func (m *OrderedMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,94 @@
+package upload
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// Handler serves a schema over HTTP. It executes GraphQL requests sent as
+// JSON POST bodies and as multipart requests with files, removing the files
+// once the response has been written.
+type Handler struct {
+	Schema graphql.Schema
+	Limits Limits
+	// RootObject is the root value of the executed operations.
+	RootObject map[string]interface{}
+}
+
+// NewHandler returns a handler serving schema.
+func NewHandler(schema graphql.Schema, limits Limits) *Handler {
+	return &Handler{
+		Schema: schema,
+		Limits: limits,
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, &Error{StatusCode: http.StatusMethodNotAllowed, Message: "GraphQL requests must be sent with POST"})
+		return
+	}
+
+	var requests []*Request
+	var batch bool
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		form, err := ParseMultipartForm(r, h.Limits)
+		if err != nil {
+			writeError(w, err.(*Error))
+			return
+		}
+		defer form.RemoveAll()
+		requests, batch = form.Requests, form.Batch
+	} else {
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, h.Limits.maxBodySize()+1))
+		if err != nil {
+			writeError(w, badRequest("Cannot read request body: %v", err))
+			return
+		}
+		if int64(len(body)) > h.Limits.maxBodySize() {
+			writeError(w, tooLarge("Request body exceeds the maximum size of %v bytes", h.Limits.maxBodySize()))
+			return
+		}
+		requests, batch, err = decodeRequests(body)
+		if err != nil {
+			writeError(w, err.(*Error))
+			return
+		}
+	}
+
+	results := make([]*graphql.Result, len(requests))
+	for i, request := range requests {
+		results[i] = graphql.Do(graphql.Params{
+			Schema:         h.Schema,
+			RequestString:  request.Query,
+			RootObject:     h.RootObject,
+			VariableValues: request.Variables,
+			OperationName:  request.OperationName,
+			Context:        r.Context(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if batch {
+		json.NewEncoder(w).Encode(results)
+		return
+	}
+	json.NewEncoder(w).Encode(results[0])
+}
+
+func writeError(w http.ResponseWriter, err *Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.StatusCode)
+	json.NewEncoder(w).Encode(&graphql.Result{
+		Errors: []gqlerrors.FormattedError{{Message: err.Message}},
+	})
+}
@@ -0,0 +1,297 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultMaxFiles is the number of files accepted per request when
+	// Limits.MaxFiles is zero.
+	DefaultMaxFiles = 10
+	// DefaultMaxFileSize is the size in bytes accepted per file when
+	// Limits.MaxFileSize is zero.
+	DefaultMaxFileSize = 32 << 20
+	// DefaultMaxBodySize is the size in bytes accepted for the body of JSON
+	// requests when Limits.MaxBodySize is zero.
+	DefaultMaxBodySize = 10 << 20
+
+	// maxFieldSize bounds the size of the `operations` and `map` fields.
+	maxFieldSize = 10 << 20
+)
+
+// Request is a GraphQL request, as sent in the body of JSON requests and in
+// the `operations` field of multipart requests.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Limits bound the files accepted in a multipart request and the size of JSON
+// requests. Zero values select the defaults.
+type Limits struct {
+	MaxFiles    int
+	MaxFileSize int64
+	MaxBodySize int64
+	// TempDir is the directory of the temporary files storing the uploads,
+	// os.TempDir() if empty.
+	TempDir string
+}
+
+func (l Limits) maxFiles() int {
+	if l.MaxFiles > 0 {
+		return l.MaxFiles
+	}
+	return DefaultMaxFiles
+}
+
+func (l Limits) maxFileSize() int64 {
+	if l.MaxFileSize > 0 {
+		return l.MaxFileSize
+	}
+	return DefaultMaxFileSize
+}
+
+func (l Limits) maxBodySize() int64 {
+	if l.MaxBodySize > 0 {
+		return l.MaxBodySize
+	}
+	return DefaultMaxBodySize
+}
+
+// Error is an invalid request, StatusCode is the HTTP status of the response
+// to send.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func badRequest(format string, a ...interface{}) *Error {
+	return &Error{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(format, a...)}
+}
+
+func tooLarge(format string, a ...interface{}) *Error {
+	return &Error{StatusCode: http.StatusRequestEntityTooLarge, Message: fmt.Sprintf(format, a...)}
+}
+
+// Form is a parsed multipart request. Its files must be removed with
+// RemoveAll once the requests have been executed.
+type Form struct {
+	// Requests holds the operations of the request, with the files set in
+	// their variables.
+	Requests []*Request
+	// Batch reports whether `operations` is a list of requests.
+	Batch bool
+	// Files maps the names of the file fields to their files.
+	Files map[string]*File
+}
+
+// RemoveAll closes and removes the temporary files of the form.
+func (f *Form) RemoveAll() error {
+	var err error
+	for _, file := range f.Files {
+		if e := file.remove(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// ParseMultipartForm reads a multipart request of the GraphQL multipart
+// request specification: the `operations` field, followed by the `map` field
+// then the files. Each file is stored in a temporary file and set in the
+// variables at the paths of its map entry, e.g. "variables.file" or, for
+// batches, "0.variables.files.1".
+//
+// The returned errors are of type *Error.
+func ParseMultipartForm(r *http.Request, limits Limits) (*Form, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, badRequest("Invalid multipart request: %v", err)
+	}
+	form := &Form{Files: map[string]*File{}}
+	if err := form.read(reader, limits); err != nil {
+		form.RemoveAll()
+		return nil, err
+	}
+	return form, nil
+}
+
+func (f *Form) read(reader *multipart.Reader, limits Limits) error {
+	operations, err := readField(reader, "operations")
+	if err != nil {
+		return err
+	}
+	if f.Requests, f.Batch, err = decodeRequests(operations); err != nil {
+		return err
+	}
+
+	mapField, err := readField(reader, "map")
+	if err != nil {
+		return err
+	}
+	fileMap := map[string][]string{}
+	if err := json.Unmarshal(mapField, &fileMap); err != nil {
+		return badRequest("Invalid map field: %v", err)
+	}
+	if len(fileMap) > limits.maxFiles() {
+		return tooLarge("Request exceeds the maximum of %v files", limits.maxFiles())
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return badRequest("Invalid multipart request: %v", err)
+		}
+		name := part.FormName()
+		paths, ok := fileMap[name]
+		if !ok {
+			return badRequest("Unexpected field %q, files must be listed in the map field", name)
+		}
+		if _, ok := f.Files[name]; ok {
+			return badRequest("Duplicate file field %q", name)
+		}
+		file, err := storeFile(part, limits)
+		if err != nil {
+			return err
+		}
+		file.Filename = part.FileName()
+		file.ContentType = part.Header.Get("Content-Type")
+		f.Files[name] = file
+		for _, path := range paths {
+			if err := setFile(f, path, file); err != nil {
+				return err
+			}
+		}
+	}
+	for name := range fileMap {
+		if _, ok := f.Files[name]; !ok {
+			return badRequest("File field %q is missing", name)
+		}
+	}
+	return nil
+}
+
+func readField(reader *multipart.Reader, name string) ([]byte, error) {
+	part, err := reader.NextPart()
+	if err != nil {
+		return nil, badRequest("Missing %v field: %v", name, err)
+	}
+	if part.FormName() != name {
+		return nil, badRequest("Expected the %v field, found %q", name, part.FormName())
+	}
+	value, err := ioutil.ReadAll(io.LimitReader(part, maxFieldSize+1))
+	if err != nil {
+		return nil, badRequest("Invalid %v field: %v", name, err)
+	}
+	if len(value) > maxFieldSize {
+		return nil, tooLarge("The %v field exceeds the maximum size of %v bytes", name, maxFieldSize)
+	}
+	return value, nil
+}
+
+func decodeRequests(body []byte) ([]*Request, bool, error) {
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var requests []*Request
+		if err := json.Unmarshal(trimmed, &requests); err != nil {
+			return nil, false, badRequest("Invalid operations: %v", err)
+		}
+		if len(requests) == 0 {
+			return nil, false, badRequest("Invalid operations: empty batch")
+		}
+		for _, request := range requests {
+			if request == nil {
+				return nil, false, badRequest("Invalid operations: null request")
+			}
+		}
+		return requests, true, nil
+	}
+	request := &Request{}
+	if err := json.Unmarshal(body, request); err != nil {
+		return nil, false, badRequest("Invalid operations: %v", err)
+	}
+	return []*Request{request}, false, nil
+}
+
+func storeFile(part io.Reader, limits Limits) (*File, error) {
+	tmp, err := ioutil.TempFile(limits.TempDir, "graphql-upload-")
+	if err != nil {
+		return nil, &Error{StatusCode: http.StatusInternalServerError, Message: "Cannot store file"}
+	}
+	file := &File{file: tmp}
+	size, err := io.Copy(tmp, io.LimitReader(part, limits.maxFileSize()+1))
+	if err == nil && size > limits.maxFileSize() {
+		file.remove()
+		return nil, tooLarge("File exceeds the maximum size of %v bytes", limits.maxFileSize())
+	}
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.remove()
+		return nil, badRequest("Cannot read file: %v", err)
+	}
+	file.Size = size
+	return file, nil
+}
+
+// setFile sets file in the variables of form at a map path.
+func setFile(form *Form, path string, file *File) error {
+	segments := strings.Split(path, ".")
+	request := form.Requests[0]
+	if form.Batch {
+		index, err := strconv.Atoi(segments[0])
+		if err != nil || index < 0 || index >= len(form.Requests) {
+			return badRequest("Invalid map path %q", path)
+		}
+		request = form.Requests[index]
+		segments = segments[1:]
+	}
+	if len(segments) < 2 || segments[0] != "variables" {
+		return badRequest("Invalid map path %q, files must be set in variables", path)
+	}
+	if request.Variables == nil {
+		request.Variables = map[string]interface{}{}
+	}
+
+	var parent interface{} = request.Variables
+	for i, segment := range segments[1:] {
+		last := i == len(segments)-2
+		switch value := parent.(type) {
+		case map[string]interface{}:
+			if last {
+				value[segment] = file
+				return nil
+			}
+			parent = value[segment]
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return badRequest("Invalid map path %q", path)
+			}
+			if last {
+				value[index] = file
+				return nil
+			}
+			parent = value[index]
+		default:
+			return badRequest("Invalid map path %q", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package upload implements file uploads following the GraphQL multipart
+// request specification, https://github.com/jaydenseric/graphql-multipart-request-spec.
+//
+// Files are declared as variables of the Upload scalar. ParseMultipartForm
+// reads the `operations`, `map` and file fields of a request and injects the
+// files into the variables of the operations; Handler serves a schema over
+// HTTP, accepting both JSON and multipart requests.
+package upload
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// File is an uploaded file, the value of an Upload argument. It reads the
+// content of the file, which is stored in a temporary file removed once the
+// request has been handled.
+type File struct {
+	Filename    string
+	ContentType string
+	Size        int64
+
+	file *os.File
+}
+
+// Read implements io.Reader.
+func (f *File) Read(p []byte) (int, error) {
+	return f.file.Read(p)
+}
+
+// ReadAt implements io.ReaderAt.
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	return f.file.ReadAt(p, off)
+}
+
+// Seek implements io.Seeker.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	return f.file.Seek(offset, whence)
+}
+
+func (f *File) remove() error {
+	f.file.Close()
+	return os.Remove(f.file.Name())
+}
+
+// Upload is the scalar of uploaded files. It is an input only type, the
+// values of its variables are set by ParseMultipartForm.
+var Upload = graphql.NewScalar(graphql.ScalarConfig{
+	Name:           "Upload",
+	Description:    "The `Upload` scalar type represents a file sent in a multipart request.",
+	SpecifiedByURL: "https://github.com/jaydenseric/graphql-multipart-request-spec",
+	Serialize: func(value interface{}) interface{} {
+		return fmt.Errorf("Upload cannot be used as an output type")
+	},
+	ParseValue: func(value interface{}) interface{} {
+		if file, ok := value.(*File); ok && file != nil {
+			return file
+		}
+		return fmt.Errorf("Upload value must be a file sent in a multipart request")
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return fmt.Errorf("Upload literals are not supported, send files as variables of a multipart request")
+	},
+})
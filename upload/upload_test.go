@@ -0,0 +1,329 @@
+package upload_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+	"github.com/graphql-go/graphql/upload"
+)
+
+var fileType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "File",
+	Fields: graphql.Fields{
+		"filename":    &graphql.Field{Type: graphql.String},
+		"contentType": &graphql.Field{Type: graphql.String},
+		"size":        &graphql.Field{Type: graphql.Int},
+		"content":     &graphql.Field{Type: graphql.String},
+	},
+})
+
+func describeFile(file *upload.File) (interface{}, error) {
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"filename":    file.Filename,
+		"contentType": file.ContentType,
+		"size":        file.Size,
+		"content":     string(content),
+	}, nil
+}
+
+func uploadSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"ok": &graphql.Field{
+					Type: graphql.Boolean,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return true, nil
+					},
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"singleUpload": &graphql.Field{
+					Type: fileType,
+					Args: graphql.FieldConfigArgument{
+						"file": &graphql.ArgumentConfig{Type: graphql.NewNonNull(upload.Upload)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return describeFile(p.Args["file"].(*upload.File))
+					},
+				},
+				"multipleUpload": &graphql.Field{
+					Type: graphql.NewList(fileType),
+					Args: graphql.FieldConfigArgument{
+						"files": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(upload.Upload))},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						var files []interface{}
+						for _, file := range p.Args["files"].([]interface{}) {
+							file := file.(*upload.File)
+							file.Seek(0, 0)
+							described, err := describeFile(file)
+							if err != nil {
+								return nil, err
+							}
+							files = append(files, described)
+						}
+						return files, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+type testFile struct {
+	field, filename, content string
+}
+
+func multipartRequest(t *testing.T, url, operations, fileMap string, files ...testFile) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("operations", operations)
+	writer.WriteField("map", fileMap)
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.field, file.filename)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		part.Write([]byte(file.content))
+	}
+	writer.Close()
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// serve runs req against a handler storing files in a temporary directory,
+// and checks the directory is empty once the response is written.
+func serve(t *testing.T, limits upload.Limits, req func(url string) *http.Request) (int, interface{}) {
+	dir, err := ioutil.TempDir("", "upload-test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	limits.TempDir = dir
+
+	server := httptest.NewServer(upload.NewHandler(uploadSchema(t), limits))
+	defer server.Close()
+	resp, err := http.DefaultClient.Do(req(server.URL))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	var body interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	remaining, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("expected temporary files to be removed, found %v", len(remaining))
+	}
+	return resp.StatusCode, body
+}
+
+func TestHandler_SingleUpload(t *testing.T) {
+	status, body := serve(t, upload.Limits{}, func(url string) *http.Request {
+		return multipartRequest(t, url,
+			`{"query": "mutation ($file: Upload!) { singleUpload(file: $file) { filename contentType size content } }", "variables": {"file": null}}`,
+			`{"0": ["variables.file"]}`,
+			testFile{"0", "a.txt", "Alpha file content."},
+		)
+	})
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{
+			"singleUpload": map[string]interface{}{
+				"filename":    "a.txt",
+				"contentType": "application/octet-stream",
+				"size":        float64(19),
+				"content":     "Alpha file content.",
+			},
+		},
+	}
+	if status != http.StatusOK || !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result (%v), Diff: %v", status, testutil.Diff(expected, body))
+	}
+}
+
+func TestHandler_BatchWithFileLists(t *testing.T) {
+	status, body := serve(t, upload.Limits{}, func(url string) *http.Request {
+		return multipartRequest(t, url,
+			`[
+				{"query": "mutation ($file: Upload!) { singleUpload(file: $file) { content } }", "variables": {"file": null}},
+				{"query": "mutation ($files: [Upload!]) { multipleUpload(files: $files) { filename content } }", "variables": {"files": [null, null]}}
+			]`,
+			`{"0": ["0.variables.file", "1.variables.files.1"], "1": ["1.variables.files.0"]}`,
+			testFile{"0", "a.txt", "a"},
+			testFile{"1", "b.txt", "b"},
+		)
+	})
+	expected := []interface{}{
+		map[string]interface{}{
+			"data": map[string]interface{}{
+				"singleUpload": map[string]interface{}{"content": "a"},
+			},
+		},
+		map[string]interface{}{
+			"data": map[string]interface{}{
+				"multipleUpload": []interface{}{
+					map[string]interface{}{"filename": "b.txt", "content": "b"},
+					map[string]interface{}{"filename": "a.txt", "content": "a"},
+				},
+			},
+		},
+	}
+	if status != http.StatusOK || !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result (%v), Diff: %v", status, testutil.Diff(expected, body))
+	}
+}
+
+func TestHandler_ExecutesJSONRequests(t *testing.T) {
+	status, body := serve(t, upload.Limits{}, func(url string) *http.Request {
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"query": "{ ok }"}`))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	})
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{"ok": true},
+	}
+	if status != http.StatusOK || !reflect.DeepEqual(expected, body) {
+		t.Fatalf("Unexpected result (%v), Diff: %v", status, testutil.Diff(expected, body))
+	}
+}
+
+func TestHandler_RejectsInvalidRequests(t *testing.T) {
+	const single = `{"query": "mutation ($file: Upload!) { singleUpload(file: $file) { size } }", "variables": {"file": null}}`
+	tests := []struct {
+		name     string
+		limits   upload.Limits
+		req      func(url string) *http.Request
+		status   int
+		expected string
+	}{
+		{
+			name:   "file too large",
+			limits: upload.Limits{MaxFileSize: 4},
+			req: func(url string) *http.Request {
+				return multipartRequest(t, url, single, `{"0": ["variables.file"]}`, testFile{"0", "a.txt", "12345"})
+			},
+			status:   http.StatusRequestEntityTooLarge,
+			expected: "File exceeds the maximum size of 4 bytes",
+		},
+		{
+			name:   "too many files",
+			limits: upload.Limits{MaxFiles: 1},
+			req: func(url string) *http.Request {
+				return multipartRequest(t, url, single, `{"0": ["variables.file"], "1": ["variables.other"]}`,
+					testFile{"0", "a.txt", "a"}, testFile{"1", "b.txt", "b"})
+			},
+			status:   http.StatusRequestEntityTooLarge,
+			expected: "Request exceeds the maximum of 1 files",
+		},
+		{
+			name: "missing file",
+			req: func(url string) *http.Request {
+				return multipartRequest(t, url, single, `{"0": ["variables.file"]}`)
+			},
+			status:   http.StatusBadRequest,
+			expected: `File field "0" is missing`,
+		},
+		{
+			name: "invalid path",
+			req: func(url string) *http.Request {
+				return multipartRequest(t, url, single, `{"0": ["query"]}`, testFile{"0", "a.txt", "a"})
+			},
+			status:   http.StatusBadRequest,
+			expected: `Invalid map path "query", files must be set in variables`,
+		},
+		{
+			name: "misordered fields",
+			req: func(url string) *http.Request {
+				var body bytes.Buffer
+				writer := multipart.NewWriter(&body)
+				writer.WriteField("map", `{}`)
+				writer.WriteField("operations", single)
+				writer.Close()
+				req, _ := http.NewRequest(http.MethodPost, url, &body)
+				req.Header.Set("Content-Type", writer.FormDataContentType())
+				return req
+			},
+			status:   http.StatusBadRequest,
+			expected: `Expected the operations field, found "map"`,
+		},
+		{
+			name:   "body too large",
+			limits: upload.Limits{MaxBodySize: 16},
+			req: func(url string) *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"query": "{ ok }"}`))
+				req.Header.Set("Content-Type", "application/json")
+				return req
+			},
+			status:   http.StatusRequestEntityTooLarge,
+			expected: "Request body exceeds the maximum size of 16 bytes",
+		},
+	}
+	for _, test := range tests {
+		status, body := serve(t, test.limits, test.req)
+		expected := map[string]interface{}{
+			"data": nil,
+			"errors": []interface{}{
+				map[string]interface{}{"message": test.expected, "locations": nil},
+			},
+		}
+		if status != test.status || !reflect.DeepEqual(expected, body) {
+			t.Fatalf("%v: unexpected result (%v), Diff: %v", test.name, status, testutil.Diff(expected, body))
+		}
+	}
+}
+
+func TestUpload_RejectsLiteralsAndStrings(t *testing.T) {
+	schema := uploadSchema(t)
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { singleUpload(file: "a.txt") { size } }`,
+	})
+	expected := `Expected value of type "Upload!", found "a.txt"; ` +
+		"Upload literals are not supported, send files as variables of a multipart request"
+	if len(result.Errors) != 1 || result.Errors[0].Message != expected {
+		t.Fatalf("expected error %q, got %v", expected, result.Errors)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `mutation ($file: Upload!) { singleUpload(file: $file) { size } }`,
+		VariableValues: map[string]interface{}{"file": "a.txt"},
+	})
+	expected = `Variable "$file" got invalid value "a.txt".` +
+		"\nExpected type \"Upload\", found \"a.txt\"; Upload value must be a file sent in a multipart request"
+	if len(result.Errors) != 1 || result.Errors[0].Message != expected {
+		t.Fatalf("expected error %q, got %v", expected, result.Errors)
+	}
+}
@@ -1,6 +1,8 @@
 package graphql
 
 import (
+	"context"
+
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/kinds"
@@ -27,6 +29,16 @@ type ValidationResult struct {
  */
 
 func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRuleFn) (vr ValidationResult) {
+	return ValidateDocumentWithContext(context.Background(), schema, astDoc, rules)
+}
+
+// ValidateDocumentWithContext is ValidateDocument for the request of ctx:
+// the fields hidden from it by the visibility of the schema are unknown to
+// the rules, and ctx is provided to them by ValidationContext.Context.
+func ValidateDocumentWithContext(ctx context.Context, schema *Schema, astDoc *ast.Document, rules []ValidationRuleFn) (vr ValidationResult) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	if len(rules) == 0 {
 		rules = SpecifiedRules
 	}
@@ -41,9 +53,28 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 	}
 
 	typeInfo := NewTypeInfo(&TypeInfoConfig{
-		Schema: schema,
+		Schema:     schema,
+		FieldDefFn: visibleFieldDefFn(ctx),
 	})
-	vr.Errors = VisitUsingRules(schema, typeInfo, astDoc, rules)
+	vr.Errors = visitUsingRules(ctx, schema, typeInfo, astDoc, rules)
+	if len(vr.Errors) == 0 {
+		vr.IsValid = true
+	}
+	return vr
+}
+
+// ValidateSDL validates a document of type definitions (SDL) with
+// SpecifiedSDLRules, before building or extending a schema from it.
+//
+// If existing is not nil, the document is validated as an extension of it:
+// its types and directives are known, and may not be defined again.
+func ValidateSDL(astDoc *ast.Document, existing *Schema) (vr ValidationResult) {
+	if astDoc == nil {
+		vr.Errors = append(vr.Errors, gqlerrors.NewFormattedError("Must provide document"))
+		return vr
+	}
+
+	vr.Errors = VisitSDLUsingRules(existing, astDoc, SpecifiedSDLRules)
 	if len(vr.Errors) == 0 {
 		vr.IsValid = true
 	}
@@ -57,8 +88,12 @@ func ValidateDocument(schema *Schema, astDoc *ast.Document, rules []ValidationRu
 // Had to expose it to unit test experimental customizable validation feature,
 // but not meant for public consumption
 func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) []gqlerrors.FormattedError {
+	return visitUsingRules(context.Background(), schema, typeInfo, astDoc, rules)
+}
 
+func visitUsingRules(ctx context.Context, schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, rules []ValidationRuleFn) []gqlerrors.FormattedError {
 	context := NewValidationContext(schema, astDoc, typeInfo)
+	context.context = ctx
 	visitors := []*visitor.VisitorOptions{}
 
 	for _, rule := range rules {
@@ -71,6 +106,20 @@ func VisitUsingRules(schema *Schema, typeInfo *TypeInfo, astDoc *ast.Document, r
 	return context.Errors()
 }
 
+// VisitSDLUsingRules is VisitUsingRules for documents of type definitions,
+// with existing being the schema they extend, if any. Unlike VisitUsingRules,
+// it does not provide type information to the rules.
+func VisitSDLUsingRules(existing *Schema, astDoc *ast.Document, rules []ValidationRuleFn) []gqlerrors.FormattedError {
+	context := NewValidationContext(existing, astDoc, nil)
+	context.sdl = true
+	visitors := []*visitor.VisitorOptions{}
+	for _, rule := range rules {
+		visitors = append(visitors, rule(context).VisitorOpts)
+	}
+	visitor.Visit(astDoc, visitor.VisitInParallel(visitors...), nil)
+	return context.Errors()
+}
+
 type HasSelectionSet interface {
 	GetKind() string
 	GetLoc() *ast.Location
@@ -95,6 +144,11 @@ type ValidationContext struct {
 	recursiveVariableUsages        map[*ast.OperationDefinition][]*VariableUsage
 	recursivelyReferencedFragments map[*ast.OperationDefinition][]*ast.FragmentDefinition
 	fragmentSpreads                map[*ast.SelectionSet][]*ast.FragmentSpread
+	// sdl is set when validating a document of type definitions, in which
+	// case schema is the schema being extended, if any, and typeInfo is nil.
+	sdl bool
+	// context is the context of the request the document is validated for.
+	context context.Context
 }
 
 func NewValidationContext(schema *Schema, astDoc *ast.Document, typeInfo *TypeInfo) *ValidationContext {
@@ -107,6 +161,7 @@ func NewValidationContext(schema *Schema, astDoc *ast.Document, typeInfo *TypeIn
 		recursiveVariableUsages:        map[*ast.OperationDefinition][]*VariableUsage{},
 		recursivelyReferencedFragments: map[*ast.OperationDefinition][]*ast.FragmentDefinition{},
 		fragmentSpreads:                map[*ast.SelectionSet][]*ast.FragmentSpread{},
+		context:                        context.Background(),
 	}
 }
 
@@ -124,6 +179,12 @@ func (ctx *ValidationContext) Schema() *Schema {
 func (ctx *ValidationContext) Document() *ast.Document {
 	return ctx.astDoc
 }
+
+// Context returns the context of the request the document is validated for,
+// see ValidateDocumentWithContext.
+func (ctx *ValidationContext) Context() context.Context {
+	return ctx.context
+}
 func (ctx *ValidationContext) Fragment(name string) *ast.FragmentDefinition {
 	if len(ctx.fragments) == 0 {
 		if ctx.Document() == nil {
@@ -95,3 +95,75 @@ func TestValidator_SupportsFullValidation_ValidatesUsingACustomTypeInfo(t *testi
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, errors))
 	}
 }
+
+func TestValidator_ValidateSDL_ReportsErrorsWithSourceLocations(t *testing.T) {
+	source := source.NewSource(&source.Source{
+		Body: []byte(`
+      type Query {
+        dog: Dog
+        dog: Dog
+      }
+
+      extend type Cat {
+        name: String
+      }
+
+      directive @skip on FIELD
+    `),
+		Name: "schema.graphql",
+	})
+	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result := graphql.ValidateSDL(AST, nil)
+	expectedErrors := []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot extend type "Cat" because it is not defined.`, 7, 19),
+		testutil.RuleError(`Field "Query.dog" can only be defined once.`, 3, 9, 4, 9),
+		testutil.RuleError(`Unknown type "Dog".`, 3, 14),
+		testutil.RuleError(`Unknown type "Dog".`, 4, 14),
+	}
+	if result.IsValid || !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+
+	result = graphql.ValidateSDL(AST, testutil.TestSchema)
+	expectedErrors = []gqlerrors.FormattedError{
+		testutil.RuleError(`Field "Query.dog" can only be defined once.`, 3, 9, 4, 9),
+		testutil.RuleError(`Field "Cat.name" already exists in the schema. It cannot also be defined in this type extension.`, 8, 9),
+		testutil.RuleError(`Directive "@skip" already exists in the schema. It cannot be redefined.`, 11, 18),
+	}
+	if result.IsValid || !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
+
+func TestValidator_ValidatesPartialDocumentsOfTolerantParsing(t *testing.T) {
+	AST, err := parser.Parse(parser.ParseParams{
+		Source: `
+      query {
+        dog {
+          ... on { name }
+          unknownField
+        }
+      }
+      query Broken(
+    `,
+		Options: parser.ParseOptions{Tolerant: true},
+	})
+	if _, ok := err.(gqlerrors.SyntaxErrors); !ok || AST == nil {
+		t.Fatalf("Expected a partial document and syntax errors, got: %v", err)
+	}
+	if formatted := gqlerrors.FormatErrors(err); len(formatted) != 2 {
+		t.Fatalf("Expected two syntax errors, got: %v", formatted)
+	}
+
+	result := graphql.ValidateDocument(testutil.TestSchema, AST, nil)
+	expectedErrors := []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot query field "unknownField" on type "Dog".`, 5, 11),
+	}
+	if !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedErrors, result.Errors))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/graphql-go/graphql/gqlerrors"
@@ -166,7 +167,7 @@ func coerceValue(ttype Input, value interface{}) interface{} {
 		}
 		return obj
 	case *Scalar:
-		if parsed := ttype.ParseValue(value); !isNullish(parsed) {
+		if parsed := ttype.ParseValue(value); !isNullish(parsed) && !isParseError(parsed) {
 			return parsed
 		}
 	case *Enum:
@@ -283,7 +284,11 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 		}
 		return (len(messagesReduce) == 0), messagesReduce
 	case *Scalar:
-		if parsedVal := ttype.ParseValue(value); isNullish(parsedVal) {
+		parsedVal := ttype.ParseValue(value)
+		if err, ok := parsedVal.(error); ok {
+			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v"; %v`, ttype.Name(), value, err)}
+		}
+		if isNullish(parsedVal) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
 		}
 	case *Enum:
@@ -404,7 +409,11 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 		}
 		return obj
 	case *Scalar:
-		return ttype.ParseLiteral(valueAST)
+		parsed := ttype.ParseLiteral(substituteVariables(valueAST, variables))
+		if isParseError(parsed) {
+			return nil
+		}
+		return parsed
 	case *Enum:
 		return ttype.ParseLiteral(valueAST)
 	}
@@ -412,6 +421,102 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 	return nil
 }
 
+// isParseError reports whether a scalar rejected a value by returning an
+// error instead of nil.
+func isParseError(parsed interface{}) bool {
+	_, ok := parsed.(error)
+	return ok
+}
+
+// substituteVariables replaces the variables nested in list and object
+// literals with the literal form of their values, so that scalars accepting
+// such literals, e.g. JSON, can parse them. Null and absent variables are
+// replaced with ast.NullValue.
+func substituteVariables(valueAST ast.Value, variables map[string]interface{}) ast.Value {
+	switch valueAST := valueAST.(type) {
+	case *ast.Variable:
+		if valueAST.Name == nil {
+			return ast.NewNullValue(&ast.NullValue{Loc: valueAST.Loc})
+		}
+		return astFromUntypedValue(variables[valueAST.Name.Value])
+	case *ast.ListValue:
+		values := []ast.Value{}
+		for _, itemAST := range valueAST.Values {
+			if itemAST = substituteVariables(itemAST, variables); itemAST != nil {
+				values = append(values, itemAST)
+			}
+		}
+		return ast.NewListValue(&ast.ListValue{
+			Loc:    valueAST.Loc,
+			Values: values,
+		})
+	case *ast.ObjectValue:
+		fields := []*ast.ObjectField{}
+		for _, field := range valueAST.Fields {
+			if value := substituteVariables(field.Value, variables); value != nil {
+				fields = append(fields, ast.NewObjectField(&ast.ObjectField{
+					Loc:   field.Loc,
+					Name:  field.Name,
+					Value: value,
+				}))
+			}
+		}
+		return ast.NewObjectValue(&ast.ObjectValue{
+			Loc:    valueAST.Loc,
+			Fields: fields,
+		})
+	}
+	return valueAST
+}
+
+// astFromUntypedValue returns the literal form of a value decoded from JSON.
+func astFromUntypedValue(value interface{}) ast.Value {
+	switch value := value.(type) {
+	case nil:
+		return ast.NewNullValue(nil)
+	case string:
+		return ast.NewStringValue(&ast.StringValue{Value: value})
+	case bool:
+		return ast.NewBooleanValue(&ast.BooleanValue{Value: value})
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return ast.NewIntValue(&ast.IntValue{Value: fmt.Sprintf("%d", value)})
+	case float32:
+		return ast.NewFloatValue(&ast.FloatValue{Value: strconv.FormatFloat(float64(value), 'g', -1, 32)})
+	case float64:
+		return ast.NewFloatValue(&ast.FloatValue{Value: strconv.FormatFloat(value, 'g', -1, 64)})
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return ast.NewIntValue(&ast.IntValue{Value: value.String()})
+		}
+		return ast.NewFloatValue(&ast.FloatValue{Value: value.String()})
+	case []interface{}:
+		values := []ast.Value{}
+		for _, item := range value {
+			if itemAST := astFromUntypedValue(item); itemAST != nil {
+				values = append(values, itemAST)
+			}
+		}
+		return ast.NewListValue(&ast.ListValue{Values: values})
+	case map[string]interface{}:
+		names := []string{}
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := []*ast.ObjectField{}
+		for _, name := range names {
+			if fieldAST := astFromUntypedValue(value[name]); fieldAST != nil {
+				fields = append(fields, ast.NewObjectField(&ast.ObjectField{
+					Name:  ast.NewName(&ast.Name{Value: name}),
+					Value: fieldAST,
+				}))
+			}
+		}
+		return ast.NewObjectValue(&ast.ObjectValue{Fields: fields})
+	}
+	return nil
+}
+
 func invariant(condition bool, message string) error {
 	if !condition {
 		return gqlerrors.NewFormattedError(message)
@@ -0,0 +1,61 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// SchemaVisibility decides whether the field fieldDef of parentType, an
+// Object or an Interface, is visible to the request of ctx, for instance
+// hiding internal fields from some of the clients.
+//
+// The fields it returns false for behave as if they did not exist: the
+// documents selecting them fail validation with FieldsOnCorrectTypeRule and
+// they are neither suggested nor introspected. The executor leaves them out
+// of the results of documents which are executed without validation.
+// Introspection fields are always visible, see DisableIntrospectionRule.
+type SchemaVisibility func(ctx context.Context, parentType Type, fieldDef *FieldDefinition) bool
+
+// isFieldVisible reports whether the field fieldDef of parentType is visible
+// to the request of ctx.
+func (gq *Schema) isFieldVisible(ctx context.Context, parentType Type, fieldDef *FieldDefinition) bool {
+	if gq.visibility == nil || fieldDef == nil {
+		return true
+	}
+	switch fieldDef {
+	case SchemaMetaFieldDef, TypeMetaFieldDef, TypeNameMetaFieldDef:
+		return true
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return gq.visibility(ctx, parentType, fieldDef)
+}
+
+// visibleFields returns the fields of parentType visible to the request of
+// ctx.
+func (gq *Schema) visibleFields(ctx context.Context, parentType Type, fields FieldDefinitionMap) FieldDefinitionMap {
+	if gq.visibility == nil {
+		return fields
+	}
+	visible := FieldDefinitionMap{}
+	for name, fieldDef := range fields {
+		if gq.isFieldVisible(ctx, parentType, fieldDef) {
+			visible[name] = fieldDef
+		}
+	}
+	return visible
+}
+
+// visibleFieldDefFn returns the function TypeInfo finds the fields of the
+// document with, leaving out the ones hidden from the request of ctx.
+func visibleFieldDefFn(ctx context.Context) fieldDefFn {
+	return func(schema *Schema, parentType Type, fieldAST *ast.Field) *FieldDefinition {
+		fieldDef := DefaultTypeInfoFieldDef(schema, parentType, fieldAST)
+		if !schema.isFieldVisible(ctx, parentType, fieldDef) {
+			return nil
+		}
+		return fieldDef
+	}
+}
@@ -0,0 +1,176 @@
+package graphql_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+type roleKey struct{}
+
+// visibilityTestSchema returns a schema whose User.email field is visible to
+// the staff only.
+func visibilityTestSchema(t *testing.T) graphql.Schema {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"name":  &graphql.Field{Type: graphql.String},
+			"email": &graphql.Field{Type: graphql.String},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return map[string]interface{}{"name": "Luke", "email": "luke@example.com"}, nil
+					},
+				},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Fields: graphql.Fields{
+				"userChanged": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source, nil
+					},
+					Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+						c := make(chan interface{}, 1)
+						c <- map[string]interface{}{"name": "Luke", "email": "luke@example.com"}
+						close(c)
+						return c, nil
+					},
+				},
+			},
+		}),
+		Visibility: func(ctx context.Context, parentType graphql.Type, fieldDef *graphql.FieldDefinition) bool {
+			return parentType.Name() != "User" || fieldDef.Name != "email" || ctx.Value(roleKey{}) == "staff"
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return schema
+}
+
+func TestVisibility_HiddenFieldsFailValidation(t *testing.T) {
+	schema := visibilityTestSchema(t)
+	staff := context.WithValue(context.Background(), roleKey{}, "staff")
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ user { name email } }`, Context: staff})
+	expected := map[string]interface{}{"user": map[string]interface{}{"name": "Luke", "email": "luke@example.com"}}
+	if len(result.Errors) > 0 || !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+
+	result = graphql.Do(graphql.Params{Schema: schema, RequestString: `{ user { name email } }`, Context: context.Background()})
+	expectedErrors := []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot query field "email" on type "User".`, 1, 15),
+	}
+	if result.Data != nil || !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
+
+func TestVisibility_HiddenFieldsFailSubscriptionValidation(t *testing.T) {
+	schema := visibilityTestSchema(t)
+	query := `subscription { userChanged { name email } }`
+	staff := context.WithValue(context.Background(), roleKey{}, "staff")
+
+	var results []*graphql.Result
+	for result := range graphql.Subscribe(graphql.Params{Schema: schema, RequestString: query, Context: staff}) {
+		results = append(results, result)
+	}
+	expected := map[string]interface{}{"userChanged": map[string]interface{}{"name": "Luke", "email": "luke@example.com"}}
+	if len(results) != 1 || len(results[0].Errors) > 0 || !reflect.DeepEqual(expected, results[0].Data) {
+		t.Fatalf("unexpected results: %v", results)
+	}
+
+	results = nil
+	for result := range graphql.Subscribe(graphql.Params{Schema: schema, RequestString: query, Context: context.Background()}) {
+		results = append(results, result)
+	}
+	expectedErrors := []gqlerrors.FormattedError{
+		testutil.RuleError(`Cannot query field "email" on type "User".`, 1, 35),
+	}
+	if len(results) != 1 || results[0].Data != nil || !testutil.EqualFormattedErrors(expectedErrors, results[0].Errors) {
+		t.Fatalf("unexpected results: %v", results)
+	}
+}
+
+func TestVisibility_HiddenFieldsAreNotSuggested(t *testing.T) {
+	schema := visibilityTestSchema(t)
+	doc := testutil.TestParse(t, `{ user { emai } }`)
+	staff := context.WithValue(context.Background(), roleKey{}, "staff")
+
+	tests := map[context.Context]string{
+		staff:                `Cannot query field "emai" on type "User". Did you mean "email"?`,
+		context.Background(): `Cannot query field "emai" on type "User".`,
+	}
+	for ctx, message := range tests {
+		result := graphql.ValidateDocumentWithContext(ctx, &schema, doc, nil)
+		if len(result.Errors) != 1 || result.Errors[0].Message != message {
+			t.Fatalf("expected %q, got %v", message, result.Errors)
+		}
+	}
+}
+
+func TestVisibility_HiddenFieldsAreNotIntrospected(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        visibilityTestSchema(t),
+		RequestString: `{ __type(name: "User") { fields { name } } }`,
+		Context:       context.Background(),
+	})
+	expected := map[string]interface{}{
+		"__type": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{"name": "name"},
+			},
+		},
+	}
+	if len(result.Errors) > 0 || !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
+
+func TestVisibility_HiddenFieldsAreNotExecuted(t *testing.T) {
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:  visibilityTestSchema(t),
+		AST:     testutil.TestParse(t, `{ user { name email } }`),
+		Context: context.Background(),
+	})
+	expected := map[string]interface{}{"user": map[string]interface{}{"name": "Luke"}}
+	if len(result.Errors) > 0 || !reflect.DeepEqual(expected, result.Data) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}
+
+func TestVisibility_IntrospectionCanBeDisabledPerRequest(t *testing.T) {
+	schema := visibilityTestSchema(t)
+	query := `{ __schema { queryType { name } } }`
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	if len(result.Errors) > 0 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:          schema,
+		RequestString:   query,
+		ValidationRules: append([]graphql.ValidationRuleFn{graphql.DisableIntrospectionRule}, graphql.SpecifiedRules...),
+	})
+	expectedErrors := []gqlerrors.FormattedError{
+		testutil.RuleError(`GraphQL introspection is disabled, but the query contained the field "__schema".`, 1, 3),
+	}
+	if result.Data != nil || !testutil.EqualFormattedErrors(expectedErrors, result.Errors) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+}